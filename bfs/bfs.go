@@ -0,0 +1,116 @@
+// Contains algos and logic related to breadth-first graph traversal.
+package bfs
+
+import (
+	"errors"
+
+	"github.com/sdboyer/gogl"
+)
+
+// A DepthStep is called once for each vertex reached by a breadth-first
+// traversal, along with the vertex's depth - its distance, in edges, from the
+// start vertex. Returning true terminates the traversal.
+type DepthStep func(v gogl.Vertex, depth int) (terminate bool)
+
+// Traverses the given graph in a breadth-first manner, beginning from the given
+// start vertex. Each reachable vertex is passed to the step function exactly
+// once, in nondecreasing order of depth.
+//
+// Arcs in digraphs and mixed graphs are only followed in their direction;
+// undirected edges are followed either way.
+func Traverse(g gogl.Graph, f DepthStep, start gogl.Vertex) error {
+	if !g.HasVertex(start) {
+		return errors.New("Start vertex is not present in graph.")
+	}
+
+	walk(g, start, func(v, parent gogl.Vertex, depth int) bool {
+		return f(v, depth)
+	})
+	return nil
+}
+
+// Finds a shortest path, by edge count, from the start vertex to the target
+// vertex in the provided graph.
+//
+// A slice of vertices is returned, ordered from the start to the target vertex.
+// If no path can be found, the returned slice is nil and an error is returned instead.
+//
+// Arcs in digraphs and mixed graphs are only followed in their direction;
+// undirected edges are followed either way.
+func ShortestPath(g gogl.Graph, start, target gogl.Vertex) ([]gogl.Vertex, error) {
+	if !g.HasVertex(target) {
+		return nil, errors.New("Target vertex is not present in graph.")
+	}
+	if !g.HasVertex(start) {
+		return nil, errors.New("Start vertex is not present in graph.")
+	}
+
+	parents := make(map[gogl.Vertex]gogl.Vertex)
+	var found bool
+	var length int
+	walk(g, start, func(v, parent gogl.Vertex, depth int) bool {
+		if depth > 0 {
+			parents[v] = parent
+		}
+		found, length = v == target, depth
+		return found
+	})
+
+	if !found {
+		return nil, errors.New("No path exists from the start vertex to the target vertex.")
+	}
+
+	path := make([]gogl.Vertex, length+1)
+	for v, i := target, length; i >= 0; i-- {
+		path[i] = v
+		v = parents[v]
+	}
+
+	return path, nil
+}
+
+// The shared breadth-first walk. Each reached vertex is passed to the step
+// function along with the vertex from which it was reached (nil for the
+// start vertex) and its depth.
+func walk(g gogl.Graph, start gogl.Vertex, f func(v, parent gogl.Vertex, depth int) bool) {
+	next := neighbors(g)
+	depths := map[gogl.Vertex]int{start: 0}
+
+	if f(start, nil, 0) {
+		return
+	}
+
+	queue := []gogl.Vertex{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		depth := depths[v] + 1
+
+		var terminate bool
+		next(v, func(adj gogl.Vertex) bool {
+			if _, seen := depths[adj]; seen {
+				return false
+			}
+
+			depths[adj] = depth
+			queue = append(queue, adj)
+			terminate = f(adj, v, depth)
+			return terminate
+		})
+
+		if terminate {
+			return
+		}
+	}
+}
+
+// Picks the enumerator of vertices reachable in one step for the given graph.
+func neighbors(g gogl.Graph) func(gogl.Vertex, gogl.VertexStep) {
+	if dg, ok := g.(gogl.Digraph); ok {
+		return dg.SuccessorsOf
+	}
+	if mg, ok := g.(gogl.MixedGraph); ok {
+		return mg.SuccessorsOf
+	}
+	return g.AdjacentTo
+}
@@ -0,0 +1,125 @@
+package bfs
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+// A diamond with a tail: two equal-length routes from foo to baz.
+var bfArcSet = gogl.ArcList{
+	gogl.NewArc("foo", "bar"),
+	gogl.NewArc("foo", "quark"),
+	gogl.NewArc("bar", "baz"),
+	gogl.NewArc("quark", "baz"),
+	gogl.NewArc("baz", "qux"),
+}
+
+type BreadthFirstSuite struct{}
+
+var _ = Suite(&BreadthFirstSuite{})
+
+func (s *BreadthFirstSuite) TestTraverseDepths(c *C) {
+	g := gogl.Spec().Directed().Using(bfArcSet).Create(al.G)
+
+	depths := make(map[gogl.Vertex]int)
+	var last int
+	err := Traverse(g, func(v gogl.Vertex, depth int) (terminate bool) {
+		c.Assert(depth >= last, Equals, true)
+		last = depth
+		depths[v] = depth
+		return
+	}, "foo")
+
+	c.Assert(err, IsNil)
+	c.Assert(depths, DeepEquals, map[gogl.Vertex]int{
+		"foo":   0,
+		"bar":   1,
+		"quark": 1,
+		"baz":   2,
+		"qux":   3,
+	})
+
+	// arcs are not followed backwards
+	var hit int
+	Traverse(g, func(v gogl.Vertex, depth int) (terminate bool) {
+		hit++
+		return
+	}, "baz")
+	c.Assert(hit, Equals, 2)
+}
+
+func (s *BreadthFirstSuite) TestTraverseTermination(c *C) {
+	g := gogl.Spec().Directed().Using(bfArcSet).Create(al.G)
+
+	var hit int
+	Traverse(g, func(v gogl.Vertex, depth int) bool {
+		hit++
+		return depth == 1
+	}, "foo")
+	c.Assert(hit, Equals, 2)
+
+	err := Traverse(g, func(v gogl.Vertex, depth int) bool { return false }, "missing")
+	c.Assert(err, ErrorMatches, "Start vertex.*")
+}
+
+func (s *BreadthFirstSuite) TestShortestPath(c *C) {
+	g := gogl.Spec().Directed().Using(bfArcSet).Create(al.G)
+
+	path, err := ShortestPath(g, "foo", "qux")
+	c.Assert(err, IsNil)
+	c.Assert(path, HasLen, 4)
+	c.Assert(path[0], Equals, "foo")
+	c.Assert(path[2], Equals, "baz")
+	c.Assert(path[3], Equals, "qux")
+
+	path, err = ShortestPath(g, "foo", "foo")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"foo"})
+
+	path, err = ShortestPath(g, "qux", "foo")
+	c.Assert(path, IsNil)
+	c.Assert(err, ErrorMatches, "No path exists.*")
+
+	_, err = ShortestPath(g, "foo", "missing")
+	c.Assert(err, ErrorMatches, "Target vertex.*")
+}
+
+func (s *BreadthFirstSuite) TestShortestPathUndirected(c *C) {
+	g := gogl.Spec().Using(bfArcSet).Create(al.G)
+
+	path, err := ShortestPath(g, "qux", "bar")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"qux", "baz", "bar"})
+}
+
+func (s *BreadthFirstSuite) TestShortestPathMixed(c *C) {
+	el := gogl.EdgeList{
+		gogl.NewArc("foo", "bar"),
+		gogl.NewEdge("bar", "baz"),
+		gogl.NewArc("baz", "qux"),
+		gogl.NewArc("qux", "foo"),
+	}
+	g := gogl.Spec().Mixed().Using(el).Create(al.G)
+
+	path, err := ShortestPath(g, "baz", "foo")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"baz", "qux", "foo"})
+
+	// the undirected edge goes both ways, but the arcs do not
+	path, err = ShortestPath(g, "bar", "qux")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"bar", "baz", "qux"})
+
+	_, err = ShortestPath(g, "bar", "bar")
+	c.Assert(err, IsNil)
+
+	path, err = ShortestPath(g, "qux", "baz")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"qux", "foo", "bar", "baz"})
+}
@@ -1,14 +1,19 @@
 package gogl
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 /* Graph type constants. Used primarily for specs. */
 
 // Describes the properties of a graph as a bitfield.
 type GraphProperties uint16
 
 const (
-	// Edge directedness. Flags are provided for both, though gogl does not really support
-	// a hybrid graph containing both directed and undirected edges. Algorithms would have
-	// undefined results.
+	// Edge directedness. Setting both flags describes a mixed graph, containing both
+	// directed and undirected edges; see MixedGraph.
 	G_UNDIRECTED = 1 << iota
 	G_DIRECTED
 
@@ -29,6 +34,85 @@ const (
 	G_PERSISTENT = 1<<iota | G_MUTABLE // Persistent graphs are, kinda weirdly, both.
 )
 
+// Names for each of the GraphProperties bits, in bit order.
+var gpNames = []struct {
+	bit  GraphProperties
+	name string
+}{
+	{G_UNDIRECTED, "G_UNDIRECTED"},
+	{G_DIRECTED, "G_DIRECTED"},
+	{G_BASIC, "G_BASIC"},
+	{G_LABELED, "G_LABELED"},
+	{G_WEIGHTED, "G_WEIGHTED"},
+	{G_DATA, "G_DATA"},
+	{G_SIMPLE, "G_SIMPLE"},
+	{G_LOOPS, "G_LOOPS"},
+	{G_PARALLEL, "G_PARALLEL"},
+	{G_IMMUTABLE, "G_IMMUTABLE"},
+	{G_MUTABLE, "G_MUTABLE"},
+	{G_PERSISTENT &^ G_MUTABLE, "G_PERSISTENT"},
+}
+
+// Renders the set properties as a |-separated list of their constant names.
+func (gp GraphProperties) String() string {
+	if gp == 0 {
+		return "0"
+	}
+
+	var names []string
+	for _, n := range gpNames {
+		if gp&n.bit != 0 {
+			names = append(names, n.name)
+			gp &^= n.bit
+		}
+	}
+
+	if gp != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint16(gp)))
+	}
+
+	return strings.Join(names, "|")
+}
+
+// Implements encoding.TextMarshaler, using the same form as String().
+func (gp GraphProperties) MarshalText() ([]byte, error) {
+	return []byte(gp.String()), nil
+}
+
+// Implements encoding.TextUnmarshaler, accepting the form produced by String().
+func (gp *GraphProperties) UnmarshalText(text []byte) error {
+	var parsed GraphProperties
+
+	for _, name := range strings.Split(string(text), "|") {
+		name = strings.TrimSpace(name)
+		if name == "0" {
+			continue
+		}
+
+		var bit uint64
+		var err error
+		for _, n := range gpNames {
+			if n.name == name {
+				bit = uint64(n.bit)
+				break
+			}
+		}
+
+		if bit == 0 {
+			if !strings.HasPrefix(name, "0x") {
+				return fmt.Errorf("Unrecognized graph property %q.", name)
+			}
+			if bit, err = strconv.ParseUint(name[2:], 16, 16); err != nil {
+				return fmt.Errorf("Unrecognized graph property %q.", name)
+			}
+		}
+		parsed |= GraphProperties(bit)
+	}
+
+	*gp = parsed
+	return nil
+}
+
 /*
 TODO go back to using zero vals; see if the following can be made to work
 const (
@@ -56,6 +140,7 @@ func Spec() GraphSpec {
 // The GraphSource interface is used here because this is an ideal place
 // at which to load in, for example, graph data exported into a flat file;
 // a GraphSource can represent that data and only implement the minimal interface.
+// The encoding/flat package provides such sources for common flat file formats.
 func (b GraphSpec) Using(g GraphSource) GraphSpec {
 	b.Source = g
 	return b
@@ -75,6 +160,12 @@ func (b GraphSpec) Directed() GraphSpec {
 	return b
 }
 
+// Specify that the graph should be mixed, containing both directed and undirected edges.
+func (b GraphSpec) Mixed() GraphSpec {
+	b.Props |= G_UNDIRECTED | G_DIRECTED
+	return b
+}
+
 // Specify that the edges should be "basic" - no weights, labels, or data.
 func (b GraphSpec) Basic() GraphSpec {
 	b.Props &^= G_LABELED | G_WEIGHTED | G_DATA
@@ -103,6 +194,15 @@ func (b GraphSpec) DataEdges() GraphSpec {
 	return b
 }
 
+// Specify that the edges should carry a weight, a label and arbitrary data all
+// at once. This is equivalent to calling Weighted(), Labeled() and DataEdges();
+// as with those, edge types accumulate. See PropertyEdge
+func (b GraphSpec) PropertyEdges() GraphSpec {
+	b.Props &^= G_BASIC
+	b.Props |= G_WEIGHTED | G_LABELED | G_DATA
+	return b
+}
+
 // Specify that the graph should be simple - have no loops or multiple edges.
 func (b GraphSpec) SimpleGraph() GraphSpec {
 	b.Props &^= G_LOOPS | G_PARALLEL
@@ -167,3 +267,12 @@ func (b GraphSpec) Immutable() GraphSpec {
 func (b GraphSpec) Create(f func(GraphSpec) Graph) Graph {
 	return f(b)
 }
+
+// Creates a graph from the spec, using the provided error-returning creator
+// function. Creators should return an error, rather than panic, if they cannot
+// produce a graph satisfying the spec.
+//
+// A Registry's Create method is suitable for use here.
+func (b GraphSpec) TryCreate(f func(GraphSpec) (Graph, error)) (Graph, error) {
+	return f(b)
+}
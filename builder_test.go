@@ -36,6 +36,11 @@ func (s *SpecTestSuite) TestMutators(c *C) {
 		c.Assert(spec.Undirected().Props&G_DIRECTED == 0, Equals, true)
 	}
 
+	for _, spec.Props = range s.permuteField() {
+		c.Assert(spec.Mixed().Props&(G_UNDIRECTED|G_DIRECTED) == G_UNDIRECTED|G_DIRECTED, Equals, true)
+		c.Assert(spec.Mixed().Directed().Props&G_UNDIRECTED == 0, Equals, true)
+	}
+
 	for _, spec.Props = range s.permuteField() {
 		c.Assert(spec.Basic().Props&G_BASIC == G_BASIC, Equals, true)
 		c.Assert(spec.Basic().Props&(G_LABELED|G_WEIGHTED|G_DATA) == 0, Equals, true)
@@ -56,6 +61,11 @@ func (s *SpecTestSuite) TestMutators(c *C) {
 		c.Assert(spec.DataEdges().Props&G_BASIC == 0, Equals, true)
 	}
 
+	for _, spec.Props = range s.permuteField() {
+		c.Assert(spec.PropertyEdges().Props&(G_LABELED|G_WEIGHTED|G_DATA) == G_LABELED|G_WEIGHTED|G_DATA, Equals, true)
+		c.Assert(spec.PropertyEdges().Props&G_BASIC == 0, Equals, true)
+	}
+
 	for _, spec.Props = range s.permuteField() {
 		c.Assert(spec.SimpleGraph().Props&G_SIMPLE == G_SIMPLE, Equals, true)
 		c.Assert(spec.SimpleGraph().Props&(G_LOOPS|G_PARALLEL) == 0, Equals, true)
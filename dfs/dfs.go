@@ -2,7 +2,10 @@
 package dfs
 
 import (
+	"context"
 	"errors"
+	"runtime"
+	"sync"
 
 	"github.com/sdboyer/gogl"
 	"gopkg.in/fatih/set.v0"
@@ -20,6 +23,12 @@ const (
 // A slice of vertices is returned, identifying the path from the start to the target vertex.
 // If no path can be found, the returned slice is nil and an error is returned instead.
 func Search(g gogl.Graph, target gogl.Vertex, start gogl.Vertex) (path []gogl.Vertex, err error) {
+	return SearchContext(context.Background(), g, target, start)
+}
+
+// Performs a depth-first search as Search does, but abandons the search and
+// returns ctx.Err() if the provided context is cancelled before it completes.
+func SearchContext(ctx context.Context, g gogl.Graph, target gogl.Vertex, start gogl.Vertex) (path []gogl.Vertex, err error) {
 	if !g.HasVertex(target) {
 		return nil, errors.New("Target vertex is not present in graph.")
 	}
@@ -27,44 +36,123 @@ func Search(g gogl.Graph, target gogl.Vertex, start gogl.Vertex) (path []gogl.Ve
 		return nil, errors.New("Start vertex is not present in graph.")
 	}
 
+	path, _, err = search(ctx, g, target, start)
+	return
+}
+
+// Searches from a single start vertex, reporting whether the target was
+// found. Neither vertex is checked for presence in the graph.
+func search(ctx context.Context, g gogl.Graph, target, start gogl.Vertex) (path []gogl.Vertex, found bool, err error) {
 	visitor := &searchVisitor{}
 
 	w := walker{
-		vis:    visitor,
+		vis:    Adapt(visitor),
 		g:      g,
+		ctx:    ctx,
 		colors: make(map[gogl.Vertex]uint),
 		target: target,
+		search: true,
 	}
 
-	if dg, ok := g.(gogl.Digraph); ok {
-		w.dg = dg
+	if w.directionalize() {
+		w.dftraverse(start)
+	} else {
+		w.dfutraverse(start)
 	}
 
-	w.dfsearch(start)
-
-	return visitor.getPath(), nil
+	if w.err != nil {
+		return nil, false, w.err
+	}
+	return visitor.getPath(), w.complete, nil
 }
 
-// Performs a depth-first search for the given target vertex in the provided graph, using the vertices
-// provided to the third variadic parameter as starting points. For each given start vertex,
-// the search proceeds in a parallel goroutine until a path to the target vertex is found.
-// The resulting path is then sent back through the channel.
+// Performs depth-first searches for the given target vertex in the provided graph, one from
+// each of the vertices provided to the final variadic parameter. The searches proceed in
+// parallel, on at most the given number of goroutines; if workers is less than one,
+// GOMAXPROCS is used. The result of each search is sent on the returned channel as it
+// completes, and the channel is closed once all searches have completed.
 //
 // If no starting vertices are provided, then a list of source vertices is built via FindSources(),
 // and that set is used as the starting point. Because FindSources() requires a Digraph,
 // an error will be returned if a non-directed graph is provided without any start vertices.
 //
-// TODO unexported until this is actually implemented. keeping it here as a note for now :)
-func multiSearch(g gogl.Graph, target gogl.Vertex, start ...gogl.Vertex) (pathchan chan<- *searchPath, err error) {
-	start, err = buildStartQueue(g)
+// Cancelling the context abandons any searches in progress and any not yet begun; their
+// results are not sent. Callers that stop receiving before the channel is closed must cancel
+// the context, or the searching goroutines will leak.
+//
+// The searches only read from the graph, and hold none of its locks while waiting on one
+// another, so graphs such as those in the al package that guard reads with an RWMutex are
+// safe to search. The graph must not be mutated until the channel is closed.
+func MultiSearch(ctx context.Context, g gogl.Graph, target gogl.Vertex, workers int, start ...gogl.Vertex) (<-chan SearchPath, error) {
+	if !g.HasVertex(target) {
+		return nil, errors.New("Target vertex is not present in graph.")
+	}
+
+	start, err := buildStartQueue(g, start...)
 	if err != nil {
 		return nil, err
 	}
+	for _, v := range start {
+		if !g.HasVertex(v) {
+			return nil, errors.New("Start vertex is not present in graph.")
+		}
+	}
 
-	return
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(start) {
+		workers = len(start)
+	}
+
+	queue := make(chan gogl.Vertex)
+	paths := make(chan SearchPath)
+
+	go func() {
+		defer close(queue)
+		for _, v := range start {
+			select {
+			case queue <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for v := range queue {
+				path, found, err := search(ctx, g, target, v)
+				if err != nil {
+					// Only cancellation can fail a search; the queue is
+					// abandoned too, so there is nothing more to do.
+					return
+				}
+
+				select {
+				case paths <- SearchPath{Start: v, Target: target, Reachable: found, Path: path}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(paths)
+	}()
+
+	return paths, nil
 }
 
-type searchPath struct {
+// The result of one of the searches performed by MultiSearch. If the target is
+// reachable from the start vertex, Path holds the vertices of a path between
+// them, beginning with the target, as Search returns it.
+type SearchPath struct {
 	Start     gogl.Vertex
 	Target    gogl.Vertex
 	Reachable bool
@@ -79,6 +167,12 @@ type searchPath struct {
 // and that set is used as the starting point. Because FindSources() requires a Digraph,
 // an error will be returned if a non-directed graph is provided without any start vertices.
 func Toposort(g gogl.Graph, start ...gogl.Vertex) ([]gogl.Vertex, error) {
+	return ToposortContext(context.Background(), g, start...)
+}
+
+// Performs a topological sort as Toposort does, but abandons the sort and
+// returns ctx.Err() if the provided context is cancelled before it completes.
+func ToposortContext(ctx context.Context, g gogl.Graph, start ...gogl.Vertex) ([]gogl.Vertex, error) {
 	start, err := buildStartQueue(g, start...)
 	if err != nil {
 		return nil, err
@@ -104,33 +198,46 @@ func Toposort(g gogl.Graph, start ...gogl.Vertex) ([]gogl.Vertex, error) {
 	visitor := &TslVisitor{tsl: make([]gogl.Vertex, 0, capacity)}
 
 	w := &walker{
-		vis:    visitor,
 		g:      g,
+		ctx:    ctx,
 		colors: make(map[gogl.Vertex]uint),
 	}
 
+	// Cycles are of no consequence to an undirected topological sort.
+	directed := w.directionalize()
+	w.vis = &adapter{v: visitor, backEdges: directed}
+
 	var traverser func(*walker, gogl.Vertex)
-	if dg, ok := g.(gogl.Digraph); ok {
-		w.dg = dg
+	if directed {
 		traverser = (*walker).dftraverse
 	} else {
 		traverser = (*walker).dfutraverse
 	}
 
-	for v := stack.pop(); ; {
-		traverser(w, v)
-
-		if stack.length() == 0 {
-			break
-		}
+	for stack.length() > 0 && !w.halted() {
+		traverser(w, stack.pop())
 	}
 
+	if w.err != nil {
+		return nil, w.err
+	}
 	return visitor.GetTsl()
 }
 
 // Traverses the given graph in a depth-first manner, using the given visitor
 // and starting from the given vertices.
+//
+// If the visitor is an ActionVisitor, the actions it returns may prune parts
+// of the traversal, or stop it entirely.
 func Traverse(g gogl.Graph, visitor Visitor, start ...gogl.Vertex) (Visitor, error) {
+	return TraverseContext(context.Background(), g, visitor, start...)
+}
+
+// Traverses the given graph as Traverse does, but abandons the traversal and
+// returns ctx.Err() if the provided context is cancelled before it completes.
+// The visitor is returned either way, holding whatever it saw before the
+// traversal was abandoned.
+func TraverseContext(ctx context.Context, g gogl.Graph, visitor Visitor, start ...gogl.Vertex) (Visitor, error) {
 	start, err := buildStartQueue(g, start...)
 	if err != nil {
 		return nil, err
@@ -142,24 +249,62 @@ func Traverse(g gogl.Graph, visitor Visitor, start ...gogl.Vertex) (Visitor, err
 	}
 
 	w := &walker{
-		vis:    visitor,
+		vis:    Adapt(visitor),
 		g:      g,
+		ctx:    ctx,
 		colors: make(map[gogl.Vertex]uint),
 	}
 
-	if dg, ok := g.(gogl.Digraph); ok {
-		w.dg = dg
+	w.directionalize()
+
+	for stack.length() > 0 && !w.halted() {
+		w.dftraverse(stack.pop())
 	}
 
-	for v := stack.pop(); ; {
-		w.dftraverse(v)
+	return visitor, w.err
+}
 
-		if stack.length() == 0 {
-			break
-		}
+// Walks the given graph in a depth-first manner from the given vertices,
+// classifying each edge and timestamping each vertex for the given visitor.
+//
+// Unlike Traverse, Walk also walks undirected graphs, treating each edge as
+// leading in both directions. As with Traverse, start vertices are required
+// for undirected graphs; for directed graphs, they default to the graph's
+// sources.
+func Walk(g gogl.Graph, visitor ClassifyingVisitor, start ...gogl.Vertex) error {
+	return WalkContext(context.Background(), g, visitor, start...)
+}
+
+// Walks the given graph as Walk does, but abandons the walk and returns
+// ctx.Err() if the provided context is cancelled before it completes.
+func WalkContext(ctx context.Context, g gogl.Graph, visitor ClassifyingVisitor, start ...gogl.Vertex) error {
+	start, err := buildStartQueue(g, start...)
+	if err != nil {
+		return err
+	}
+
+	stack := vstack{}
+	for _, v := range start {
+		stack.push(v)
+	}
+
+	w := &walker{
+		vis:    visitor,
+		g:      g,
+		ctx:    ctx,
+		colors: make(map[gogl.Vertex]uint),
+	}
+
+	traverser := (*walker).dfutraverse
+	if w.directionalize() {
+		traverser = (*walker).dftraverse
+	}
+
+	for stack.length() > 0 && !w.halted() {
+		traverser(w, stack.pop())
 	}
 
-	return visitor, nil
+	return w.err
 }
 
 // Finds all source vertices (vertices with no incoming edges) in the given directed graph.
@@ -274,6 +419,15 @@ func (s *vstack) pop() gogl.Vertex {
 	return ret.v
 }
 
+// Returns the vertex at the top of the stack without removing it.
+func (s *vstack) peek() gogl.Vertex {
+	if s.top == nil {
+		return nil
+	}
+
+	return s.top.v
+}
+
 func (s *vstack) length() int {
 	return s.count
 }
@@ -285,6 +439,40 @@ type Visitor interface {
 	OnFinishVertex(vertex gogl.Vertex)
 }
 
+// An Action directs a traversal on how to proceed after a visitor has been
+// told of a vertex or edge.
+type Action int
+
+const (
+	// Proceed with the traversal as normal.
+	Continue Action = iota
+	// Do not proceed past the current vertex or edge. When returned for a
+	// vertex, none of its out-edges are examined, though the vertex is still
+	// finished; when returned for an edge, the edge is not followed.
+	Prune
+	// End the traversal immediately. No further vertices are started or
+	// finished, including those currently in progress.
+	Stop
+)
+
+// An ActionVisitor is a Visitor that can steer the traversal it is visiting.
+//
+// Traversals consult StartAction immediately after calling OnStartVertex, and
+// EdgeAction immediately after calling OnExamineEdge.
+//
+// A ClassifyingVisitor may implement the same two methods to the same effect.
+type ActionVisitor interface {
+	Visitor
+	StartAction(vertex gogl.Vertex) Action
+	EdgeAction(edge gogl.Edge) Action
+}
+
+// The methods by which a visitor of either kind may steer a walk.
+type actor interface {
+	StartAction(vertex gogl.Vertex) Action
+	EdgeAction(edge gogl.Edge) Action
+}
+
 type searchVisitor struct {
 	g     gogl.Graph
 	stack vstack
@@ -337,92 +525,290 @@ func (vis *TslVisitor) GetTsl() ([]gogl.Vertex, error) {
 }
 
 type walker struct {
-	vis      Visitor
+	vis      ClassifyingVisitor
 	g        gogl.Graph
 	dg       gogl.Digraph
+	mg       gogl.MixedGraph
+	ctx      context.Context
+	search   bool // whether the walk ends upon reaching target
 	complete bool
+	stopped  bool
+	err      error // ctx.Err(), if the walk was abandoned due to cancellation
+	time     int   // the discovery and finish time counter
 	target   gogl.Vertex
 	// TODO is there ANY way to do this more efficiently without mutating/coloring the vertex objects directly? this means lots of hashtable lookups
 	colors map[gogl.Vertex]uint
 	ll     linkedlist
+	// The undirected edges of a mixed graph already classified from one end,
+	// in a directed walk, by their endpoints in the order they were seen.
+	classified map[[2]gogl.Vertex]struct{}
 }
 
-func (w *walker) dftraverse(v gogl.Vertex) {
-	color, exists := w.colors[v]
-	if !exists {
-		color = white
+// Records the walker's graph as a digraph or mixed graph, if it is either,
+// reporting whether directed traversal is possible.
+func (w *walker) directionalize() bool {
+	if dg, ok := w.g.(gogl.Digraph); ok {
+		w.dg = dg
+		return true
+	}
+	if mg, ok := w.g.(gogl.MixedGraph); ok {
+		w.mg = mg
+		return true
 	}
+	return false
+}
 
-	if color == grey {
-		w.vis.OnBackEdge(v)
-	} else if color == white {
-		w.colors[v] = grey
-		w.vis.OnStartVertex(v)
+// Reports whether the walk should go no further, either because the visitor
+// asked to stop or because the context has been cancelled.
+func (w *walker) halted() bool {
+	if w.stopped {
+		return true
+	}
+	if w.ctx != nil {
+		if err := w.ctx.Err(); err != nil {
+			w.err = err
+			w.stopped = true
+		}
+	}
+	return w.stopped
+}
 
-		w.dg.ArcsFrom(v, func(e gogl.Arc) (terminate bool) {
-			w.vis.OnExamineEdge(e)
-			w.dftraverse(e.Target())
-			return
-		})
+// Consults the visitor, if it is an ActionVisitor, on how to proceed after a
+// vertex has been started. Returns true if the vertex's out-edges should be
+// skipped.
+func (w *walker) startAction(v gogl.Vertex) (prune bool) {
+	if av, ok := w.vis.(actor); ok {
+		switch av.StartAction(v) {
+		case Prune:
+			return true
+		case Stop:
+			w.stopped = true
+			return true
+		}
+	}
+	return false
+}
 
-		w.vis.OnFinishVertex(v)
-		w.colors[v] = black
+// Consults the visitor, if it is an ActionVisitor, on how to proceed after an
+// edge has been examined. Returns true if the edge should not be followed.
+func (w *walker) edgeAction(e gogl.Edge) (prune bool) {
+	if av, ok := w.vis.(actor); ok {
+		switch av.EdgeAction(e) {
+		case Prune:
+			return true
+		case Stop:
+			w.stopped = true
+			return true
+		}
 	}
+	return false
 }
 
-func (w *walker) dfsearch(v gogl.Vertex) {
-	if v == w.target {
-		w.complete = true
-		w.vis.OnStartVertex(v)
+// Enumerates the edges along which a directed walk may proceed from the given
+// vertex, passing each to the step function along with the vertex at its far end.
+//
+// In a mixed graph, both out-arcs and undirected edges are followed. If v was
+// reached along an undirected edge, that edge is skipped, as following it back
+// would be mistaken for a back edge; any other edge back to the vertex from
+// which v was reached, such as an undirected one alongside the arc that was
+// followed, is still passed.
+func (w *walker) eachOutEdge(v gogl.Vertex, via gogl.Edge, f func(gogl.Edge, gogl.Vertex) bool) {
+	if w.mg == nil {
+		w.dg.ArcsFrom(v, func(e gogl.Arc) bool {
+			return f(e, e.Target())
+		})
 		return
 	}
 
-	color, exists := w.colors[v]
-	if !exists {
-		color = white
+	var terminate bool
+	w.mg.ArcsFrom(v, func(e gogl.Arc) bool {
+		terminate = f(e, e.Target())
+		return terminate
+	})
+	if terminate {
+		return
 	}
 
-	if color == grey {
-		w.vis.OnBackEdge(v)
-	} else if color == white {
-		w.colors[v] = grey
-		w.vis.OnStartVertex(v)
+	// The far end of the undirected edge v was reached along, if it was.
+	var back gogl.Vertex
+	skip := via != nil && !isArc(via)
+	if skip {
+		back = far(via, v)
+	}
 
-		w.dg.ArcsFrom(v, func(e gogl.Arc) bool {
-			// no more new visits if complete
-			if !w.complete {
-				w.vis.OnExamineEdge(e)
-				w.dfsearch(e.Target())
-			}
-			return w.complete
-		})
-		// escape hatch
-		if w.complete {
-			return
+	w.mg.UndirectedIncidentTo(v, func(e gogl.Edge) bool {
+		u := far(e, v)
+		if skip && u == back {
+			skip = false
+			return false
 		}
+		return f(e, u)
+	})
+}
 
-		w.vis.OnFinishVertex(v)
-		w.colors[v] = black
+// Returns the end of the edge opposite v.
+func far(e gogl.Edge, v gogl.Vertex) gogl.Vertex {
+	u1, u2 := e.Both()
+	if u1 != v {
+		return u1
 	}
+	return u2
 }
 
+// A vertex that has been discovered by a walk, along with the edges leading
+// out of it that have yet to be examined. Frames are kept on the walk's stack
+// in place of the call stack, so that long paths cannot exhaust it.
+type frame struct {
+	v      gogl.Vertex
+	parent gogl.Vertex
+	via    gogl.Edge // the tree edge along which v was discovered, if any
+	edges  []outEdge
+}
+
+// An edge leading out of a vertex, paired with the vertex at its far end.
+type outEdge struct {
+	e    gogl.Edge
+	next gogl.Vertex
+}
+
+// Walks the graph depth-first from the given vertex, following out-edges.
+func (w *walker) dftraverse(v gogl.Vertex) {
+	w.walk(v, false)
+}
+
+// Walks the graph depth-first from the given vertex, treating all edges as
+// undirected.
 func (w *walker) dfutraverse(v gogl.Vertex) {
-	if _, exists := w.colors[v]; !exists {
-		w.colors[v] = grey
-		w.vis.OnStartVertex(v)
-
-		w.g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
-			w.vis.OnExamineEdge(e)
-			v1, v2 := e.Both()
-			if v == v1 {
-				w.dfutraverse(v2)
-			} else {
-				w.dfutraverse(v1)
-			}
+	w.walk(v, true)
+}
+
+// Advances and returns the walk's discovery and finish time counter.
+func (w *walker) tick() int {
+	t := w.time
+	w.time++
+	return t
+}
+
+// Performs a depth-first walk from the given vertex using an explicit stack
+// of frames. Visitor callbacks are made in the same order a recursive walk
+// would make them: each vertex is finished only once every edge out of it has
+// been examined and followed.
+func (w *walker) walk(start gogl.Vertex, undirected bool) {
+	if w.halted() {
+		return
+	}
+	if _, seen := w.colors[start]; seen {
+		return
+	}
+
+	stack := vstack{}
+	w.discover(&stack, start, nil, nil, undirected)
+
+	for stack.length() > 0 {
+		// escape hatch
+		if w.stopped || w.complete {
 			return
-		})
+		}
+
+		f := stack.peek().(*frame)
+		if len(f.edges) == 0 {
+			stack.pop()
+			w.colors[f.v] = black
+			w.vis.FinishVertex(f.v, w.tick())
+			if f.via != nil {
+				w.vis.FinishEdge(f.via)
+			}
+			continue
+		}
+
+		oe := f.edges[0]
+		f.edges = f.edges[1:]
+
+		mixed := !undirected && w.mg != nil && !isArc(oe.e)
+		if mixed && w.wasClassified(f.v, oe.next) {
+			continue
+		}
+
+		w.vis.ExamineEdge(oe.e)
+		if w.edgeAction(oe.e) || w.halted() {
+			continue
+		}
 
-		w.vis.OnFinishVertex(v)
-		w.colors[v] = black
+		color, seen := w.colors[oe.next]
+		switch {
+		case !seen:
+			w.vis.TreeEdge(oe.e)
+			w.discover(&stack, oe.next, f.v, oe.e, undirected)
+		case undirected && (color == black || oe.next == f.parent):
+			// The far side of an edge already classified from its other end.
+		case color == grey:
+			w.markClassified(mixed, f.v, oe.next)
+			w.vis.BackEdge(oe.e)
+			w.vis.FinishEdge(oe.e)
+		default:
+			w.markClassified(mixed, f.v, oe.next)
+			w.vis.ForwardOrCrossEdge(oe.e)
+			w.vis.FinishEdge(oe.e)
+		}
+	}
+}
+
+func isArc(e gogl.Edge) bool {
+	_, ok := e.(gogl.Arc)
+	return ok
+}
+
+// Records that the undirected edge between u and v has been classified, if
+// it is an undirected edge of a mixed graph being walked directionally; it
+// will be reached again from v, and must not be classified a second time.
+func (w *walker) markClassified(mixed bool, u, v gogl.Vertex) {
+	if !mixed {
+		return
+	}
+	if w.classified == nil {
+		w.classified = make(map[[2]gogl.Vertex]struct{})
+	}
+	w.classified[[2]gogl.Vertex{u, v}] = struct{}{}
+}
+
+// Reports whether the undirected edge between u and v was classified from its
+// other end.
+func (w *walker) wasClassified(u, v gogl.Vertex) bool {
+	_, seen := w.classified[[2]gogl.Vertex{v, u}]
+	return seen
+}
+
+// Discovers a vertex during a walk, pushing a frame holding its out-edges
+// onto the stack.
+func (w *walker) discover(stack *vstack, v, parent gogl.Vertex, via gogl.Edge, undirected bool) {
+	if w.search && v == w.target {
+		w.complete = true
+		w.vis.DiscoverVertex(v, w.tick())
+		return
 	}
+
+	w.colors[v] = grey
+	w.vis.DiscoverVertex(v, w.tick())
+
+	f := &frame{v: v, parent: parent, via: via}
+	if !w.startAction(v) {
+		if undirected {
+			w.g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+				v1, v2 := e.Both()
+				if v == v1 {
+					f.edges = append(f.edges, outEdge{e, v2})
+				} else {
+					f.edges = append(f.edges, outEdge{e, v1})
+				}
+				return
+			})
+		} else {
+			w.eachOutEdge(v, via, func(e gogl.Edge, next gogl.Vertex) (terminate bool) {
+				f.edges = append(f.edges, outEdge{e, next})
+				return
+			})
+		}
+	}
+
+	stack.push(f)
 }
@@ -1,6 +1,7 @@
 package dfs
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -41,14 +42,61 @@ func (s *DepthFirstSearchSuite) TestSearch(c *C) {
 	c.Assert(err, IsNil)
 
 	// undirected
-	//ug := gogl.BuildGraph().Using(extraSet).Create(al.AdjacencyList)
+	ug := gogl.Spec().Using(extraSet).Create(al.G)
 
-	// TODO accidentally passed wrong graph - fix!
-	path, err = Search(g, "qux", "bar")
+	path, err = Search(ug, "qux", "bar")
 	c.Assert(path, DeepEquals, []gogl.Vertex{"qux", "baz", "bar"})
 	c.Assert(err, IsNil)
 }
 
+func (s *DepthFirstSearchSuite) TestMultiSearch(c *C) {
+	el := append(gogl.ArcList{gogl.NewArc("quark", "foo")}, dfArcSet...)
+	g := gogl.Spec().Directed().Mutable().Using(el).Create(al.G).(gogl.MutableDigraph)
+	g.EnsureVertex("isolate")
+
+	collect := func(paths <-chan SearchPath) map[gogl.Vertex]SearchPath {
+		results := make(map[gogl.Vertex]SearchPath)
+		for p := range paths {
+			results[p.Start] = p
+		}
+		return results
+	}
+
+	paths, err := MultiSearch(context.Background(), g, "qux", 2, "foo", "baz", "isolate")
+	c.Assert(err, IsNil)
+
+	results := collect(paths)
+	c.Assert(len(results), Equals, 3)
+	c.Assert(results["foo"].Reachable, Equals, true)
+	c.Assert(results["foo"].Path, DeepEquals, []gogl.Vertex{"qux", "baz", "bar", "foo"})
+	c.Assert(results["baz"].Path, DeepEquals, []gogl.Vertex{"qux", "baz"})
+	c.Assert(results["isolate"].Reachable, Equals, false)
+	c.Assert(results["isolate"].Target, Equals, "qux")
+
+	// sources are used when no starts are given
+	paths, err = MultiSearch(context.Background(), g, "qux", 0)
+	c.Assert(err, IsNil)
+
+	results = collect(paths)
+	c.Assert(len(results), Equals, 2)
+	c.Assert(results["quark"].Reachable, Equals, true)
+	c.Assert(results["isolate"].Reachable, Equals, false)
+
+	_, err = MultiSearch(context.Background(), g, "nope", 0)
+	c.Assert(err, ErrorMatches, "Target vertex.*")
+	_, err = MultiSearch(context.Background(), g, "qux", 0, "nope")
+	c.Assert(err, ErrorMatches, "Start vertex.*")
+	_, err = MultiSearch(context.Background(), gogl.Spec().Using(dfEdgeSet).Create(al.G), "qux", 0)
+	c.Assert(err, ErrorMatches, ".*do not have sources.*")
+
+	// cancelled searches send nothing
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	paths, err = MultiSearch(ctx, g, "qux", 1, "foo", "bar", "baz")
+	c.Assert(err, IsNil)
+	c.Assert(len(collect(paths)), Equals, 0)
+}
+
 func (s *DepthFirstSearchSuite) TestSearchVertexVerification(c *C) {
 	g := gogl.Spec().Mutable().Directed().
 		Create(al.G).(gogl.MutableDigraph)
@@ -107,6 +155,44 @@ func (s *DepthFirstSearchSuite) TestToposort(c *C) {
 	c.Assert(tsl, DeepEquals, []gogl.Vertex{"qux", "baz", "bar", "foo"})
 }
 
+func (s *DepthFirstSearchSuite) TestMixed(c *C) {
+	el := gogl.EdgeList{
+		gogl.NewArc("foo", "bar"),
+		gogl.NewEdge("bar", "baz"),
+		gogl.NewArc("baz", "qux"),
+		gogl.NewArc("quark", "foo"),
+	}
+	g := gogl.Spec().Mixed().Using(el).Create(al.G)
+
+	// the undirected edge may be traversed in either direction
+	path, err := Search(g, "qux", "foo")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"qux", "baz", "bar", "foo"})
+
+	// but arcs only in theirs
+	path, err = Search(g, "quark", "qux")
+	c.Assert(err, IsNil)
+	c.Assert(path, HasLen, 0)
+
+	// the undirected edge back to the parent is not a cycle
+	tsl, err := Toposort(g, "foo")
+	c.Assert(err, IsNil)
+	c.Assert(tsl, DeepEquals, []gogl.Vertex{"qux", "baz", "bar", "foo"})
+
+	// but an arc back to the parent is
+	g.(gogl.MutableMixedGraph).AddArcs(gogl.NewArc("bar", "foo"))
+	_, err = Toposort(g, "foo")
+	c.Assert(err, ErrorMatches, "Cycle detected in graph")
+}
+
+func (s *DepthFirstSearchSuite) TestTraverseMultipleStarts(c *C) {
+	g := gogl.Spec().Directed().Using(dfArcSet).Create(al.G)
+
+	tsl, err := Toposort(g, "baz", "foo")
+	c.Assert(err, IsNil)
+	c.Assert(tsl, DeepEquals, []gogl.Vertex{"qux", "baz", "bar", "foo"})
+}
+
 // This is a bit wackyhacky, but works well enough
 var _ = Suite(&TestVisitor{})
 
@@ -175,6 +261,362 @@ func (v *TestVisitor) TestTraverse(c *C) {
 	c.Assert(len(v.found_edges), Equals, len(el))
 }
 
+// Records the vertices it starts, and directs the walk according to the
+// provided actions.
+type actionVisitor struct {
+	started []gogl.Vertex
+	starts  map[gogl.Vertex]Action
+	edges   map[gogl.Vertex]Action // keyed by the edge's target
+	onStart func(gogl.Vertex)
+}
+
+func (v *actionVisitor) OnBackEdge(vertex gogl.Vertex) {}
+
+func (v *actionVisitor) OnStartVertex(vertex gogl.Vertex) {
+	v.started = append(v.started, vertex)
+	if v.onStart != nil {
+		v.onStart(vertex)
+	}
+}
+
+func (v *actionVisitor) OnExamineEdge(edge gogl.Edge) {}
+
+func (v *actionVisitor) OnFinishVertex(vertex gogl.Vertex) {}
+
+func (v *actionVisitor) StartAction(vertex gogl.Vertex) Action {
+	return v.starts[vertex]
+}
+
+func (v *actionVisitor) EdgeAction(edge gogl.Edge) Action {
+	_, target := edge.Both()
+	return v.edges[target]
+}
+
+type TraversalControlSuite struct{}
+
+var _ = Suite(&TraversalControlSuite{})
+
+func (s *TraversalControlSuite) TestPrune(c *C) {
+	el := append(gogl.ArcList{gogl.NewArc("foo", "quark")}, dfArcSet...)
+	g := gogl.Spec().Directed().Using(el).Create(al.G)
+
+	vis := &actionVisitor{starts: map[gogl.Vertex]Action{"bar": Prune}}
+	_, err := Traverse(g, vis, "foo")
+	c.Assert(err, IsNil)
+	c.Assert(vis.started, Contains, "bar")
+	c.Assert(vis.started, Contains, "quark")
+	c.Assert(vis.started, Not(Contains), "baz")
+
+	vis = &actionVisitor{edges: map[gogl.Vertex]Action{"baz": Prune}}
+	Traverse(g, vis, "foo")
+	c.Assert(vis.started, Contains, "bar")
+	c.Assert(vis.started, Not(Contains), "baz")
+	c.Assert(len(vis.started), Equals, 3)
+}
+
+func (s *TraversalControlSuite) TestStop(c *C) {
+	g := gogl.Spec().Directed().Mutable().Using(dfArcSet).Create(al.G)
+
+	g.(gogl.MutableDigraph).EnsureVertex("quark")
+
+	// later start points are not begun once stopped
+	vis := &actionVisitor{starts: map[gogl.Vertex]Action{"bar": Stop}}
+	_, err := Traverse(g, vis, "quark", "foo")
+	c.Assert(err, IsNil)
+	c.Assert(vis.started, DeepEquals, []gogl.Vertex{"foo", "bar"})
+
+	// undirected traversals stop as well
+	ug := gogl.Spec().Using(dfEdgeSet).Create(al.G)
+	vis = &actionVisitor{edges: map[gogl.Vertex]Action{"baz": Stop}}
+	w := &walker{vis: Adapt(vis), g: ug, colors: make(map[gogl.Vertex]uint)}
+	w.dfutraverse("foo")
+	c.Assert(vis.started, DeepEquals, []gogl.Vertex{"foo", "bar"})
+	c.Assert(w.colors["foo"], Equals, uint(grey))
+}
+
+func (s *TraversalControlSuite) TestCancellation(c *C) {
+	g := gogl.Spec().Directed().Using(dfArcSet).Create(al.G)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := SearchContext(ctx, g, "qux", "foo")
+	c.Assert(err, Equals, context.Canceled)
+	_, err = ToposortContext(ctx, g, "foo")
+	c.Assert(err, Equals, context.Canceled)
+
+	// cancelling mid-walk abandons it promptly
+	ctx, cancel = context.WithCancel(context.Background())
+	vis := &actionVisitor{onStart: func(v gogl.Vertex) {
+		if v == "bar" {
+			cancel()
+		}
+	}}
+	_, err = TraverseContext(ctx, g, vis, "foo")
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(vis.started, DeepEquals, []gogl.Vertex{"foo", "bar"})
+
+	// uncancelled contexts change nothing
+	path, err := SearchContext(context.Background(), g, "qux", "foo")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"qux", "baz", "bar", "foo"})
+}
+
+// Records each visitor callback made, in order.
+type recordingVisitor struct {
+	events []string
+}
+
+func (v *recordingVisitor) OnBackEdge(vertex gogl.Vertex) {
+	v.events = append(v.events, fmt.Sprint("back ", vertex))
+}
+
+func (v *recordingVisitor) OnStartVertex(vertex gogl.Vertex) {
+	v.events = append(v.events, fmt.Sprint("start ", vertex))
+}
+
+func (v *recordingVisitor) OnExamineEdge(edge gogl.Edge) {
+	u, w := edge.Both()
+	v.events = append(v.events, fmt.Sprint("examine ", u, "-", w))
+}
+
+func (v *recordingVisitor) OnFinishVertex(vertex gogl.Vertex) {
+	v.events = append(v.events, fmt.Sprint("finish ", vertex))
+}
+
+// Builds a directed chain of n vertices, 0 -> 1 -> ... -> n-1.
+func chain(n int) gogl.Digraph {
+	el := make(gogl.ArcList, 0, n-1)
+	for i := 1; i < n; i++ {
+		el = append(el, gogl.NewArc(i-1, i))
+	}
+	return gogl.Spec().Directed().Using(el).Create(al.G).(gogl.Digraph)
+}
+
+type IterativeWalkSuite struct{}
+
+var _ = Suite(&IterativeWalkSuite{})
+
+func (s *IterativeWalkSuite) TestCallbackOrder(c *C) {
+	el := gogl.ArcList{
+		gogl.NewArc("foo", "bar"),
+		gogl.NewArc("bar", "baz"),
+		gogl.NewArc("baz", "foo"),
+	}
+	g := gogl.Spec().Directed().Using(el).Create(al.G)
+
+	vis := &recordingVisitor{}
+	Traverse(g, vis, "foo")
+	c.Assert(vis.events, DeepEquals, []string{
+		"start foo",
+		"examine foo-bar",
+		"start bar",
+		"examine bar-baz",
+		"start baz",
+		"examine baz-foo",
+		"back foo",
+		"finish baz",
+		"finish bar",
+		"finish foo",
+	})
+}
+
+func (s *IterativeWalkSuite) TestDeepChain(c *C) {
+	n := 100000
+	g := chain(n)
+
+	tsl, err := Toposort(g, 0)
+	c.Assert(err, IsNil)
+	c.Assert(len(tsl), Equals, n)
+	c.Assert(tsl[0], Equals, n-1)
+	c.Assert(tsl[n-1], Equals, 0)
+
+	path, err := Search(g, n-1, 0)
+	c.Assert(err, IsNil)
+	c.Assert(len(path), Equals, n)
+}
+
+func benchmarkChain(b *testing.B, n int, f func(gogl.Digraph)) {
+	g := chain(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f(g)
+	}
+}
+
+func BenchmarkToposortChain10K(b *testing.B) {
+	benchmarkChain(b, 10000, func(g gogl.Digraph) { Toposort(g, 0) })
+}
+
+func BenchmarkToposortChain1M(b *testing.B) {
+	benchmarkChain(b, 1000000, func(g gogl.Digraph) { Toposort(g, 0) })
+}
+
+func BenchmarkSearchChain10K(b *testing.B) {
+	benchmarkChain(b, 10000, func(g gogl.Digraph) { Search(g, 9999, 0) })
+}
+
+func BenchmarkSearchChain1M(b *testing.B) {
+	benchmarkChain(b, 1000000, func(g gogl.Digraph) { Search(g, 999999, 0) })
+}
+
+// Counts edge classifications and records vertex timestamps.
+type classifyingVisitor struct {
+	examined, tree, back, forwardOrCross, finishedEdges int
+	discovered, finished                                map[gogl.Vertex]int
+}
+
+func newClassifyingVisitor() *classifyingVisitor {
+	return &classifyingVisitor{
+		discovered: make(map[gogl.Vertex]int),
+		finished:   make(map[gogl.Vertex]int),
+	}
+}
+
+func (v *classifyingVisitor) DiscoverVertex(vertex gogl.Vertex, time int) {
+	v.discovered[vertex] = time
+}
+
+func (v *classifyingVisitor) ExamineEdge(edge gogl.Edge)        { v.examined++ }
+func (v *classifyingVisitor) TreeEdge(edge gogl.Edge)           { v.tree++ }
+func (v *classifyingVisitor) BackEdge(edge gogl.Edge)           { v.back++ }
+func (v *classifyingVisitor) ForwardOrCrossEdge(edge gogl.Edge) { v.forwardOrCross++ }
+func (v *classifyingVisitor) FinishEdge(edge gogl.Edge)         { v.finishedEdges++ }
+
+func (v *classifyingVisitor) FinishVertex(vertex gogl.Vertex, time int) {
+	v.finished[vertex] = time
+}
+
+// A mixed graph with an extra undirected edge, which may run alongside one of
+// its arcs; al's mixed graphs do not allow that.
+type alongside struct {
+	gogl.MixedGraph
+	extra gogl.Edge
+}
+
+func (g alongside) UndirectedEdges(f gogl.EdgeStep) {
+	if !f(g.extra) {
+		g.MixedGraph.UndirectedEdges(f)
+	}
+}
+
+func (g alongside) UndirectedIncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	if u1, u2 := g.extra.Both(); u1 == v || u2 == v {
+		if f(g.extra) {
+			return
+		}
+	}
+	g.MixedGraph.UndirectedIncidentTo(v, f)
+}
+
+type ClassifyingVisitorSuite struct{}
+
+var _ = Suite(&ClassifyingVisitorSuite{})
+
+func (s *ClassifyingVisitorSuite) TestDirected(c *C) {
+	el := gogl.ArcList{
+		gogl.NewArc("foo", "bar"),
+		gogl.NewArc("bar", "baz"),
+		gogl.NewArc("foo", "baz"),
+		gogl.NewArc("baz", "foo"),
+	}
+	g := gogl.Spec().Directed().Using(el).Create(al.G)
+
+	vis := newClassifyingVisitor()
+	c.Assert(Walk(g, vis, "foo"), IsNil)
+
+	// foo->baz is a tree edge or a forward edge, depending on enumeration order
+	c.Assert(vis.examined, Equals, 4)
+	c.Assert(vis.tree, Equals, 2)
+	c.Assert(vis.back, Equals, 1)
+	c.Assert(vis.forwardOrCross, Equals, 1)
+	c.Assert(vis.finishedEdges, Equals, 4)
+
+	// timestamps are distinct, and nest: the root brackets every other vertex
+	times := make(map[int]bool)
+	for v, d := range vis.discovered {
+		f := vis.finished[v]
+		c.Assert(d < f, Equals, true)
+		c.Assert(d >= vis.discovered["foo"] && f <= vis.finished["foo"], Equals, true)
+		times[d], times[f] = true, true
+	}
+	c.Assert(len(times), Equals, 6)
+	c.Assert(vis.discovered["foo"], Equals, 0)
+	c.Assert(vis.finished["foo"], Equals, 5)
+}
+
+func (s *ClassifyingVisitorSuite) TestUndirected(c *C) {
+	el := gogl.EdgeList{
+		gogl.NewEdge("foo", "bar"),
+		gogl.NewEdge("bar", "baz"),
+		gogl.NewEdge("baz", "foo"),
+		gogl.NewEdge("baz", "qux"),
+	}
+	g := gogl.Spec().Using(el).Create(al.G)
+
+	vis := newClassifyingVisitor()
+	c.Assert(Walk(g, vis, "foo"), IsNil)
+
+	// each edge is examined from both ends, but classified once
+	c.Assert(vis.examined, Equals, 8)
+	c.Assert(vis.tree, Equals, 3)
+	c.Assert(vis.back, Equals, 1)
+	c.Assert(vis.forwardOrCross, Equals, 0)
+	c.Assert(vis.finishedEdges, Equals, 4)
+	c.Assert(len(vis.finished), Equals, 4)
+
+	err := Walk(g, vis)
+	c.Assert(err, ErrorMatches, ".*do not have sources.*")
+}
+
+func (s *ClassifyingVisitorSuite) TestMixed(c *C) {
+	el := gogl.EdgeList{
+		gogl.NewArc("foo", "bar"),
+		gogl.NewArc("bar", "baz"),
+		gogl.NewEdge("baz", "foo"),
+	}
+	g := gogl.Spec().Mixed().Using(el).Create(al.G)
+
+	vis := newClassifyingVisitor()
+	c.Assert(Walk(g, vis, "foo"), IsNil)
+
+	// the undirected edge is reached from both ends, but examined and
+	// classified only from baz, as a back edge
+	c.Assert(vis.examined, Equals, 3)
+	c.Assert(vis.tree, Equals, 2)
+	c.Assert(vis.back, Equals, 1)
+	c.Assert(vis.forwardOrCross, Equals, 0)
+	c.Assert(vis.finishedEdges, Equals, 3)
+
+	// an undirected edge alongside the arc that was followed is still examined
+	arc := gogl.Spec().Mixed().Using(gogl.ArcList{gogl.NewArc(1, 2)}).Create(al.G).(gogl.MixedGraph)
+	vis = newClassifyingVisitor()
+	c.Assert(Walk(alongside{arc, gogl.NewEdge(1, 2)}, vis, 1), IsNil)
+	c.Assert(vis.examined, Equals, 2)
+	c.Assert(vis.tree, Equals, 1)
+	c.Assert(vis.back, Equals, 1)
+}
+
+func (s *ClassifyingVisitorSuite) TestAdapt(c *C) {
+	// back edges along undirected edges of a mixed graph report the ancestor
+	el := gogl.EdgeList{
+		gogl.NewArc("foo", "bar"),
+		gogl.NewArc("bar", "baz"),
+		gogl.NewEdge("baz", "foo"),
+	}
+	g := gogl.Spec().Mixed().Using(el).Create(al.G)
+
+	vis := &recordingVisitor{}
+	c.Assert(Walk(g, Adapt(vis), "foo"), IsNil)
+	c.Assert(vis.events, Contains, "back foo")
+	c.Assert(vis.events[len(vis.events)-1], Equals, "finish foo")
+
+	// actions are passed through
+	avis := &actionVisitor{starts: map[gogl.Vertex]Action{"bar": Stop}}
+	c.Assert(Walk(g, Adapt(avis), "foo"), IsNil)
+	c.Assert(avis.started, DeepEquals, []gogl.Vertex{"foo", "bar"})
+}
+
 type LinkedListSuite struct{}
 
 var _ = Suite(&LinkedListSuite{})
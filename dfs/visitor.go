@@ -0,0 +1,115 @@
+package dfs
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// A ClassifyingVisitor is told of each step of a depth-first walk in full
+// detail, in the manner of the Boost Graph Library's DFS visitors: every edge
+// the walk examines is classified according to its place in the depth-first
+// forest.
+//
+// Discovery and finish times are drawn from a single counter, which advances
+// each time a vertex is discovered or finished. A vertex u is thus an
+// ancestor of v in the depth-first forest exactly when u's discovery and
+// finish times bracket v's.
+//
+// Edges are passed as they are enumerated by the graph. In directed walks,
+// each examined edge is classified exactly once, as a tree, back, or forward
+// or cross edge; an undirected edge of a mixed graph, which may be followed
+// from either end, is examined and classified only from the first. In
+// undirected walks, each edge is examined from both ends, but only classified
+// - as a tree or back edge - from the first; undirected graphs have no
+// forward or cross edges.
+//
+// A ClassifyingVisitor may also implement the StartAction and EdgeAction
+// methods of ActionVisitor in order to steer the walk. StartAction is then
+// consulted after DiscoverVertex, and EdgeAction after ExamineEdge; an edge
+// pruned by EdgeAction is not classified.
+type ClassifyingVisitor interface {
+	// Called when a vertex is first reached.
+	DiscoverVertex(vertex gogl.Vertex, time int)
+	// Called as each edge out of a discovered vertex is reached.
+	ExamineEdge(edge gogl.Edge)
+	// Called for an edge leading to an undiscovered vertex, immediately
+	// before that vertex is discovered.
+	TreeEdge(edge gogl.Edge)
+	// Called for an edge leading to an ancestor in the depth-first tree,
+	// which closes a cycle.
+	BackEdge(edge gogl.Edge)
+	// Called for an edge leading to a vertex that has already been finished.
+	ForwardOrCrossEdge(edge gogl.Edge)
+	// Called once an edge has been classified and, for tree edges, once the
+	// vertex it leads to has been finished.
+	FinishEdge(edge gogl.Edge)
+	// Called once every edge out of a vertex has been finished.
+	FinishVertex(vertex gogl.Vertex, time int)
+}
+
+// Wraps a Visitor so that it may be used where a ClassifyingVisitor is
+// required. Discovering and finishing a vertex, and examining an edge, map
+// directly onto the Visitor's methods; a back edge is reported by passing its
+// ancestor end to OnBackEdge. If the Visitor is an ActionVisitor, its actions
+// are respected.
+func Adapt(v Visitor) ClassifyingVisitor {
+	return &adapter{v: v, backEdges: true}
+}
+
+type adapter struct {
+	v         Visitor
+	backEdges bool   // whether to report back edges; false for undirected toposorts
+	path      vstack // discovered vertices not yet finished, innermost on top
+}
+
+func (a *adapter) DiscoverVertex(vertex gogl.Vertex, time int) {
+	a.path.push(vertex)
+	a.v.OnStartVertex(vertex)
+}
+
+func (a *adapter) ExamineEdge(edge gogl.Edge) {
+	a.v.OnExamineEdge(edge)
+}
+
+func (a *adapter) TreeEdge(edge gogl.Edge) {}
+
+func (a *adapter) BackEdge(edge gogl.Edge) {
+	if !a.backEdges {
+		return
+	}
+
+	// An examined edge always leads out of the innermost unfinished vertex, so
+	// the ancestor is whichever end of the edge that vertex is not.
+	if arc, ok := edge.(gogl.Arc); ok {
+		a.v.OnBackEdge(arc.Target())
+		return
+	}
+
+	u, v := edge.Both()
+	if u == a.path.peek() {
+		u = v
+	}
+	a.v.OnBackEdge(u)
+}
+
+func (a *adapter) ForwardOrCrossEdge(edge gogl.Edge) {}
+
+func (a *adapter) FinishEdge(edge gogl.Edge) {}
+
+func (a *adapter) FinishVertex(vertex gogl.Vertex, time int) {
+	a.path.pop()
+	a.v.OnFinishVertex(vertex)
+}
+
+func (a *adapter) StartAction(vertex gogl.Vertex) Action {
+	if av, ok := a.v.(ActionVisitor); ok {
+		return av.StartAction(vertex)
+	}
+	return Continue
+}
+
+func (a *adapter) EdgeAction(edge gogl.Edge) Action {
+	if av, ok := a.v.(ActionVisitor); ok {
+		return av.EdgeAction(edge)
+	}
+	return Continue
+}
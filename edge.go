@@ -59,6 +59,24 @@ type DataArc interface {
 	Data() interface{}
 }
 
+// PropertyEdge describes an Edge that carries a weight, a label and arbitrary
+// data all at once. It satisfies WeightedEdge, LabeledEdge and DataEdge.
+type PropertyEdge interface {
+	Edge
+	Weight() float64
+	Label() string
+	Data() interface{}
+}
+
+// PropertyArc describes an Arc that carries a weight, a label and arbitrary
+// data all at once. It satisfies WeightedArc, LabeledArc and DataArc.
+type PropertyArc interface {
+	Arc
+	Weight() float64
+	Label() string
+	Data() interface{}
+}
+
 /* Base implementations of Edge interfaces */
 
 // BaseEdge is a struct used to represent edges and meet the Edge interface
@@ -183,3 +201,53 @@ func (e baseDataArc) Data() interface{} {
 func NewDataArc(u, v Vertex, data interface{}) DataArc {
 	return baseDataArc{baseArc{baseEdge{u: u, v: v}}, data}
 }
+
+// BasePropertyEdge extends BaseEdge with a weight, a label and arbitrary data.
+type basePropertyEdge struct {
+	baseEdge
+	w float64
+	l string
+	d interface{}
+}
+
+func (e basePropertyEdge) Weight() float64 {
+	return e.w
+}
+
+func (e basePropertyEdge) Label() string {
+	return e.l
+}
+
+func (e basePropertyEdge) Data() interface{} {
+	return e.d
+}
+
+// Create a new property edge - an edge with a weight, a label and arbitrary data.
+func NewPropertyEdge(u, v Vertex, weight float64, label string, data interface{}) PropertyEdge {
+	return basePropertyEdge{baseEdge{u: u, v: v}, weight, label, data}
+}
+
+// BasePropertyArc extends BaseArc with a weight, a label and arbitrary data.
+type basePropertyArc struct {
+	baseArc
+	w float64
+	l string
+	d interface{}
+}
+
+func (e basePropertyArc) Weight() float64 {
+	return e.w
+}
+
+func (e basePropertyArc) Label() string {
+	return e.l
+}
+
+func (e basePropertyArc) Data() interface{} {
+	return e.d
+}
+
+// Create a new property arc - an arc with a weight, a label and arbitrary data.
+func NewPropertyArc(u, v Vertex, weight float64, label string, data interface{}) PropertyArc {
+	return basePropertyArc{baseArc{baseEdge{u: u, v: v}}, weight, label, data}
+}
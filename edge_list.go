@@ -185,3 +185,43 @@ func (el DataArcList) Arcs(fn ArcStep) {
 		}
 	}
 }
+
+// A PropertyEdgeList is a naive GraphSource implementation that is backed only by an edge slice.
+//
+// This variant is for property edges.
+type PropertyEdgeList []PropertyEdge
+
+func (el PropertyEdgeList) Vertices(fn VertexStep) {
+	elVertices(el, fn)
+}
+
+func (el PropertyEdgeList) Edges(fn EdgeStep) {
+	for _, e := range el {
+		if fn(e) {
+			return
+		}
+	}
+}
+
+// A PropertyArcList is a naive DigraphSource implementation that is backed only by an arc slice.
+type PropertyArcList []Arc
+
+func (el PropertyArcList) Vertices(fn VertexStep) {
+	elVertices(el, fn)
+}
+
+func (el PropertyArcList) Edges(fn EdgeStep) {
+	for _, e := range el {
+		if fn(e) {
+			return
+		}
+	}
+}
+
+func (el PropertyArcList) Arcs(fn ArcStep) {
+	for _, e := range el {
+		if fn(e) {
+			return
+		}
+	}
+}
@@ -0,0 +1,392 @@
+// Reads and writes graphs in a compact, versioned binary format, suitable for
+// quickly snapshotting and restoring large graphs.
+//
+// The format begins with a magic number and version, followed by the graph's
+// GraphProperties. Vertices are then written once each, via a Codec, and
+// thereafter referred to by their index, as a varint. Edges follow, each with
+// a flag byte recording which properties it carries and whether it is an arc;
+// weights are stored as byte-reversed varints, labels as length-prefixed
+// strings, and data via a Codec. A lone end flag closes the edges, so that
+// they need not be counted before being written. Finally, any vertex
+// attributes are written.
+package binary
+
+import (
+	"bufio"
+	stdbinary "encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+var magic = [4]byte{'g', 'o', 'g', 'l'}
+
+// The current version of the binary format.
+const Version = 1
+
+// Flags recorded for each edge, and each attributed vertex. fEnd is never
+// set on an edge; it is written alone after the last one.
+const (
+	fArc byte = 1 << iota
+	fWeight
+	fLabel
+	fData
+	fEnd
+)
+
+// A Format holds the Codecs used to store vertices and data.
+type Format struct {
+	Vertex Codec // Stores vertices
+	Data   Codec // Stores edge and vertex data
+}
+
+// The Format used by the package-level Encode and Decode, which can store
+// vertices and data of Go's basic types.
+var Default = Format{Vertex: Scalar, Data: Scalar}
+
+// Writes the provided graph to w using the Default format.
+func Encode(w io.Writer, g gogl.GraphSource) error {
+	return Default.Encode(w, g)
+}
+
+// Reads a graph from r using the Default format.
+func Decode(r io.Reader) (gogl.GraphSource, error) {
+	return Default.Decode(r)
+}
+
+type writer struct {
+	*bufio.Writer
+	buf [stdbinary.MaxVarintLen64]byte
+	err error
+}
+
+func (w *writer) uvarint(u uint64) {
+	if w.err == nil {
+		_, w.err = w.Write(w.buf[:stdbinary.PutUvarint(w.buf[:], u)])
+	}
+}
+
+func (w *writer) bytes(b []byte) {
+	w.uvarint(uint64(len(b)))
+	if w.err == nil {
+		_, w.err = w.Write(b)
+	}
+}
+
+func (w *writer) flag(f byte) {
+	if w.err == nil {
+		w.err = w.WriteByte(f)
+	}
+}
+
+func (w *writer) encoded(c Codec, v interface{}) {
+	if w.err == nil {
+		var b []byte
+		if b, w.err = c.Encode(v); w.err == nil {
+			w.bytes(b)
+		}
+	}
+}
+
+// Writes the properties in a, prefixed by their flags.
+func (w *writer) attrs(f Format, a encoding.Attrs, flags byte) {
+	if a.Props&gogl.G_WEIGHTED != 0 {
+		flags |= fWeight
+	}
+	if a.Props&gogl.G_LABELED != 0 {
+		flags |= fLabel
+	}
+	if a.Props&gogl.G_DATA != 0 {
+		flags |= fData
+	}
+
+	w.flag(flags)
+	if flags&fWeight != 0 {
+		w.uvarint(floatBits(a.Weight))
+	}
+	if flags&fLabel != 0 {
+		w.bytes([]byte(a.Label))
+	}
+	if flags&fData != 0 {
+		w.encoded(f.Data, a.Data)
+	}
+}
+
+// Writes the provided graph to w.
+//
+// The recorded GraphProperties are those reported by encoding.Infer; unless
+// the graph is an encoding.PropertiesReporter, as al's graphs are, that
+// enumerates its edges an extra time, holding each vertex pair in memory.
+// Otherwise the edges are enumerated once, and the vertices once, plus once
+// more to count them if the graph is not a VertexCounter and again for their
+// attributes if it is a VertexAttributeSource. An index of the vertices is
+// held in memory throughout.
+//
+// An error is returned if an edge refers to a vertex the graph did not
+// enumerate.
+func (f Format) Encode(w io.Writer, g gogl.GraphSource) error {
+	bw := &writer{Writer: bufio.NewWriter(w)}
+	gp := encoding.Infer(g)
+
+	_, bw.err = bw.Write(magic[:])
+	bw.uvarint(Version)
+	bw.uvarint(uint64(gp))
+
+	index := make(map[gogl.Vertex]uint64)
+	bw.uvarint(uint64(gogl.Order(g)))
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		index[v] = uint64(len(index))
+		bw.encoded(f.Vertex, v)
+		return bw.err != nil
+	})
+	if bw.err != nil {
+		return bw.err
+	}
+
+	var edges []func(gogl.EdgeStep)
+	var flags []byte
+	if dg, ok := g.(gogl.DigraphSource); ok {
+		edges = append(edges, func(step gogl.EdgeStep) {
+			dg.Arcs(func(a gogl.Arc) (terminate bool) {
+				return step(a)
+			})
+		})
+		flags = append(flags, fArc)
+
+		if ug, ok := g.(gogl.UndirectedEdgeEnumerator); ok {
+			edges = append(edges, ug.UndirectedEdges)
+			flags = append(flags, 0)
+		}
+	} else {
+		edges = append(edges, g.Edges)
+		flags = append(flags, 0)
+	}
+
+	for k, each := range edges {
+		each(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			iu, uok := index[u]
+			iv, vok := index[v]
+			if !uok || !vok {
+				bw.err = fmt.Errorf("Edge %v refers to a vertex not among the graph's vertices.", e)
+				return true
+			}
+
+			bw.attrs(f, encoding.AttrsOf(e), flags[k])
+			bw.uvarint(iu)
+			bw.uvarint(iv)
+			return bw.err != nil
+		})
+	}
+	bw.flag(fEnd)
+
+	// Vertex attributes are written last, as they are sparse; the count of
+	// attributed vertices is not known until they have all been seen.
+	if vas, ok := g.(gogl.VertexAttributeSource); ok {
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			var a encoding.Attrs
+			if weight, exists := vas.VertexWeight(v); exists {
+				a.SetWeight(weight)
+			}
+			if label, exists := vas.VertexLabel(v); exists {
+				a.SetLabel(label)
+			}
+			if data, exists := vas.VertexData(v); exists {
+				a.SetData(data)
+			}
+
+			i, ok := index[v]
+			if !ok {
+				bw.err = fmt.Errorf("Vertex %v was not among the graph's vertices when first enumerated.", v)
+				return true
+			}
+			if a.Props != 0 {
+				bw.uvarint(i + 1)
+				bw.attrs(f, a, 0)
+			}
+			return bw.err != nil
+		})
+	}
+	bw.uvarint(0)
+
+	if bw.err != nil {
+		return bw.err
+	}
+	return bw.Flush()
+}
+
+type reader struct {
+	*bufio.Reader
+}
+
+func (r reader) bytes() ([]byte, error) {
+	n, err := stdbinary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > math.MaxInt32 {
+		return nil, errors.New("Malformed graph: oversized value.")
+	}
+
+	b := make([]byte, n)
+	_, err = io.ReadFull(r, b)
+	return b, err
+}
+
+func (r reader) decoded(c Codec) (interface{}, error) {
+	b, err := r.bytes()
+	if err != nil {
+		return nil, err
+	}
+	return c.Decode(b)
+}
+
+// Reads a flag byte and the properties it announces.
+func (r reader) attrs(f Format) (a encoding.Attrs, flags byte, err error) {
+	if flags, err = r.ReadByte(); err != nil {
+		return
+	}
+
+	if flags&fWeight != 0 {
+		var u uint64
+		if u, err = stdbinary.ReadUvarint(r); err != nil {
+			return
+		}
+		a.SetWeight(bitsFloat(u))
+	}
+	if flags&fLabel != 0 {
+		var b []byte
+		if b, err = r.bytes(); err != nil {
+			return
+		}
+		a.SetLabel(string(b))
+	}
+	if flags&fData != 0 {
+		var data interface{}
+		if data, err = r.decoded(f.Data); err != nil {
+			return
+		}
+		a.SetData(data)
+	}
+
+	return
+}
+
+// Reads a graph from r.
+//
+// The result is held in memory, and reports the GraphProperties recorded
+// when it was written as an encoding.PropertiesReporter, so encoding.Spec
+// can recreate the original graph.
+func (f Format) Decode(r io.Reader) (gogl.GraphSource, error) {
+	br := reader{bufio.NewReader(r)}
+
+	var m [4]byte
+	if _, err := io.ReadFull(br, m[:]); err != nil || m != magic {
+		return nil, errors.New("Not a gogl binary graph.")
+	}
+
+	version, err := stdbinary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if version != Version {
+		return nil, fmt.Errorf("Unsupported binary graph version %d.", version)
+	}
+
+	gp, err := stdbinary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+
+	src := encoding.NewSource(gp&gogl.G_DIRECTED != 0)
+	src.Declare(gogl.GraphProperties(gp))
+
+	order, err := stdbinary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+
+	var vertices []gogl.Vertex
+	for i := uint64(0); i < order; i++ {
+		v, err := br.decoded(f.Vertex)
+		if err != nil {
+			return nil, err
+		}
+		vertices = append(vertices, v)
+		src.AddVertex(v)
+	}
+
+	vertex := func() (gogl.Vertex, error) {
+		i, err := stdbinary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		if i >= uint64(len(vertices)) {
+			return nil, errors.New("Malformed graph: vertex index out of range.")
+		}
+		return vertices[i], nil
+	}
+
+	for {
+		a, flags, err := br.attrs(f)
+		if err != nil {
+			return nil, err
+		}
+		if flags == fEnd {
+			break
+		}
+		if flags&^(fArc|fWeight|fLabel|fData) != 0 {
+			return nil, errors.New("Malformed graph: unknown edge flags.")
+		}
+
+		u, err := vertex()
+		if err != nil {
+			return nil, err
+		}
+		v, err := vertex()
+		if err != nil {
+			return nil, err
+		}
+
+		if flags&fArc != 0 {
+			src.AddEdge(a.Arc(u, v))
+		} else {
+			src.AddEdge(a.Edge(u, v))
+		}
+	}
+
+	for {
+		i, err := stdbinary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			break
+		}
+		if i > uint64(len(vertices)) {
+			return nil, errors.New("Malformed graph: vertex index out of range.")
+		}
+
+		a, _, err := br.attrs(f)
+		if err != nil {
+			return nil, err
+		}
+
+		v := vertices[i-1]
+		if a.Props&gogl.G_WEIGHTED != 0 {
+			src.SetVertexWeight(v, a.Weight)
+		}
+		if a.Props&gogl.G_LABELED != 0 {
+			src.SetVertexLabel(v, a.Label)
+		}
+		if a.Props&gogl.G_DATA != 0 {
+			src.SetVertexData(v, a.Data)
+		}
+	}
+
+	return src.GraphSource(), nil
+}
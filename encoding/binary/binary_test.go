@@ -0,0 +1,237 @@
+package binary
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type BinarySuite struct{}
+
+var _ = Suite(&BinarySuite{})
+
+func roundTrip(c *C, f Format, g gogl.GraphSource) gogl.Graph {
+	var buf bytes.Buffer
+	c.Assert(f.Encode(&buf, g), IsNil)
+
+	src, err := f.Decode(&buf)
+	c.Assert(err, IsNil)
+
+	g2, err := encoding.Spec(src).TryCreate(al.TryG)
+	c.Assert(err, IsNil)
+	return g2
+}
+
+func (s *BinarySuite) TestRoundTripEdgeTypes(c *C) {
+	specs := []gogl.GraphSpec{
+		gogl.Spec(),
+		gogl.Spec().Immutable(),
+		gogl.Spec().Weighted(),
+		gogl.Spec().Labeled(),
+		gogl.Spec().DataEdges(),
+		gogl.Spec().PropertyEdges(),
+	}
+
+	el := []gogl.PropertyArc{
+		gogl.NewPropertyArc(1, 2, 5.23, "foo", "bar"),
+		gogl.NewPropertyArc(2, 3, -1, "", 42),
+	}
+
+	for _, spec := range specs {
+		for _, directed := range []bool{false, true} {
+			if directed {
+				spec = spec.Directed()
+			}
+
+			g := spec.Using(gogl.PropertyArcList{el[0], el[1]}).Create(al.G)
+			g2 := roundTrip(c, Default, g)
+
+			c.Assert(fmt.Sprintf("%T", g2), Equals, fmt.Sprintf("%T", g))
+			c.Assert(encoding.Infer(g2), Equals, encoding.Infer(g))
+			c.Assert(gogl.Order(g2), Equals, 3)
+			c.Assert(gogl.Size(g2), Equals, 2)
+
+			for _, e := range el {
+				if directed {
+					c.Assert(g2.(gogl.Digraph).HasArc(e), Equals, true)
+				}
+
+				switch g2 := g2.(type) {
+				case gogl.PropertyGraph:
+					c.Assert(g2.HasPropertyEdge(e), Equals, true)
+				case gogl.WeightedGraph:
+					c.Assert(g2.HasWeightedEdge(e), Equals, true)
+				case gogl.LabeledGraph:
+					c.Assert(g2.HasLabeledEdge(e), Equals, true)
+				case gogl.DataGraph:
+					c.Assert(g2.HasDataEdge(e), Equals, true)
+				default:
+					c.Assert(g2.HasEdge(e), Equals, true)
+				}
+			}
+		}
+	}
+}
+
+func (s *BinarySuite) TestRoundTripMixed(c *C) {
+	g := gogl.Spec().Mixed().Using(gogl.EdgeList{gogl.NewArc(1, 2), gogl.NewEdge(2, 3)}).Create(al.G)
+
+	g2 := roundTrip(c, Default, g).(gogl.MixedGraph)
+	c.Assert(g2.HasArc(gogl.NewArc(1, 2)), Equals, true)
+	c.Assert(g2.HasArc(gogl.NewArc(2, 3)), Equals, false)
+	c.Assert(g2.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+}
+
+func (s *BinarySuite) TestRoundTripVertexAttributes(c *C) {
+	g := gogl.Spec().Using(gogl.EdgeList{gogl.NewEdge("a", "b")}).Create(al.G)
+	g.(gogl.VertexSetMutator).EnsureVertex("isolate")
+	g.(gogl.VertexAttributes).SetVertexLabel("a", "start")
+	g.(gogl.VertexAttributes).SetVertexWeight("isolate", 1.5)
+	g.(gogl.VertexAttributes).SetVertexData("b", uint16(7))
+
+	g2 := roundTrip(c, Default, g)
+	c.Assert(g2.HasVertex("isolate"), Equals, true)
+
+	vas := g2.(gogl.VertexAttributeSource)
+	label, _ := vas.VertexLabel("a")
+	c.Assert(label, Equals, "start")
+	weight, _ := vas.VertexWeight("isolate")
+	c.Assert(weight, Equals, 1.5)
+	data, _ := vas.VertexData("b")
+	c.Assert(data, Equals, uint16(7))
+	_, exists := vas.VertexWeight("a")
+	c.Assert(exists, Equals, false)
+}
+
+type point struct{ x, y int8 }
+
+// A codec for point vertices.
+type pointCodec struct{}
+
+func (pointCodec) Encode(v interface{}) ([]byte, error) {
+	p, ok := v.(point)
+	if !ok {
+		return nil, errors.New("not a point")
+	}
+	return []byte{byte(p.x), byte(p.y)}, nil
+}
+
+func (pointCodec) Decode(b []byte) (interface{}, error) {
+	return point{int8(b[0]), int8(b[1])}, nil
+}
+
+func (s *BinarySuite) TestVertexCodec(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{gogl.NewArc(point{0, 0}, point{1, -1})}).Create(al.G)
+
+	g2 := roundTrip(c, Format{Vertex: pointCodec{}, Data: Scalar}, g).(gogl.Digraph)
+	c.Assert(g2.HasArc(gogl.NewArc(point{0, 0}, point{1, -1})), Equals, true)
+
+	err := Encode(&bytes.Buffer{}, g)
+	c.Assert(err, ErrorMatches, `The Scalar codec cannot encode a binary.point; supply a Codec that can.`)
+}
+
+func (s *BinarySuite) TestCompact(c *C) {
+	var el gogl.WeightedEdgeList
+	for i := 0; i < 1000; i++ {
+		el = append(el, gogl.NewWeightedEdge(i, i+1, 2))
+	}
+
+	var buf bytes.Buffer
+	c.Assert(Encode(&buf, el), IsNil)
+
+	// Per edge: a flag byte, two indices of at most two bytes, and a one byte weight
+	c.Assert(buf.Len() < 1001*4+1000*6, Equals, true)
+}
+
+func (s *BinarySuite) TestScalarCodec(c *C) {
+	for _, v := range []interface{}{nil, "", "foo", true, false, 0, -1, int8(-8), int16(16), int32(-32), int64(1 << 40),
+		uint(1), uint8(8), uint16(16), uint32(32), uint64(1 << 63), float32(1.5), 3.14159, -0.0} {
+		b, err := Scalar.Encode(v)
+		c.Assert(err, IsNil)
+
+		v2, err := Scalar.Decode(b)
+		c.Assert(err, IsNil)
+		c.Assert(v2, Equals, v)
+	}
+
+	_, err := Scalar.Decode([]byte{200})
+	c.Assert(err, ErrorMatches, "Unknown scalar type tag 200.")
+}
+
+func (s *BinarySuite) TestDecodeErrors(c *C) {
+	_, err := Decode(bytes.NewReader([]byte("nope")))
+	c.Assert(err, ErrorMatches, "Not a gogl binary graph.")
+
+	_, err = Decode(bytes.NewReader([]byte("gogl\x02")))
+	c.Assert(err, ErrorMatches, "Unsupported binary graph version 2.")
+
+	var buf bytes.Buffer
+	c.Assert(Encode(&buf, gogl.EdgeList{gogl.NewEdge(1, 2)}), IsNil)
+	full := buf.Bytes()
+
+	for i := 5; i < len(full); i++ {
+		_, err = Decode(bytes.NewReader(full[:i]))
+		c.Assert(err, NotNil)
+	}
+
+	// Corrupt the final vertex index of the edge, and then the end flag
+	bad := append([]byte{}, full...)
+	bad[len(bad)-3] = 9
+	_, err = Decode(bytes.NewReader(bad))
+	c.Assert(err, ErrorMatches, "Malformed graph: vertex index out of range.")
+
+	bad = append([]byte{}, full...)
+	bad[len(bad)-2] = 1 << 5
+	_, err = Decode(bytes.NewReader(bad))
+	c.Assert(err, ErrorMatches, "Malformed graph: unknown edge flags.")
+}
+
+// An edge list that reports its properties, and counts its edge enumerations.
+type countedEdges struct {
+	gogl.EdgeList
+	n int
+}
+
+func (l *countedEdges) Edges(f gogl.EdgeStep) {
+	l.n++
+	l.EdgeList.Edges(f)
+}
+
+func (l *countedEdges) Properties() gogl.GraphProperties {
+	return gogl.Spec().Props
+}
+
+func (s *BinarySuite) TestEdgesEnumeratedOnce(c *C) {
+	l := &countedEdges{EdgeList: gogl.EdgeList{gogl.NewEdge(1, 2), gogl.NewEdge(2, 3)}}
+
+	var buf bytes.Buffer
+	c.Assert(Encode(&buf, l), IsNil)
+	c.Assert(l.n, Equals, 1)
+
+	src, err := Decode(&buf)
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Size(src), Equals, 2)
+}
+
+// An edge list that omits the target of its edge from its vertices.
+type strayVertex struct {
+	gogl.EdgeList
+}
+
+func (strayVertex) Vertices(f gogl.VertexStep) {
+	f(1)
+}
+
+func (s *BinarySuite) TestEncodeStrayVertex(c *C) {
+	err := Encode(&bytes.Buffer{}, strayVertex{gogl.EdgeList{gogl.NewEdge(1, 2)}})
+	c.Assert(err, ErrorMatches, `Edge .* refers to a vertex not among the graph's vertices.`)
+}
@@ -0,0 +1,168 @@
+package binary
+
+import (
+	stdbinary "encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// A Codec converts vertices, or edge and vertex data, to and from bytes.
+// As both are interface{}, the binary format relies on a Codec to know how
+// to store them.
+type Codec interface {
+	Encode(v interface{}) ([]byte, error)
+	Decode(b []byte) (interface{}, error)
+}
+
+// Scalar is a Codec for nil, and for values of Go's string, bool and numeric
+// types, each of which is decoded to the same type it was encoded from.
+var Scalar Codec = scalarCodec{}
+
+type scalarCodec struct{}
+
+const (
+	tNil byte = iota
+	tString
+	tBool
+	tInt
+	tInt8
+	tInt16
+	tInt32
+	tInt64
+	tUint
+	tUint8
+	tUint16
+	tUint32
+	tUint64
+	tFloat32
+	tFloat64
+)
+
+func (scalarCodec) Encode(v interface{}) ([]byte, error) {
+	varint := func(t byte, i int64) []byte {
+		b := make([]byte, 1+stdbinary.MaxVarintLen64)
+		b[0] = t
+		return b[:1+stdbinary.PutVarint(b[1:], i)]
+	}
+	uvarint := func(t byte, u uint64) []byte {
+		b := make([]byte, 1+stdbinary.MaxVarintLen64)
+		b[0] = t
+		return b[:1+stdbinary.PutUvarint(b[1:], u)]
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return []byte{tNil}, nil
+	case string:
+		return append([]byte{tString}, v...), nil
+	case bool:
+		if v {
+			return []byte{tBool, 1}, nil
+		}
+		return []byte{tBool, 0}, nil
+	case int:
+		return varint(tInt, int64(v)), nil
+	case int8:
+		return varint(tInt8, int64(v)), nil
+	case int16:
+		return varint(tInt16, int64(v)), nil
+	case int32:
+		return varint(tInt32, int64(v)), nil
+	case int64:
+		return varint(tInt64, v), nil
+	case uint:
+		return uvarint(tUint, uint64(v)), nil
+	case uint8:
+		return uvarint(tUint8, uint64(v)), nil
+	case uint16:
+		return uvarint(tUint16, uint64(v)), nil
+	case uint32:
+		return uvarint(tUint32, uint64(v)), nil
+	case uint64:
+		return uvarint(tUint64, v), nil
+	case float32:
+		return uvarint(tFloat32, floatBits(float64(v))), nil
+	case float64:
+		return uvarint(tFloat64, floatBits(v)), nil
+	}
+
+	return nil, fmt.Errorf("The Scalar codec cannot encode a %T; supply a Codec that can.", v)
+}
+
+func (scalarCodec) Decode(b []byte) (interface{}, error) {
+	if len(b) == 0 {
+		return nil, errors.New("Empty scalar value.")
+	}
+
+	t, b := b[0], b[1:]
+	switch t {
+	case tNil:
+		return nil, nil
+	case tString:
+		return string(b), nil
+	case tBool:
+		return len(b) > 0 && b[0] == 1, nil
+	case tInt, tInt8, tInt16, tInt32, tInt64:
+		i, n := stdbinary.Varint(b)
+		if n <= 0 {
+			break
+		}
+		switch t {
+		case tInt:
+			return int(i), nil
+		case tInt8:
+			return int8(i), nil
+		case tInt16:
+			return int16(i), nil
+		case tInt32:
+			return int32(i), nil
+		}
+		return i, nil
+	case tUint, tUint8, tUint16, tUint32, tUint64, tFloat32, tFloat64:
+		u, n := stdbinary.Uvarint(b)
+		if n <= 0 {
+			break
+		}
+		switch t {
+		case tUint:
+			return uint(u), nil
+		case tUint8:
+			return uint8(u), nil
+		case tUint16:
+			return uint16(u), nil
+		case tUint32:
+			return uint32(u), nil
+		case tFloat32:
+			return float32(bitsFloat(u)), nil
+		case tFloat64:
+			return bitsFloat(u), nil
+		}
+		return u, nil
+	default:
+		return nil, fmt.Errorf("Unknown scalar type tag %d.", t)
+	}
+
+	return nil, errors.New("Malformed scalar value.")
+}
+
+// Floats are stored as their bits, byte-reversed, as varints; as with gob,
+// this makes floats with short mantissas - such as small integers - compact.
+func floatBits(f float64) uint64 {
+	u := math.Float64bits(f)
+	var r uint64
+	for i := 0; i < 8; i++ {
+		r = r<<8 | u&0xff
+		u >>= 8
+	}
+	return r
+}
+
+func bitsFloat(r uint64) float64 {
+	var u uint64
+	for i := 0; i < 8; i++ {
+		u = u<<8 | r&0xff
+		r >>= 8
+	}
+	return math.Float64frombits(u)
+}
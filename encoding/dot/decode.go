@@ -0,0 +1,500 @@
+package dot
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// A Decoder reads graphs written in the DOT language from an input stream.
+//
+// Decoding produces a GraphSource - a DigraphSource for digraphs - suitable
+// for passing to GraphSpec.Using. The source also implements
+// VertexAttributeSource, reporting the "label", "weight" and "data" attributes
+// found on node statements. Edges are typed according to which of those same
+// attributes they carry; a weight that does not parse as a float is an error.
+//
+// Ports, attributes other than those above, and graph attributes are
+// accepted but discarded. Subgraphs are supported, both as a means of scoping
+// default attributes and as edge operands. Within a digraph, edges with
+// "dir=none" are decoded as undirected edges (making the result a mixed
+// graph), and those with "dir=back" or "dir=both" as the corresponding arcs.
+type Decoder struct {
+	// Produces a vertex from a DOT node ID. If nil, the vertex is the ID
+	// string itself. It is called only once for each distinct ID.
+	Vertex func(id string) (gogl.Vertex, error)
+
+	r   io.Reader
+	lex *lexer
+}
+
+// Creates a new Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Decodes the first graph in the provided DOT data.
+func Unmarshal(data []byte) (gogl.GraphSource, error) {
+	dec := &Decoder{lex: &lexer{src: data, line: 1}}
+	return dec.Decode()
+}
+
+// Reads the next graph from the stream. Successive calls read successive
+// graphs; io.EOF is returned once the stream is exhausted.
+func (dec *Decoder) Decode() (gogl.GraphSource, error) {
+	if dec.lex == nil {
+		src, err := ioutil.ReadAll(dec.r)
+		if err != nil {
+			return nil, err
+		}
+		dec.lex = &lexer{src: src, line: 1}
+	}
+
+	p := &parser{lex: dec.lex, vertex: dec.Vertex, ids: make(map[string]gogl.Vertex)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tEOF {
+		return nil, io.EOF
+	}
+
+	if err := p.graph(); err != nil {
+		return nil, err
+	}
+	return p.src.GraphSource(), nil
+}
+
+// Default attributes in effect within a graph or subgraph.
+type scope struct {
+	node, edge map[string]string
+}
+
+func (sc scope) child() scope {
+	c := scope{node: make(map[string]string), edge: make(map[string]string)}
+	for k, v := range sc.node {
+		c.node[k] = v
+	}
+	for k, v := range sc.edge {
+		c.edge[k] = v
+	}
+	return c
+}
+
+type parser struct {
+	lex      *lexer
+	tok      token
+	vertex   func(string) (gogl.Vertex, error)
+	ids      map[string]gogl.Vertex
+	src      *encoding.Source
+	directed bool
+	strict   bool
+}
+
+func (p *parser) advance() (err error) {
+	p.tok, err = p.lex.next()
+	return
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Line: p.tok.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isPunct(s string) bool {
+	return p.tok.kind == tPunct && p.tok.text == s
+}
+
+func (p *parser) expect(s string) error {
+	if !p.isPunct(s) {
+		return p.errorf("expected '%s', found %s", s, p.tok)
+	}
+	return p.advance()
+}
+
+// graph : [ strict ] (graph | digraph) [ ID ] '{' stmt_list '}'
+func (p *parser) graph() error {
+	if p.tok.is("strict") {
+		p.strict = true
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case p.tok.is("graph"):
+	case p.tok.is("digraph"):
+		p.directed = true
+	default:
+		return p.errorf("expected 'graph' or 'digraph', found %s", p.tok)
+	}
+	p.src = encoding.NewSource(p.directed)
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.tok.kind == tID {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	if _, err := p.stmtList(scope{}.child()); err != nil {
+		return err
+	}
+	if !p.isPunct("}") {
+		return p.errorf("expected '}', found %s", p.tok)
+	}
+
+	// Leave the closing brace as the last token consumed, so that a following
+	// Decode picks up with the next graph.
+	return nil
+}
+
+// Parses statements up to, but not including, a closing brace. Returns all
+// the vertices mentioned within.
+func (p *parser) stmtList(sc scope) (members []gogl.Vertex, err error) {
+	for !p.isPunct("}") {
+		if p.tok.kind == tEOF {
+			return nil, p.errorf("expected '}', found %s", p.tok)
+		}
+
+		var vs []gogl.Vertex
+		if vs, err = p.stmt(sc); err != nil {
+			return nil, err
+		}
+		members = append(members, vs...)
+
+		if p.isPunct(";") {
+			if err = p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return members, nil
+}
+
+func (p *parser) stmt(sc scope) ([]gogl.Vertex, error) {
+	switch {
+	case p.tok.is("graph"), p.tok.is("node"), p.tok.is("edge"):
+		// attr_stmt
+		kw := p.tok
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		attrs, err := p.attrLists()
+		if err != nil {
+			return nil, err
+		}
+
+		// Graph attributes are discarded.
+		into := map[string]string{}
+		if kw.is("node") {
+			into = sc.node
+		} else if kw.is("edge") {
+			into = sc.edge
+		}
+		for k, v := range attrs {
+			into[k] = v
+		}
+		return nil, nil
+	case p.tok.kind == tID && !p.tok.is("subgraph"):
+		id := p.tok
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.isPunct("=") {
+			// Graph attribute assignment; discarded.
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tID {
+				return nil, p.errorf("expected an attribute value, found %s", p.tok)
+			}
+			return nil, p.advance()
+		}
+
+		if err := p.port(); err != nil {
+			return nil, err
+		}
+
+		v, err := p.node(id, sc)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == tEdgeOp {
+			return p.edgeStmt(sc, []gogl.Vertex{v})
+		}
+
+		attrs, err := p.attrLists()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.nodeAttrs(v, attrs, id.line); err != nil {
+			return nil, err
+		}
+
+		return []gogl.Vertex{v}, nil
+	case p.tok.is("subgraph"), p.isPunct("{"):
+		members, err := p.subgraph(sc)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == tEdgeOp {
+			return p.edgeStmt(sc, members)
+		}
+		return members, nil
+	}
+
+	return nil, p.errorf("unexpected %s", p.tok)
+}
+
+// subgraph : [ subgraph [ ID ] ] '{' stmt_list '}'
+func (p *parser) subgraph(sc scope) ([]gogl.Vertex, error) {
+	if p.tok.is("subgraph") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tID {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	members, err := p.stmtList(sc.child())
+	if err != nil {
+		return nil, err
+	}
+	return members, p.expect("}")
+}
+
+// Skips over a node's port, if it has one.
+func (p *parser) port() error {
+	for i := 0; i < 2 && p.isPunct(":"); i++ {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if p.tok.kind != tID {
+			return p.errorf("expected a port, found %s", p.tok)
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parses the remainder of an edge statement. The first operand, already
+// consumed, is passed as the vertices it comprises - a single node, or the
+// members of a subgraph.
+func (p *parser) edgeStmt(sc scope, first []gogl.Vertex) ([]gogl.Vertex, error) {
+	ops := [][]gogl.Vertex{first}
+	var all []gogl.Vertex
+	line := p.tok.line
+
+	for p.tok.kind == tEdgeOp {
+		if p.directed && p.tok.text != "->" {
+			return nil, p.errorf("undirected edge operator '--' used in a digraph")
+		} else if !p.directed && p.tok.text != "--" {
+			return nil, p.errorf("directed edge operator '->' used in an undirected graph")
+		}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.is("subgraph") || p.isPunct("{") {
+			members, err := p.subgraph(sc)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, members)
+		} else if p.tok.kind == tID {
+			v, err := p.node(p.tok, sc)
+			if err != nil {
+				return nil, err
+			}
+			if err = p.advance(); err != nil {
+				return nil, err
+			}
+			if err = p.port(); err != nil {
+				return nil, err
+			}
+			ops = append(ops, []gogl.Vertex{v})
+		} else {
+			return nil, p.errorf("expected a node or subgraph, found %s", p.tok)
+		}
+	}
+
+	list, err := p.attrLists()
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := make(map[string]string, len(sc.edge)+len(list))
+	for k, v := range sc.edge {
+		attrs[k] = v
+	}
+	for k, v := range list {
+		attrs[k] = v
+	}
+
+	var a encoding.Attrs
+	if err = p.decodeAttrs(attrs, &a, line); err != nil {
+		return nil, err
+	}
+
+	for k, op := range ops {
+		all = append(all, op...)
+		if k == 0 {
+			continue
+		}
+
+		for _, u := range ops[k-1] {
+			for _, v := range op {
+				p.addEdges(u, v, a, attrs["dir"])
+			}
+		}
+	}
+
+	return all, nil
+}
+
+func (p *parser) addEdges(u, v gogl.Vertex, a encoding.Attrs, dir string) {
+	var edges []gogl.Edge
+	switch {
+	case !p.directed:
+		edges = append(edges, a.Edge(u, v))
+	case dir == "none":
+		edges = append(edges, a.Edge(u, v))
+	case dir == "back":
+		edges = append(edges, a.Arc(v, u))
+	case dir == "both":
+		edges = append(edges, a.Arc(u, v), a.Arc(v, u))
+	default:
+		edges = append(edges, a.Arc(u, v))
+	}
+
+	for _, e := range edges {
+		if !p.strict || !p.src.HasEdge(e) {
+			p.src.AddEdge(e)
+		}
+	}
+}
+
+// attr_list : '[' [ a_list ] ']' [ attr_list ]
+func (p *parser) attrLists() (map[string]string, error) {
+	attrs := make(map[string]string)
+
+	for p.isPunct("[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		for !p.isPunct("]") {
+			if p.tok.kind != tID {
+				return nil, p.errorf("expected an attribute name, found %s", p.tok)
+			}
+			k := p.tok.text
+
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tID {
+				return nil, p.errorf("expected an attribute value, found %s", p.tok)
+			}
+			attrs[k] = p.tok.text
+
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.isPunct(",") || p.isPunct(";") {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	return attrs, nil
+}
+
+// Picks the weight, label and data out of a set of attributes belonging to
+// the statement on the given line.
+func (p *parser) decodeAttrs(attrs map[string]string, a *encoding.Attrs, line int) error {
+	if w, exists := attrs["weight"]; exists {
+		weight, err := strconv.ParseFloat(w, 64)
+		if err != nil {
+			return &SyntaxError{Line: line, Msg: fmt.Sprintf("weight %q is not a number", w)}
+		}
+		a.SetWeight(weight)
+	}
+	if label, exists := attrs["label"]; exists {
+		a.SetLabel(label)
+	}
+	if data, exists := attrs["data"]; exists {
+		a.SetData(data)
+	}
+
+	return nil
+}
+
+// Returns the vertex for a node ID, adding it to the graph if it is new and
+// applying any default node attributes in scope.
+func (p *parser) node(id token, sc scope) (gogl.Vertex, error) {
+	if v, exists := p.ids[id.text]; exists {
+		return v, nil
+	}
+
+	var v gogl.Vertex = id.text
+	if p.vertex != nil {
+		var err error
+		if v, err = p.vertex(id.text); err != nil {
+			return nil, err
+		}
+	}
+
+	p.ids[id.text] = v
+	p.src.AddVertex(v)
+	return v, p.nodeAttrs(v, sc.node, id.line)
+}
+
+func (p *parser) nodeAttrs(v gogl.Vertex, attrs map[string]string, line int) error {
+	var a encoding.Attrs
+	if err := p.decodeAttrs(attrs, &a, line); err != nil {
+		return err
+	}
+
+	if a.Props&gogl.G_WEIGHTED != 0 {
+		p.src.SetVertexWeight(v, a.Weight)
+	}
+	if a.Props&gogl.G_LABELED != 0 {
+		p.src.SetVertexLabel(v, a.Label)
+	}
+	if a.Props&gogl.G_DATA != 0 {
+		p.src.SetVertexData(v, a.Data)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package dot
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type DotSuite struct{}
+
+var _ = Suite(&DotSuite{})
+
+func atoi(id string) (gogl.Vertex, error) {
+	return strconv.Atoi(id)
+}
+
+func (s *DotSuite) TestEncodeUndirected(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{gogl.NewWeightedEdge(1, 2, 5.23)}).Create(al.G)
+
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+
+	dot := string(out)
+	c.Assert(strings.HasPrefix(dot, "graph {\n"), Equals, true)
+	c.Assert(strings.Contains(dot, "\t1;\n"), Equals, true)
+	c.Assert(strings.Contains(dot, "1 -- 2 [weight=5.23];") || strings.Contains(dot, "2 -- 1 [weight=5.23];"), Equals, true)
+}
+
+func (s *DotSuite) TestEncodeNaming(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{gogl.NewArc(1, 2)}).Create(al.G)
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.Name = "my graph"
+	enc.VertexName = func(v gogl.Vertex) string {
+		return "v" + strconv.Itoa(v.(int))
+	}
+	c.Assert(enc.Encode(g), IsNil)
+
+	dot := buf.String()
+	c.Assert(strings.HasPrefix(dot, "digraph \"my graph\" {\n"), Equals, true)
+	c.Assert(strings.Contains(dot, "\tv1 -> v2;\n"), Equals, true)
+}
+
+func (s *DotSuite) TestQuoteID(c *C) {
+	c.Assert(quoteID("foo_1"), Equals, "foo_1")
+	c.Assert(quoteID("-1.5"), Equals, "-1.5")
+	c.Assert(quoteID("node"), Equals, `"node"`)
+	c.Assert(quoteID("1e+21"), Equals, `"1e+21"`)
+	c.Assert(quoteID(`say "hi"\`), Equals, `"say \"hi\"\\"`)
+}
+
+func (s *DotSuite) TestRoundTrip(c *C) {
+	g := gogl.Spec().Directed().PropertyEdges().Using(gogl.PropertyArcList{
+		gogl.NewPropertyArc(1, 2, 5.23, "foo bar", "data"),
+		gogl.NewPropertyArc(2, 3, -1, `"quoted"`, "x"),
+	}).Create(al.G)
+	g.(gogl.VertexSetMutator).EnsureVertex(4)
+	g.(gogl.VertexAttributes).SetVertexLabel(1, "start")
+	g.(gogl.VertexAttributes).SetVertexWeight(4, 1.5)
+
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+
+	dec := NewDecoder(bytes.NewReader(out))
+	dec.Vertex = atoi
+	src, err := dec.Decode()
+	c.Assert(err, IsNil)
+	c.Assert(src, Implements, new(gogl.DigraphSource))
+
+	g2 := gogl.Spec().Directed().PropertyEdges().Using(src).Create(al.G).(gogl.PropertyDigraph)
+	c.Assert(gogl.Order(g2), Equals, 4)
+	c.Assert(gogl.Size(g2), Equals, 2)
+	c.Assert(g2.HasPropertyArc(gogl.NewPropertyArc(1, 2, 5.23, "foo bar", "data")), Equals, true)
+	c.Assert(g2.HasPropertyArc(gogl.NewPropertyArc(2, 3, -1, `"quoted"`, "x")), Equals, true)
+
+	vas := g2.(gogl.VertexAttributeSource)
+	label, exists := vas.VertexLabel(1)
+	c.Assert(exists, Equals, true)
+	c.Assert(label, Equals, "start")
+	weight, exists := vas.VertexWeight(4)
+	c.Assert(exists, Equals, true)
+	c.Assert(weight, Equals, 1.5)
+}
+
+func (s *DotSuite) TestRoundTripMixed(c *C) {
+	g := gogl.Spec().Mixed().Using(gogl.EdgeList{gogl.NewArc(1, 2), gogl.NewEdge(2, 3)}).Create(al.G)
+
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(out), "[dir=none]"), Equals, true)
+
+	dec := NewDecoder(bytes.NewReader(out))
+	dec.Vertex = atoi
+	src, err := dec.Decode()
+	c.Assert(err, IsNil)
+
+	g2 := gogl.Spec().Mixed().Using(src).Create(al.G).(gogl.MixedGraph)
+	c.Assert(g2.HasArc(gogl.NewArc(1, 2)), Equals, true)
+	c.Assert(g2.HasArc(gogl.NewArc(2, 3)), Equals, false)
+	c.Assert(g2.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+}
+
+func (s *DotSuite) TestDecodeDocFile(c *C) {
+	// Taken from the illustrations under doc/
+	src, err := Unmarshal([]byte(`digraph G {
+  node [color="grey"]
+  edge [color="grey"]
+  a -> b -> c;
+  a -> c;
+  d -> a;
+  d -> e;
+  a [style=filled,color="#23A7C0"];
+  f [style=filled,color="#23A7C0"];
+  label="EachVertex()"
+}`))
+	c.Assert(err, IsNil)
+
+	g := gogl.Spec().Directed().Using(src).Create(al.G).(gogl.Digraph)
+	c.Assert(gogl.Order(g), Equals, 6)
+	c.Assert(gogl.Size(g), Equals, 5)
+	c.Assert(g.HasArc(gogl.NewArc("a", "b")), Equals, true)
+	c.Assert(g.HasArc(gogl.NewArc("b", "c")), Equals, true)
+	c.Assert(g.HasVertex("f"), Equals, true)
+}
+
+func (s *DotSuite) TestDecodeSyntax(c *C) {
+	src, err := Unmarshal([]byte(`
+# a preprocessor line
+strict graph {
+	// line comment
+	/* block
+	   comment */
+	"multi" + "part" -- b:port:n [label=<<b>html</b>>; weight=2];
+	b -- "multipart"; /* dropped, as the graph is strict */
+	c -- { d e } -- f
+	subgraph s { edge [label=inner] g -- h }
+	-1.5 -- .5
+}`))
+	c.Assert(err, IsNil)
+	c.Assert(src, Not(Implements), new(gogl.DigraphSource))
+
+	var edges []gogl.Edge
+	src.Edges(func(e gogl.Edge) (terminate bool) {
+		edges = append(edges, e)
+		return
+	})
+
+	c.Assert(edges, HasLen, 7)
+	c.Assert(edges[0], DeepEquals, gogl.NewPropertyEdge("multipart", "b", 2, "<b>html</b>", nil))
+	c.Assert(edges[1], DeepEquals, gogl.NewEdge("c", "d"))
+	c.Assert(edges[4], DeepEquals, gogl.NewEdge("e", "f"))
+	c.Assert(edges[5], DeepEquals, gogl.NewLabeledEdge("g", "h", "inner"))
+	c.Assert(edges[6], DeepEquals, gogl.NewEdge("-1.5", ".5"))
+}
+
+func (s *DotSuite) TestDecodeDirections(c *C) {
+	src, err := Unmarshal([]byte(`digraph { a -> b [dir=back]; c -> d [dir=both] }`))
+	c.Assert(err, IsNil)
+
+	g := gogl.Spec().Directed().Using(src).Create(al.G).(gogl.Digraph)
+	c.Assert(g.HasArc(gogl.NewArc("b", "a")), Equals, true)
+	c.Assert(g.HasArc(gogl.NewArc("a", "b")), Equals, false)
+	c.Assert(g.HasArc(gogl.NewArc("c", "d")), Equals, true)
+	c.Assert(g.HasArc(gogl.NewArc("d", "c")), Equals, true)
+}
+
+func (s *DotSuite) TestDecodeMultiple(c *C) {
+	dec := NewDecoder(strings.NewReader("graph { a -- b }\ndigraph { c -> d }\n"))
+
+	src, err := dec.Decode()
+	c.Assert(err, IsNil)
+	c.Assert(src, Not(Implements), new(gogl.DigraphSource))
+
+	src, err = dec.Decode()
+	c.Assert(err, IsNil)
+	c.Assert(src, Implements, new(gogl.DigraphSource))
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{"c", "d"})
+
+	_, err = dec.Decode()
+	c.Assert(err, Equals, io.EOF)
+}
+
+func (s *DotSuite) TestDecodeErrors(c *C) {
+	_, err := Unmarshal([]byte("graph {\n a -> b\n}"))
+	c.Assert(err, ErrorMatches, "Syntax error on line 2: directed edge operator '->' used in an undirected graph")
+
+	_, err = Unmarshal([]byte("digraph {\n\n a -> b [weight=heavy]\n}"))
+	c.Assert(err, ErrorMatches, `Syntax error on line 3: weight "heavy" is not a number`)
+
+	_, err = Unmarshal([]byte("graph { a -- b"))
+	c.Assert(err, ErrorMatches, "Syntax error on line 1: expected '}', found end of input")
+
+	_, err = Unmarshal([]byte(`graph { "a }`))
+	c.Assert(err, ErrorMatches, "Syntax error on line 1: unterminated string")
+
+	_, err = Unmarshal([]byte(`tree { }`))
+	c.Assert(err, ErrorMatches, "Syntax error on line 1: expected 'graph' or 'digraph', found 'tree'")
+}
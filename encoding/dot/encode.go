@@ -0,0 +1,175 @@
+// Reads and writes graphs in the DOT language used by Graphviz.
+//
+// Edge weights and labels are written as the "weight" and "label" edge
+// attributes, and non-nil edge data - formatted via fmt.Sprint - as "data". Vertex
+// attributes use the same three names on node statements. Every vertex is
+// written as a node statement, so vertex isolates survive a round trip.
+//
+// Mixed graphs are written as digraphs in which the undirected edges carry a
+// "dir=none" attribute; when read back, such edges are decoded as undirected.
+package dot
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+var (
+	plainID   = regexp.MustCompile(`^[a-zA-Z_\x{80}-\x{10FFFF}][a-zA-Z_0-9\x{80}-\x{10FFFF}]*$`)
+	numeralID = regexp.MustCompile(`^-?(\.[0-9]+|[0-9]+(\.[0-9]*)?)$`)
+)
+
+// Keywords must be quoted to be used as IDs.
+var keywords = map[string]bool{
+	"node":     true,
+	"edge":     true,
+	"graph":    true,
+	"digraph":  true,
+	"subgraph": true,
+	"strict":   true,
+}
+
+// Formats a string as a DOT ID, quoting it if necessary.
+func quoteID(s string) string {
+	if (plainID.MatchString(s) && !keywords[strings.ToLower(s)]) || numeralID.MatchString(s) {
+		return s
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// An Encoder writes graphs to an output stream in the DOT language.
+type Encoder struct {
+	// The ID given to the written graph. If empty, the graph is anonymous.
+	Name string
+	// Produces the DOT ID for a vertex. If nil, vertices are named via fmt.Sprint;
+	// either way, IDs are quoted as necessary. Distinct vertices must have
+	// distinct names.
+	VertexName func(gogl.Vertex) string
+
+	w io.Writer
+}
+
+// Creates a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Returns the DOT encoding of the provided graph, written with a default Encoder.
+func Marshal(g gogl.GraphSource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (enc *Encoder) name(v gogl.Vertex) string {
+	if enc.VertexName != nil {
+		return quoteID(enc.VertexName(v))
+	}
+	return quoteID(fmt.Sprint(v))
+}
+
+// Writes the DOT encoding of the provided graph to the stream.
+//
+// If the graph implements DigraphSource, it is written as a digraph; if it
+// additionally enumerates undirected edges in the manner of a MixedGraph,
+// those edges are written with "dir=none". Otherwise, it is written as an
+// undirected graph.
+func (enc *Encoder) Encode(g gogl.GraphSource) error {
+	w := bufio.NewWriter(enc.w)
+
+	dg, directed := g.(gogl.DigraphSource)
+	kind, op := "graph", "--"
+	if directed {
+		kind, op = "digraph", "->"
+	}
+
+	if enc.Name != "" {
+		fmt.Fprintf(w, "%s %s {\n", kind, quoteID(enc.Name))
+	} else {
+		fmt.Fprintf(w, "%s {\n", kind)
+	}
+
+	vas, _ := g.(gogl.VertexAttributeSource)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		var attrs []string
+		if vas != nil {
+			if label, exists := vas.VertexLabel(v); exists {
+				attrs = append(attrs, "label="+quoteID(label))
+			}
+			if weight, exists := vas.VertexWeight(v); exists {
+				attrs = append(attrs, "weight="+quoteID(formatFloat(weight)))
+			}
+			if data, exists := vas.VertexData(v); exists && data != nil {
+				attrs = append(attrs, "data="+quoteID(fmt.Sprint(data)))
+			}
+		}
+
+		writeStmt(w, enc.name(v), attrs)
+		return
+	})
+
+	edge := func(e gogl.Edge, extra ...string) {
+		u, v := e.Both()
+		writeStmt(w, enc.name(u)+" "+op+" "+enc.name(v), append(edgeAttrs(e), extra...))
+	}
+
+	if directed {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			edge(a)
+			return
+		})
+
+		if ug, ok := g.(gogl.UndirectedEdgeEnumerator); ok {
+			ug.UndirectedEdges(func(e gogl.Edge) (terminate bool) {
+				edge(e, "dir=none")
+				return
+			})
+		}
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			edge(e)
+			return
+		})
+	}
+
+	w.WriteString("}\n")
+	return w.Flush()
+}
+
+func edgeAttrs(e gogl.Edge) (attrs []string) {
+	a := encoding.AttrsOf(e)
+	if a.Props&gogl.G_WEIGHTED != 0 {
+		attrs = append(attrs, "weight="+quoteID(formatFloat(a.Weight)))
+	}
+	if a.Props&gogl.G_LABELED != 0 {
+		attrs = append(attrs, "label="+quoteID(a.Label))
+	}
+	if a.Props&gogl.G_DATA != 0 && a.Data != nil {
+		attrs = append(attrs, "data="+quoteID(fmt.Sprint(a.Data)))
+	}
+	return
+}
+
+func writeStmt(w *bufio.Writer, stmt string, attrs []string) {
+	w.WriteString("\t" + stmt)
+	if len(attrs) > 0 {
+		w.WriteString(" [" + strings.Join(attrs, ", ") + "]")
+	}
+	w.WriteString(";\n")
+}
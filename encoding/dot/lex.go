@@ -0,0 +1,267 @@
+package dot
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tEOF    tokenKind = iota
+	tID               // identifier, numeral, quoted string or HTML string
+	tPunct            // one of { } [ ] ; , = :
+	tEdgeOp           // -> or --
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	quoted bool // true for quoted and HTML strings, which are never keywords
+	line   int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tEOF:
+		return "end of input"
+	case tID:
+		if t.quoted {
+			return fmt.Sprintf("%q", t.text)
+		}
+	}
+	return "'" + t.text + "'"
+}
+
+// Indicates whether the token is the given keyword. Keywords are case-insensitive.
+func (t token) is(keyword string) bool {
+	return t.kind == tID && !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+// A SyntaxError describes malformed DOT input.
+type SyntaxError struct {
+	Line int // The line on which the error was encountered
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("Syntax error on line %d: %s", e.Line, e.Msg)
+}
+
+type lexer struct {
+	src  []byte
+	pos  int
+	line int
+}
+
+func isIDStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) peekByte(off int) byte {
+	if l.pos+off < len(l.src) {
+		return l.src[l.pos+off]
+	}
+	return 0
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Line: l.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Skips whitespace, comments, and lines beginning with '#', which DOT treats
+// as preprocessor output.
+func (l *lexer) skip() error {
+	atLineStart := l.pos == 0
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+			atLineStart = true
+			continue
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			l.pos++
+			continue
+		case c == '#' && atLineStart:
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+			continue
+		case c == '/' && l.peekByte(1) == '/':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+			continue
+		case c == '/' && l.peekByte(1) == '*':
+			start := l.line
+			l.pos += 2
+			for {
+				if l.pos >= len(l.src) {
+					return &SyntaxError{Line: start, Msg: "unterminated comment"}
+				}
+				if l.src[l.pos] == '*' && l.peekByte(1) == '/' {
+					l.pos += 2
+					break
+				}
+				if l.src[l.pos] == '\n' {
+					l.line++
+				}
+				l.pos++
+			}
+			continue
+		}
+		return nil
+	}
+	return nil
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skip(); err != nil {
+		return token{}, err
+	}
+
+	if l.pos >= len(l.src) {
+		return token{kind: tEOF, line: l.line}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.IndexByte("{}[];,=:", c) >= 0:
+		l.pos++
+		return token{kind: tPunct, text: string(c), line: l.line}, nil
+	case c == '-' && (l.peekByte(1) == '>' || l.peekByte(1) == '-'):
+		l.pos += 2
+		return token{kind: tEdgeOp, text: string(l.src[l.pos-2 : l.pos]), line: l.line}, nil
+	case c == '"':
+		return l.quoted()
+	case c == '<':
+		return l.html()
+	case isIDStart(c):
+		start := l.pos
+		for l.pos < len(l.src) && (isIDStart(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tID, text: string(l.src[start:l.pos]), line: l.line}, nil
+	case c == '-' || c == '.' || isDigit(c):
+		return l.numeral()
+	}
+
+	return token{}, l.errorf("unexpected character %q", c)
+}
+
+func (l *lexer) numeral() (token, error) {
+	start := l.pos
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+
+	var digits, dot bool
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if isDigit(c) {
+			digits = true
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		l.pos++
+	}
+
+	if !digits {
+		return token{}, l.errorf("malformed numeral %q", l.src[start:l.pos])
+	}
+	return token{kind: tID, text: string(l.src[start:l.pos]), line: l.line}, nil
+}
+
+// Lexes a double-quoted string, along with any further strings concatenated
+// to it with '+'.
+func (l *lexer) quoted() (token, error) {
+	tok := token{kind: tID, quoted: true, line: l.line}
+	var buf []byte
+
+	for {
+		l.pos++ // opening quote
+		for {
+			if l.pos >= len(l.src) {
+				return token{}, &SyntaxError{Line: tok.line, Msg: "unterminated string"}
+			}
+
+			c := l.src[l.pos]
+			if c == '"' {
+				l.pos++
+				break
+			}
+
+			if c == '\\' {
+				switch l.peekByte(1) {
+				case '"', '\\':
+					buf = append(buf, l.peekByte(1))
+					l.pos += 2
+					continue
+				case '\n':
+					// Line continuation
+					l.line++
+					l.pos += 2
+					continue
+				}
+			}
+
+			if c == '\n' {
+				l.line++
+			}
+			buf = append(buf, c)
+			l.pos++
+		}
+
+		// Look past any whitespace for a concatenation
+		save, saveLine := l.pos, l.line
+		if err := l.skip(); err != nil {
+			return token{}, err
+		}
+		if l.peekByte(0) == '+' {
+			l.pos++
+			if err := l.skip(); err != nil {
+				return token{}, err
+			}
+			if l.peekByte(0) == '"' {
+				continue
+			}
+			return token{}, l.errorf("expected a string after '+'")
+		}
+
+		l.pos, l.line = save, saveLine
+		tok.text = string(buf)
+		return tok, nil
+	}
+}
+
+// Lexes an HTML string, delimited by balanced angle brackets.
+func (l *lexer) html() (token, error) {
+	tok := token{kind: tID, quoted: true, line: l.line}
+	start := l.pos + 1
+
+	for depth := 0; l.pos < len(l.src); l.pos++ {
+		switch l.src[l.pos] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 {
+				tok.text = string(l.src[start:l.pos])
+				l.pos++
+				return tok, nil
+			}
+		case '\n':
+			l.line++
+		}
+	}
+
+	return token{}, &SyntaxError{Line: tok.line, Msg: "unterminated HTML string"}
+}
@@ -0,0 +1,86 @@
+// Contains pieces shared by gogl's graph encoding subpackages: a record of the
+// properties carried by an edge, and an in-memory GraphSource for decoders to
+// assemble their results into.
+package encoding
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Attrs records which of the optional edge properties - weight, label and
+// data - an edge carries, along with their values.
+type Attrs struct {
+	Props  gogl.GraphProperties // Some combination of G_WEIGHTED, G_LABELED and G_DATA
+	Weight float64
+	Label  string
+	Data   interface{}
+}
+
+// Collects the properties carried by the provided edge.
+func AttrsOf(e gogl.Edge) (a Attrs) {
+	if we, ok := e.(gogl.WeightedEdge); ok {
+		a.Props |= gogl.G_WEIGHTED
+		a.Weight = we.Weight()
+	}
+	if le, ok := e.(gogl.LabeledEdge); ok {
+		a.Props |= gogl.G_LABELED
+		a.Label = le.Label()
+	}
+	if de, ok := e.(gogl.DataEdge); ok {
+		a.Props |= gogl.G_DATA
+		a.Data = de.Data()
+	}
+
+	return
+}
+
+// Sets the weight, marking it present.
+func (a *Attrs) SetWeight(weight float64) {
+	a.Props |= gogl.G_WEIGHTED
+	a.Weight = weight
+}
+
+// Sets the label, marking it present.
+func (a *Attrs) SetLabel(label string) {
+	a.Props |= gogl.G_LABELED
+	a.Label = label
+}
+
+// Sets the data, marking it present.
+func (a *Attrs) SetData(data interface{}) {
+	a.Props |= gogl.G_DATA
+	a.Data = data
+}
+
+// Creates an undirected edge of the narrowest type that can carry all the
+// present properties.
+func (a Attrs) Edge(u, v gogl.Vertex) gogl.Edge {
+	switch a.Props & (gogl.G_WEIGHTED | gogl.G_LABELED | gogl.G_DATA) {
+	case 0:
+		return gogl.NewEdge(u, v)
+	case gogl.G_WEIGHTED:
+		return gogl.NewWeightedEdge(u, v, a.Weight)
+	case gogl.G_LABELED:
+		return gogl.NewLabeledEdge(u, v, a.Label)
+	case gogl.G_DATA:
+		return gogl.NewDataEdge(u, v, a.Data)
+	default:
+		return gogl.NewPropertyEdge(u, v, a.Weight, a.Label, a.Data)
+	}
+}
+
+// Creates an arc of the narrowest type that can carry all the present properties.
+func (a Attrs) Arc(u, v gogl.Vertex) gogl.Arc {
+	switch a.Props & (gogl.G_WEIGHTED | gogl.G_LABELED | gogl.G_DATA) {
+	case 0:
+		return gogl.NewArc(u, v)
+	case gogl.G_WEIGHTED:
+		return gogl.NewWeightedArc(u, v, a.Weight)
+	case gogl.G_LABELED:
+		return gogl.NewLabeledArc(u, v, a.Label)
+	case gogl.G_DATA:
+		return gogl.NewDataArc(u, v, a.Data)
+	default:
+		return gogl.NewPropertyArc(u, v, a.Weight, a.Label, a.Data)
+	}
+}
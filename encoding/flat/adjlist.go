@@ -0,0 +1,146 @@
+package flat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/sdboyer/gogl"
+)
+
+// An AdjListFormat describes an adjacency list file, in the style of
+// networkx's write_adjlist: each line holds a vertex followed by its
+// neighbors, separated by whitespace. Adjacency lists cannot carry edge
+// properties.
+//
+// In an undirected adjacency list, each edge need only be listed once, under
+// either of its endpoints; in a directed one, each line lists the vertex's
+// successors.
+type AdjListFormat struct {
+	Directed bool   // Whether edges are read as arcs
+	Comment  string // Lines starting with this are ignored; "" for none
+
+	// Produces a vertex from its field. If nil, the vertex is the field
+	// itself. It is called once for each occurrence of a vertex.
+	Vertex func(string) (gogl.Vertex, error)
+	// Produces the field for a vertex. If nil, fmt.Sprint is used.
+	VertexName func(gogl.Vertex) string
+}
+
+// An adjacency list with "#" comments.
+var AdjList = AdjListFormat{Comment: "#"}
+
+// Returns a Source reading the adjacency list in rs.
+func (f AdjListFormat) Read(rs io.ReadSeeker) *Source {
+	return &Source{rs: rs, directed: f.Directed, scan: f.scan}
+}
+
+func (f AdjListFormat) scan(r io.Reader, vf func(gogl.Vertex) error, ef func(gogl.Edge) error) error {
+	return eachLine(r, f.Comment, func(line int, text string) error {
+		var u gogl.Vertex
+		for k, field := range strings.Fields(text) {
+			var v gogl.Vertex = field
+			if f.Vertex != nil {
+				var err error
+				if v, err = f.Vertex(field); err != nil {
+					return &SyntaxError{Line: line, Msg: err.Error()}
+				}
+			}
+
+			if err := vf(v); err != nil {
+				return err
+			}
+
+			if k == 0 {
+				u = v
+				continue
+			}
+
+			var err error
+			if f.Directed {
+				err = ef(gogl.NewArc(u, v))
+			} else {
+				err = ef(gogl.NewEdge(u, v))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Writes the provided graph to w as an adjacency list, one line per vertex.
+//
+// Digraphs have each vertex's successors listed; in mixed graphs, this
+// includes the vertices reached by undirected edges, which are thus written as
+// a pair of arcs. For undirected graphs, each edge is listed only once.
+func (f AdjListFormat) Write(w io.Writer, g gogl.Graph) error {
+	bw := bufio.NewWriter(w)
+
+	name := func(v gogl.Vertex) (string, error) {
+		var s string
+		if f.VertexName != nil {
+			s = f.VertexName(v)
+		} else {
+			s = fmt.Sprint(v)
+		}
+
+		if s == "" || strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+			return "", fmt.Errorf("Vertex name %q cannot be written to an adjacency list.", s)
+		}
+		return s, nil
+	}
+
+	var neighbors func(gogl.Vertex, gogl.VertexStep)
+	if pe, ok := g.(gogl.ProcessionEnumerator); ok {
+		if _, ok := g.(gogl.DigraphSource); ok {
+			neighbors = pe.SuccessorsOf
+		}
+	}
+
+	// Undirected edges are written only under whichever endpoint comes first.
+	var done map[gogl.Vertex]struct{}
+	if neighbors == nil {
+		neighbors = g.AdjacentTo
+		done = make(map[gogl.Vertex]struct{})
+	}
+
+	var err error
+	g.Vertices(func(u gogl.Vertex) (terminate bool) {
+		var line string
+		if line, err = name(u); err != nil {
+			return true
+		}
+
+		neighbors(u, func(v gogl.Vertex) (terminate bool) {
+			if done != nil {
+				if _, exists := done[v]; exists {
+					return
+				}
+			}
+
+			var n string
+			n, err = name(v)
+			line += " " + n
+			return err != nil
+		})
+		if err != nil {
+			return true
+		}
+
+		if done != nil {
+			done[u] = struct{}{}
+		}
+
+		_, err = bw.WriteString(line + "\n")
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
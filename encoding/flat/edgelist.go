@@ -0,0 +1,315 @@
+package flat
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// Columns identifies the optional columns following the two endpoints on
+// each line of an edge list.
+type Columns int
+
+const (
+	Plain       Columns = iota // source target
+	Weight                     // source target weight
+	Label                      // source target label
+	WeightLabel                // source target weight label
+)
+
+// The number of fields on a full edge line with these columns.
+func (c Columns) fields() int {
+	switch c {
+	case Weight, Label:
+		return 3
+	case WeightLabel:
+		return 4
+	default:
+		return 2
+	}
+}
+
+// An EdgeListFormat describes an edge list file, in which each line holds the
+// two endpoints of an edge, followed by the columns it is configured with.
+// A line holding a single field describes a vertex isolate.
+//
+// Fields are separated by Comma. If Comma is zero, fields are separated by
+// any run of whitespace when reading, and by a tab when writing; in that case,
+// a trailing label column extends to the end of the line, and so may contain
+// whitespace. Otherwise, the file is read and written as CSV.
+type EdgeListFormat struct {
+	Directed bool   // Whether edges are read as arcs
+	Comma    rune   // The field separator; zero for whitespace
+	Comment  string // Lines starting with this are ignored; "" for none. Only the first rune counts for CSV.
+	Header   bool   // Whether the first non-comment line is a header
+	Columns  Columns
+
+	// Produces a vertex from its field. If nil, the vertex is the field
+	// itself. It is called once for each occurrence of a vertex.
+	Vertex func(string) (gogl.Vertex, error)
+	// Produces the field for a vertex. If nil, fmt.Sprint is used.
+	VertexName func(gogl.Vertex) string
+}
+
+// A whitespace-separated edge list with "#" comments, in the style of
+// networkx's write_edgelist.
+var Whitespace = EdgeListFormat{Comment: "#"}
+
+// A comma-separated edge list with a header line.
+var CSV = EdgeListFormat{Comma: ',', Header: true}
+
+// Returns a Source reading the edge list in rs.
+func (f EdgeListFormat) Read(rs io.ReadSeeker) *Source {
+	return &Source{rs: rs, directed: f.Directed, scan: f.scan}
+}
+
+func (f EdgeListFormat) vertex(field string) (gogl.Vertex, error) {
+	if f.Vertex != nil {
+		return f.Vertex(field)
+	}
+	return field, nil
+}
+
+func (f EdgeListFormat) name(v gogl.Vertex) string {
+	if f.VertexName != nil {
+		return f.VertexName(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// Splits a line into at most n whitespace-separated fields, the last of
+// which holds the remainder of the line.
+func splitFields(line string, n int) (fields []string) {
+	line = strings.TrimSpace(line)
+	for len(line) > 0 && len(fields) < n-1 {
+		i := strings.IndexFunc(line, unicode.IsSpace)
+		if i < 0 {
+			break
+		}
+		fields = append(fields, line[:i])
+		line = strings.TrimLeftFunc(line[i:], unicode.IsSpace)
+	}
+
+	if len(line) > 0 {
+		fields = append(fields, line)
+	}
+	return
+}
+
+// Calls f for each line in the stream that is neither blank nor a comment.
+func eachLine(r io.Reader, comment string, f func(line int, text string) error) error {
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		text, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		trimmed := strings.TrimSpace(text)
+		if trimmed != "" && (comment == "" || !strings.HasPrefix(trimmed, comment)) {
+			if ferr := f(line, trimmed); ferr != nil {
+				return ferr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func (f EdgeListFormat) scan(r io.Reader, vf func(gogl.Vertex) error, ef func(gogl.Edge) error) error {
+	header := f.Header
+	record := func(line int, fields []string) error {
+		if header {
+			header = false
+			return nil
+		}
+		return f.record(line, fields, vf, ef)
+	}
+
+	if f.Comma == 0 {
+		n := f.Columns.fields()
+		if f.Columns != Label && f.Columns != WeightLabel {
+			// Take in everything, so that extra fields are caught.
+			n = -1
+		}
+
+		return eachLine(r, f.Comment, func(line int, text string) error {
+			if n < 0 {
+				return record(line, strings.Fields(text))
+			}
+			return record(line, splitFields(text, n))
+		})
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = f.Comma
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	for _, c := range f.Comment {
+		cr.Comment = c
+		break
+	}
+
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		line, _ := cr.FieldPos(0)
+		if err = record(line, fields); err != nil {
+			return err
+		}
+	}
+}
+
+// Interprets the fields of a single record.
+func (f EdgeListFormat) record(line int, fields []string, vf func(gogl.Vertex) error, ef func(gogl.Edge) error) error {
+	want := f.Columns.fields()
+	labeled := f.Columns == Label || f.Columns == WeightLabel
+
+	switch {
+	case len(fields) == 1:
+		v, err := f.vertex(fields[0])
+		if err != nil {
+			return &SyntaxError{Line: line, Msg: err.Error()}
+		}
+		return vf(v)
+	case len(fields) == want, labeled && len(fields) == want-1:
+	default:
+		return &SyntaxError{Line: line, Msg: fmt.Sprintf("expected 1 or %d fields, found %d", want, len(fields))}
+	}
+
+	u, err := f.vertex(fields[0])
+	if err != nil {
+		return &SyntaxError{Line: line, Msg: err.Error()}
+	}
+	v, err := f.vertex(fields[1])
+	if err != nil {
+		return &SyntaxError{Line: line, Msg: err.Error()}
+	}
+
+	var a encoding.Attrs
+	if f.Columns == Weight || f.Columns == WeightLabel {
+		weight, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return &SyntaxError{Line: line, Msg: fmt.Sprintf("weight %q is not a number", fields[2])}
+		}
+		a.SetWeight(weight)
+	}
+	if labeled {
+		var label string
+		if len(fields) == want {
+			label = fields[want-1]
+		}
+		a.SetLabel(label)
+	}
+
+	if err = vf(u); err != nil {
+		return err
+	}
+	if err = vf(v); err != nil {
+		return err
+	}
+
+	if f.Directed {
+		return ef(a.Arc(u, v))
+	}
+	return ef(a.Edge(u, v))
+}
+
+// Writes the provided graph to w as an edge list. Weights and labels are
+// taken from the graph's edges, as configured by the format's Columns;
+// vertex isolates are written last, one per line.
+//
+// Digraphs have their arcs written in order; mixed graphs additionally have
+// each of their undirected edges written as a pair of arcs.
+func (f EdgeListFormat) Write(w io.Writer, g gogl.GraphSource) error {
+	bw := bufio.NewWriter(w)
+
+	var write func(fields []string) error
+	if f.Comma == 0 {
+		labeled := f.Columns == Label || f.Columns == WeightLabel
+		write = func(fields []string) error {
+			for k, field := range fields {
+				// Only a trailing label may be empty or hold whitespace, and
+				// nothing may hold a line break.
+				label := labeled && k > 1 && k == len(fields)-1
+				if strings.ContainsAny(field, "\r\n") ||
+					(!label && (field == "" || strings.IndexFunc(field, unicode.IsSpace) >= 0)) {
+					return fmt.Errorf("Field %q cannot be written to a whitespace-separated edge list.", field)
+				}
+			}
+			_, err := bw.WriteString(strings.Join(fields, "\t") + "\n")
+			return err
+		}
+	} else {
+		cw := csv.NewWriter(bw)
+		cw.Comma = f.Comma
+		write = func(fields []string) error {
+			if err := cw.Write(fields); err != nil {
+				return err
+			}
+			cw.Flush()
+			return cw.Error()
+		}
+	}
+
+	if f.Header {
+		header := []string{"source", "target"}
+		switch f.Columns {
+		case Weight:
+			header = append(header, "weight")
+		case Label:
+			header = append(header, "label")
+		case WeightLabel:
+			header = append(header, "weight", "label")
+		}
+		if err := write(header); err != nil {
+			return err
+		}
+	}
+
+	touched := make(map[gogl.Vertex]struct{})
+	err := eachEdge(g, func(e gogl.Edge) error {
+		u, v := e.Both()
+		touched[u], touched[v] = struct{}{}, struct{}{}
+
+		fields := []string{f.name(u), f.name(v)}
+		a := encoding.AttrsOf(e)
+		if f.Columns == Weight || f.Columns == WeightLabel {
+			fields = append(fields, strconv.FormatFloat(a.Weight, 'g', -1, 64))
+		}
+		if f.Columns == Label || f.Columns == WeightLabel {
+			fields = append(fields, a.Label)
+		}
+
+		return write(fields)
+	})
+	if err != nil {
+		return err
+	}
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		if _, exists := touched[v]; !exists {
+			err = write([]string{f.name(v)})
+		}
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,229 @@
+package flat
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type FlatSuite struct{}
+
+var _ = Suite(&FlatSuite{})
+
+func collectEdges(g gogl.EdgeEnumerator) (edges []gogl.Edge) {
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		edges = append(edges, e)
+		return
+	})
+	return
+}
+
+// Counts the reads made of the underlying file, to ensure Sources reread it.
+type countingReader struct {
+	*strings.Reader
+	seeks int
+}
+
+func (r *countingReader) Seek(offset int64, whence int) (int64, error) {
+	r.seeks++
+	return r.Reader.Seek(offset, whence)
+}
+
+func (s *FlatSuite) TestWhitespaceEdgeList(c *C) {
+	r := &countingReader{Reader: strings.NewReader("# a comment\nfoo bar\n\n  bar\tbaz  \nqux\n")}
+	src := Whitespace.Read(r)
+
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{"foo", "bar", "baz", "qux"})
+	c.Assert(collectEdges(src), DeepEquals, []gogl.Edge{gogl.NewEdge("foo", "bar"), gogl.NewEdge("bar", "baz")})
+	c.Assert(src.Err(), IsNil)
+	c.Assert(r.seeks, Equals, 2)
+	c.Assert(src.GraphSource(), Not(Implements), new(gogl.DigraphSource))
+
+	// Early termination
+	var hit int
+	src.Edges(func(e gogl.Edge) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+	c.Assert(src.Err(), IsNil)
+}
+
+func (s *FlatSuite) TestEdgeListColumns(c *C) {
+	f := EdgeListFormat{Directed: true, Columns: WeightLabel, Vertex: atoi}
+	src := f.Read(strings.NewReader("1 2 5.23 a label\n2 3 -1\n"))
+
+	g := gogl.Spec().Directed().PropertyEdges().Using(src.GraphSource()).Create(al.G).(gogl.PropertyDigraph)
+	c.Assert(src.Err(), IsNil)
+	c.Assert(g.HasPropertyArc(gogl.NewPropertyArc(1, 2, 5.23, "a label", nil)), Equals, true)
+	c.Assert(g.HasPropertyArc(gogl.NewPropertyArc(2, 3, -1, "", nil)), Equals, true)
+
+	var buf bytes.Buffer
+	c.Assert(f.Write(&buf, g), IsNil)
+
+	src = f.Read(strings.NewReader(buf.String()))
+	g2 := gogl.Spec().Directed().PropertyEdges().Using(src.GraphSource()).Create(al.G).(gogl.PropertyDigraph)
+	c.Assert(src.Err(), IsNil)
+	c.Assert(gogl.Size(g2), Equals, 2)
+	c.Assert(g2.HasPropertyArc(gogl.NewPropertyArc(1, 2, 5.23, "a label", nil)), Equals, true)
+}
+
+func (s *FlatSuite) TestCSV(c *C) {
+	f := CSV
+	f.Columns = Weight
+	src := f.Read(strings.NewReader("source,target,weight\n\"a, b\",c,1.5\nd\n"))
+
+	c.Assert(collectEdges(src), DeepEquals, []gogl.Edge{gogl.NewWeightedEdge("a, b", "c", 1.5)})
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{"a, b", "c", "d"})
+	c.Assert(src.Err(), IsNil)
+
+	var buf bytes.Buffer
+	c.Assert(f.Write(&buf, gogl.WeightedEdgeList{gogl.NewWeightedEdge("a, b", "c", 1.5)}), IsNil)
+	c.Assert(buf.String(), Equals, "source,target,weight\n\"a, b\",c,1.5\n")
+}
+
+func (s *FlatSuite) TestWriteIsolatesAndMixed(c *C) {
+	g := gogl.Spec().Mixed().Using(gogl.EdgeList{gogl.NewArc(1, 2), gogl.NewEdge(2, 3)}).Create(al.G)
+	g.(gogl.VertexSetMutator).EnsureVertex(4)
+
+	var buf bytes.Buffer
+	c.Assert(Whitespace.Write(&buf, g), IsNil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	c.Assert(lines, HasLen, 4)
+	c.Assert(lines[0], Equals, "1\t2")
+	c.Assert(lines[3], Equals, "4")
+	c.Assert(lines[1] == "2\t3" || lines[1] == "3\t2", Equals, true)
+}
+
+func (s *FlatSuite) TestErrors(c *C) {
+	src := Whitespace.Read(strings.NewReader("a b\na b c\n"))
+	c.Assert(gogl.CollectVertices(src), HasLen, 2)
+	c.Assert(src.Err(), ErrorMatches, "Syntax error on line 2: expected 1 or 2 fields, found 3")
+
+	// Errors are sticky
+	c.Assert(collectEdges(src), HasLen, 0)
+
+	src = EdgeListFormat{Columns: Weight}.Read(strings.NewReader("a b heavy\n"))
+	src.Edges(func(gogl.Edge) bool { return false })
+	c.Assert(src.Err(), ErrorMatches, `Syntax error on line 1: weight "heavy" is not a number`)
+
+	src = SNAP.Read(strings.NewReader("1 x\n"))
+	src.Edges(func(gogl.Edge) bool { return false })
+	c.Assert(src.Err(), ErrorMatches, `Syntax error on line 1: strconv.Atoi: parsing "x": invalid syntax`)
+
+	src = Whitespace.Read(strings.NewReader("a b\n"))
+	src.Vertices(func(gogl.Vertex) bool {
+		src.Edges(func(gogl.Edge) bool { return false })
+		return false
+	})
+	c.Assert(src.Err(), ErrorMatches, "Enumerations of a flat file Source may not be nested.")
+
+	c.Assert(Whitespace.Write(ioutil.Discard, gogl.EdgeList{gogl.NewEdge("a b", "c")}), ErrorMatches, `Field "a b" cannot be written.*`)
+}
+
+func (s *FlatSuite) TestAdjList(c *C) {
+	src := AdjList.Read(strings.NewReader("# comment\na b c\nb c\nd\n"))
+	g := gogl.Spec().Using(src.GraphSource()).Create(al.G)
+	c.Assert(src.Err(), IsNil)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 3)
+
+	var buf bytes.Buffer
+	c.Assert(AdjList.Write(&buf, g), IsNil)
+
+	// Each undirected edge is written once
+	src = AdjList.Read(strings.NewReader(buf.String()))
+	c.Assert(collectEdges(src), HasLen, 3)
+	c.Assert(gogl.CollectVertices(src), HasLen, 4)
+
+	f := AdjListFormat{Directed: true, Vertex: atoi}
+	dg := gogl.Spec().Directed().Using(f.Read(strings.NewReader("1 2 3\n3 1\n")).GraphSource()).Create(al.G).(gogl.Digraph)
+	c.Assert(dg.HasArc(gogl.NewArc(3, 1)), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc(1, 3)), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc(2, 1)), Equals, false)
+
+	buf.Reset()
+	c.Assert(f.Write(&buf, dg), IsNil)
+	src = f.Read(strings.NewReader(buf.String()))
+	c.Assert(collectEdges(src), HasLen, 3)
+	c.Assert(src.GraphSource(), Implements, new(gogl.DigraphSource))
+}
+
+func (s *FlatSuite) TestMatrixMarket(c *C) {
+	src, err := ReadMatrixMarket(strings.NewReader(`%%MatrixMarket matrix coordinate real general
+% a comment
+4 4 2
+1 2 5.5
+3 1 -2
+`))
+	c.Assert(err, IsNil)
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{1, 2, 3, 4})
+
+	g := gogl.Spec().Directed().Weighted().Using(src.GraphSource()).Create(al.G).(gogl.WeightedDigraph)
+	c.Assert(src.Err(), IsNil)
+	c.Assert(g.HasWeightedArc(gogl.NewWeightedArc(1, 2, 5.5)), Equals, true)
+	c.Assert(g.HasWeightedArc(gogl.NewWeightedArc(3, 1, -2)), Equals, true)
+
+	var buf bytes.Buffer
+	c.Assert(WriteMatrixMarket(&buf, g, func(v gogl.Vertex) int { return v.(int) }), IsNil)
+	c.Assert(strings.HasPrefix(buf.String(), "%%MatrixMarket matrix coordinate real general\n4 4 2\n"), Equals, true)
+
+	src, err = ReadMatrixMarket(strings.NewReader(buf.String()))
+	c.Assert(err, IsNil)
+	c.Assert(collectEdges(src), HasLen, 2)
+
+	// Undirected graphs are written as symmetric pattern matrices, lower triangle only
+	buf.Reset()
+	c.Assert(WriteMatrixMarket(&buf, gogl.EdgeList{gogl.NewEdge("a", "b")}, nil), IsNil)
+	c.Assert(buf.String(), Equals, "%%MatrixMarket matrix coordinate pattern symmetric\n2 2 1\n2 1\n")
+
+	src, err = ReadMatrixMarket(strings.NewReader(buf.String()))
+	c.Assert(err, IsNil)
+	c.Assert(src.GraphSource(), Not(Implements), new(gogl.DigraphSource))
+	c.Assert(collectEdges(src), DeepEquals, []gogl.Edge{gogl.NewEdge(2, 1)})
+}
+
+func (s *FlatSuite) TestMatrixMarketErrors(c *C) {
+	_, err := ReadMatrixMarket(strings.NewReader("1 2\n"))
+	c.Assert(err, ErrorMatches, "Syntax error on line 1: missing %%MatrixMarket matrix header")
+
+	_, err = ReadMatrixMarket(strings.NewReader("%%MatrixMarket matrix array real general\n"))
+	c.Assert(err, ErrorMatches, `Syntax error on line 1: unsupported format "array".*`)
+
+	_, err = ReadMatrixMarket(strings.NewReader("%%MatrixMarket matrix coordinate pattern general\n%\n3 4 0\n"))
+	c.Assert(err, ErrorMatches, "Syntax error on line 3: the matrix must be square to describe a graph")
+
+	src, err := ReadMatrixMarket(strings.NewReader("%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 3\n"))
+	c.Assert(err, IsNil)
+	collectEdges(src)
+	c.Assert(src.Err(), ErrorMatches, `Syntax error on line 3: invalid entry "1 3"`)
+}
+
+func (s *FlatSuite) TestSNAP(c *C) {
+	src, err := ReadSNAP(strings.NewReader("# Undirected graph: ca-GrQc.txt\n# Nodes: 3 Edges: 2\n# FromNodeId\tToNodeId\n1\t2\n2\t3\n"))
+	c.Assert(err, IsNil)
+	c.Assert(src.GraphSource(), Not(Implements), new(gogl.DigraphSource))
+	c.Assert(collectEdges(src), DeepEquals, []gogl.Edge{gogl.NewEdge(1, 2), gogl.NewEdge(2, 3)})
+
+	src, err = ReadSNAP(strings.NewReader("1 2\n"))
+	c.Assert(err, IsNil)
+	c.Assert(src.GraphSource(), Implements, new(gogl.DigraphSource))
+
+	var buf bytes.Buffer
+	c.Assert(WriteSNAP(&buf, gogl.ArcList{gogl.NewArc(1, 2)}), IsNil)
+	c.Assert(buf.String(), Equals, "# Directed graph\n# Nodes: 2 Edges: 1\n# FromNodeId\tToNodeId\n1\t2\n")
+
+	src, err = ReadSNAP(strings.NewReader(buf.String()))
+	c.Assert(err, IsNil)
+	c.Assert(src.GraphSource(), Implements, new(gogl.DigraphSource))
+	c.Assert(collectEdges(src), DeepEquals, []gogl.Edge{gogl.NewArc(1, 2)})
+}
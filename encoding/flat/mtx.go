@@ -0,0 +1,239 @@
+package flat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// Reads the header and size line of a Matrix Market file, returning the
+// number of vertices, whether the graph is directed and weighted, and the
+// number of lines consumed.
+func mtxHeader(r io.Reader) (n int, directed, weighted bool, lines int, err error) {
+	br := bufio.NewReader(r)
+
+	next := func() (string, error) {
+		lines++
+		text, err := br.ReadString('\n')
+		if err == io.EOF && text != "" {
+			err = nil
+		}
+		return strings.TrimSpace(text), err
+	}
+
+	text, err := next()
+	if err != nil {
+		return
+	}
+
+	banner := strings.Fields(strings.ToLower(text))
+	if len(banner) != 5 || banner[0] != "%%matrixmarket" || banner[1] != "matrix" {
+		err = &SyntaxError{Line: lines, Msg: "missing %%MatrixMarket matrix header"}
+		return
+	}
+	if banner[2] != "coordinate" {
+		err = &SyntaxError{Line: lines, Msg: fmt.Sprintf("unsupported format %q; only coordinate matrices describe graphs", banner[2])}
+		return
+	}
+
+	switch banner[3] {
+	case "real", "double", "integer":
+		weighted = true
+	case "pattern":
+	default:
+		err = &SyntaxError{Line: lines, Msg: fmt.Sprintf("unsupported field %q", banner[3])}
+		return
+	}
+
+	switch banner[4] {
+	case "general":
+		directed = true
+	case "symmetric":
+	default:
+		err = &SyntaxError{Line: lines, Msg: fmt.Sprintf("unsupported symmetry %q", banner[4])}
+		return
+	}
+
+	for {
+		if text, err = next(); err != nil {
+			return
+		}
+		if text != "" && !strings.HasPrefix(text, "%") {
+			break
+		}
+	}
+
+	size := strings.Fields(text)
+	if len(size) != 3 {
+		err = &SyntaxError{Line: lines, Msg: "expected the matrix size"}
+		return
+	}
+
+	var rows, cols int
+	if rows, err = strconv.Atoi(size[0]); err == nil {
+		cols, err = strconv.Atoi(size[1])
+	}
+	if err != nil || rows != cols || rows < 0 {
+		err = &SyntaxError{Line: lines, Msg: "the matrix must be square to describe a graph"}
+		return
+	}
+
+	return rows, directed, weighted, lines, nil
+}
+
+// Returns a Source reading the Matrix Market coordinate file in rs, which is
+// taken to be the adjacency matrix of a graph. The vertices are the ints from
+// 1 through the size of the matrix.
+//
+// General matrices describe digraphs, with each entry (i, j) an arc from i to
+// j; symmetric matrices describe undirected graphs. Real and integer matrices
+// describe weighted graphs, and pattern matrices basic ones. Other kinds of
+// matrix are rejected.
+//
+// The header is read immediately, so that the Source's directedness is known;
+// any error in it is returned.
+func ReadMatrixMarket(rs io.ReadSeeker) (*Source, error) {
+	n, directed, weighted, skip, err := mtxHeader(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{rs: rs, directed: directed}
+	s.vertices = func(f gogl.VertexStep) bool {
+		for v := 1; v <= n; v++ {
+			if f(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	s.scan = func(r io.Reader, vf func(gogl.Vertex) error, ef func(gogl.Edge) error) error {
+		return eachLine(r, "%", func(line int, text string) error {
+			if line <= skip {
+				return nil
+			}
+
+			fields := strings.Fields(text)
+			want := 2
+			if weighted {
+				want = 3
+			}
+			if len(fields) != want {
+				return &SyntaxError{Line: line, Msg: fmt.Sprintf("expected %d fields, found %d", want, len(fields))}
+			}
+
+			i, err := strconv.Atoi(fields[0])
+			if err == nil {
+				var j int
+				if j, err = strconv.Atoi(fields[1]); err == nil && i >= 1 && i <= n && j >= 1 && j <= n {
+					var a encoding.Attrs
+					if weighted {
+						var weight float64
+						if weight, err = strconv.ParseFloat(fields[2], 64); err != nil {
+							return &SyntaxError{Line: line, Msg: fmt.Sprintf("weight %q is not a number", fields[2])}
+						}
+						a.SetWeight(weight)
+					}
+
+					if directed {
+						return ef(a.Arc(i, j))
+					}
+					return ef(a.Edge(i, j))
+				}
+			}
+
+			return &SyntaxError{Line: line, Msg: fmt.Sprintf("invalid entry %q", text)}
+		})
+	}
+
+	return s, nil
+}
+
+// Writes the provided graph to w as a Matrix Market coordinate file holding
+// its adjacency matrix.
+//
+// Each vertex is given a 1-based index by the provided function; if it is
+// nil, vertices are indexed in the order they are enumerated, and that order
+// must be stable. Indices must be unique, but need not be contiguous.
+//
+// Digraphs and mixed graphs are written as general matrices, and undirected
+// graphs as symmetric ones. Weighted graphs - those with any WeightedEdge -
+// are written as real matrices, and all others as pattern matrices.
+func WriteMatrixMarket(w io.Writer, g gogl.GraphSource, index func(gogl.Vertex) int) error {
+	if index == nil {
+		indices := make(map[gogl.Vertex]int)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			indices[v] = len(indices) + 1
+			return
+		})
+		index = func(v gogl.Vertex) int {
+			return indices[v]
+		}
+	}
+
+	var n, nnz int
+	var err error
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		i := index(v)
+		if i < 1 {
+			err = fmt.Errorf("Vertex %v was given index %d; indices must be positive.", v, i)
+			return true
+		}
+		if i > n {
+			n = i
+		}
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	gp := encoding.Infer(g)
+	directed := gp&gogl.G_DIRECTED != 0
+	weighted := gp&gogl.G_WEIGHTED != 0
+
+	if err = eachEdge(g, func(gogl.Edge) error {
+		nnz++
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	field, symmetry := "pattern", "symmetric"
+	if weighted {
+		field = "real"
+	}
+	if directed {
+		symmetry = "general"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%%%%MatrixMarket matrix coordinate %s %s\n%d %d %d\n", field, symmetry, n, n, nnz)
+
+	err = eachEdge(g, func(e gogl.Edge) error {
+		u, v := e.Both()
+		i, j := index(u), index(v)
+		if !directed && i < j {
+			// Symmetric matrices hold only their lower triangle.
+			i, j = j, i
+		}
+
+		if weighted {
+			_, err := fmt.Fprintf(bw, "%d %d %s\n", i, j, strconv.FormatFloat(encoding.AttrsOf(e).Weight, 'g', -1, 64))
+			return err
+		}
+		_, err := fmt.Fprintf(bw, "%d %d\n", i, j)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,79 @@
+package flat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sdboyer/gogl"
+)
+
+func atoi(field string) (gogl.Vertex, error) {
+	return strconv.Atoi(field)
+}
+
+// The edge list format used by the Stanford Network Analysis Project's
+// datasets: whitespace-separated pairs of integer vertex ids, with "#"
+// comments.
+var SNAP = EdgeListFormat{Comment: "#", Vertex: atoi}
+
+// Returns a Source reading the SNAP edge list in rs. Vertices are ints.
+//
+// SNAP datasets declare themselves as directed or undirected in the comments
+// at the head of the file; those are read immediately to determine the
+// Source's directedness. Files without a declaration are taken as directed,
+// as most SNAP datasets are.
+func ReadSNAP(rs io.ReadSeeker) (*Source, error) {
+	f := SNAP
+	f.Directed = true
+
+	br := bufio.NewReader(rs)
+	for {
+		text, err := br.ReadString('\n')
+		text = strings.ToLower(strings.TrimSpace(text))
+		if !strings.HasPrefix(text, "#") {
+			break
+		}
+
+		if strings.Contains(text, "undirected graph") {
+			f.Directed = false
+			break
+		} else if strings.Contains(text, "directed graph") {
+			break
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return f.Read(rs), nil
+}
+
+// Writes the provided graph to w as a SNAP edge list, with a header
+// declaring its directedness and size. Vertices are written via fmt.Sprint,
+// and so should be ints, if the file is to be read by other SNAP tools.
+func WriteSNAP(w io.Writer, g gogl.GraphSource) error {
+	kind := "Undirected graph"
+	if _, ok := g.(gogl.DigraphSource); ok {
+		kind = "Directed graph"
+	}
+
+	var size int
+	if err := eachEdge(g, func(gogl.Edge) error {
+		size++
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "# %s\n# Nodes: %d Edges: %d\n# FromNodeId\tToNodeId\n", kind, gogl.Order(g), size); err != nil {
+		return err
+	}
+
+	return SNAP.Write(w, g)
+}
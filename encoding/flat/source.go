@@ -0,0 +1,172 @@
+// Reads and writes graphs in flat text formats: delimited edge lists (with
+// optional weight and label columns), adjacency lists, SNAP edge lists and
+// Matrix Market coordinate files.
+//
+// The readers do not load the file into memory. Instead, they produce a
+// Source that rereads the file from the beginning each time its vertices or
+// edges are enumerated; only the set of vertices already seen is held during
+// vertex enumeration. Sources are therefore ideal for passing to
+// GraphSpec.Using, but are comparatively slow to query repeatedly.
+//
+// The writers stream their output as the graph is enumerated, holding at most
+// a set of the graph's vertices in memory.
+package flat
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// A SyntaxError describes a malformed line in a flat file.
+type SyntaxError struct {
+	Line int // The line on which the error was encountered
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("Syntax error on line %d: %s", e.Line, e.Msg)
+}
+
+// Returned internally from a scan when the step function terminates it.
+var errStop = errors.New("enumeration terminated")
+
+// Reads through an input stream, passing each vertex and edge found in it to
+// the provided functions. Vertices may be passed more than once; returning
+// errStop from either function ends the scan.
+type scanner func(r io.Reader, vf func(gogl.Vertex) error, ef func(gogl.Edge) error) error
+
+// A Source is a GraphSource backed by a flat file, which is reread from the
+// beginning on each enumeration.
+//
+// Enumerator methods cannot report errors, so a Source records the first
+// error it encounters - in reading, or in parsing the file - and stops
+// enumerating, and will not enumerate again. Check Err after using a Source.
+//
+// A Source is not safe for concurrent use, nor may its enumerations be
+// nested; as it has only the one underlying file, it can only be reading from
+// one place at a time.
+type Source struct {
+	rs       io.ReadSeeker
+	scan     scanner
+	vertices func(gogl.VertexStep) bool // optional vertex enumeration that needs no scan
+	directed bool
+	busy     bool
+	err      error
+}
+
+// Returns the first error encountered while reading from the Source, if any.
+func (s *Source) Err() error {
+	return s.err
+}
+
+// Returns the Source as a GraphSource that also implements DigraphSource if
+// the file describes a directed graph.
+func (s *Source) GraphSource() gogl.GraphSource {
+	if s.directed {
+		return digraphSource{s}
+	}
+	return s
+}
+
+// Rewinds the file and runs the scanner over it.
+func (s *Source) run(vf func(gogl.Vertex) error, ef func(gogl.Edge) error) {
+	if s.err != nil {
+		return
+	}
+	if s.busy {
+		s.err = errors.New("Enumerations of a flat file Source may not be nested.")
+		return
+	}
+
+	s.busy = true
+	defer func() { s.busy = false }()
+
+	if _, err := s.rs.Seek(0, 0); err != nil {
+		s.err = err
+		return
+	}
+
+	if err := s.scan(s.rs, vf, ef); err != nil && err != errStop {
+		s.err = err
+	}
+}
+
+func (s *Source) Vertices(f gogl.VertexStep) {
+	if s.vertices != nil {
+		s.vertices(f)
+		return
+	}
+
+	seen := make(map[gogl.Vertex]struct{})
+	s.run(func(v gogl.Vertex) error {
+		if _, exists := seen[v]; exists {
+			return nil
+		}
+		seen[v] = struct{}{}
+
+		if f(v) {
+			return errStop
+		}
+		return nil
+	}, func(gogl.Edge) error {
+		return nil
+	})
+}
+
+func (s *Source) Edges(f gogl.EdgeStep) {
+	s.run(func(gogl.Vertex) error {
+		return nil
+	}, func(e gogl.Edge) error {
+		if f(e) {
+			return errStop
+		}
+		return nil
+	})
+}
+
+type digraphSource struct {
+	*Source
+}
+
+func (s digraphSource) Arcs(f gogl.ArcStep) {
+	s.Edges(func(e gogl.Edge) (terminate bool) {
+		return f(e.(gogl.Arc))
+	})
+}
+
+// Passes each edge in the graph to the provided function, once. Arcs are
+// passed as they are; in mixed graphs, undirected edges are passed as a
+// pair of arcs, one in each direction.
+func eachEdge(g gogl.GraphSource, f func(gogl.Edge) error) (err error) {
+	step := func(e gogl.Edge) (terminate bool) {
+		err = f(e)
+		return err != nil
+	}
+
+	dg, ok := g.(gogl.DigraphSource)
+	if !ok {
+		g.Edges(step)
+		return
+	}
+
+	dg.Arcs(func(a gogl.Arc) (terminate bool) {
+		return step(a)
+	})
+
+	if ug, ok := g.(gogl.UndirectedEdgeEnumerator); ok && err == nil {
+		ug.UndirectedEdges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			a := encoding.AttrsOf(e)
+			if step(a.Arc(u, v)) {
+				return true
+			}
+			return step(a.Arc(v, u))
+		})
+	}
+
+	return
+}
@@ -0,0 +1,337 @@
+package graphml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// A Decoder reads a GraphML document from an input stream.
+//
+// Decoding produces a GraphSource suitable for passing to GraphSpec.Using. The
+// graph's edgedefault determines whether it is directed, in which case the
+// source is a DigraphSource; edges whose directed attribute disagrees with the
+// edgedefault make the result a mixed graph.
+//
+// Data under keys whose attr.name is "weight", "label" or "data" becomes the
+// corresponding edge property or vertex attribute, with key defaults honored;
+// a weight that does not parse as a float is an error. Other keys, ports and
+// any yEd or Gephi specific markup are ignored, and nested graphs are
+// flattened into their parent. Hyperedges are not supported.
+type Decoder struct {
+	// Produces a vertex from a GraphML node id. If nil, the vertex is the id
+	// string itself. It is called only once for each distinct id.
+	Vertex func(id string) (gogl.Vertex, error)
+
+	r io.Reader
+}
+
+// Creates a new Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Decodes the first graph in the provided GraphML document.
+func Unmarshal(data []byte) (gogl.GraphSource, error) {
+	return NewDecoder(bytes.NewReader(data)).Decode()
+}
+
+// A key declaration relevant to decoding.
+type keyDecl struct {
+	domain string // "node", "edge", or "all"
+	prop   gogl.GraphProperties
+	def    *string
+}
+
+type decodeState struct {
+	dec  *Decoder
+	x    *xml.Decoder
+	keys map[string]*keyDecl
+	ids  map[string]gogl.Vertex
+	src  *encoding.Source
+
+	directed bool
+}
+
+func attrOf(el xml.StartElement, name string) (string, bool) {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// Reads the first graph from the stream.
+func (dec *Decoder) Decode() (gogl.GraphSource, error) {
+	s := &decodeState{
+		dec:  dec,
+		x:    xml.NewDecoder(dec.r),
+		keys: make(map[string]*keyDecl),
+		ids:  make(map[string]gogl.Vertex),
+	}
+
+	for {
+		t, err := s.x.Token()
+		if err == io.EOF {
+			return nil, errors.New("No graph element found in GraphML document.")
+		} else if err != nil {
+			return nil, err
+		}
+
+		el, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch el.Name.Local {
+		case "key":
+			if err = s.key(el); err != nil {
+				return nil, err
+			}
+		case "graph":
+			if err = s.graph(el); err != nil {
+				return nil, err
+			}
+			return s.src.GraphSource(), nil
+		}
+	}
+}
+
+func (s *decodeState) key(el xml.StartElement) error {
+	id, _ := attrOf(el, "id")
+	domain, _ := attrOf(el, "for")
+	name, _ := attrOf(el, "attr.name")
+
+	var prop gogl.GraphProperties
+	switch name {
+	case "weight":
+		prop = gogl.G_WEIGHTED
+	case "label":
+		prop = gogl.G_LABELED
+	case "data":
+		prop = gogl.G_DATA
+	}
+
+	var k struct {
+		Default *string `xml:"default"`
+	}
+	if err := s.x.DecodeElement(&k, &el); err != nil {
+		return err
+	}
+
+	if prop != 0 {
+		s.keys[id] = &keyDecl{domain: domain, prop: prop, def: k.Default}
+	}
+
+	return nil
+}
+
+// Applies the value under the given key to the attributes of an element in
+// the given domain, if the key is one the decoder cares about.
+func (s *decodeState) apply(a *encoding.Attrs, domain, key, value string) error {
+	kd, exists := s.keys[key]
+	if !exists || (kd.domain != domain && kd.domain != "all" && kd.domain != "") {
+		return nil
+	}
+
+	switch kd.prop {
+	case gogl.G_WEIGHTED:
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("Weight %q is not a number.", value)
+		}
+		a.SetWeight(weight)
+	case gogl.G_LABELED:
+		a.SetLabel(value)
+	case gogl.G_DATA:
+		a.SetData(value)
+	}
+
+	return nil
+}
+
+// Fills in key defaults for any attributes the element did not set.
+func (s *decodeState) defaults(a *encoding.Attrs, domain string) error {
+	for id, kd := range s.keys {
+		if kd.def != nil && a.Props&kd.prop == 0 {
+			if err := s.apply(a, domain, id, *kd.def); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Reads the data children of a node or edge element, through its end.
+func (s *decodeState) data(domain string) (a encoding.Attrs, err error) {
+	for depth := 1; depth > 0; {
+		t, err := s.x.Token()
+		if err != nil {
+			return a, err
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "data" && depth == 1:
+				var d struct {
+					Value string `xml:",chardata"`
+				}
+				if err = s.x.DecodeElement(&d, &t); err != nil {
+					return a, err
+				}
+				k, _ := attrOf(t, "key")
+				if err = s.apply(&a, domain, k, d.Value); err != nil {
+					return a, err
+				}
+			case t.Name.Local == "graph" && domain == "node":
+				// A nested graph; flatten it into the parent.
+				if err = s.elements(); err != nil {
+					return a, err
+				}
+			default:
+				depth++
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	return a, s.defaults(&a, domain)
+}
+
+func (s *decodeState) graph(el xml.StartElement) error {
+	edgedefault, _ := attrOf(el, "edgedefault")
+	switch edgedefault {
+	case "directed":
+		s.directed = true
+	case "undirected", "":
+	default:
+		return fmt.Errorf("Unrecognized edgedefault %q.", edgedefault)
+	}
+
+	s.src = encoding.NewSource(s.directed)
+	return s.elements()
+}
+
+// Reads the nodes and edges of a graph element, through its end.
+func (s *decodeState) elements() error {
+	for depth := 1; depth > 0; {
+		t, err := s.x.Token()
+		if err == io.EOF {
+			return errors.New("Unexpected end of GraphML document.")
+		} else if err != nil {
+			return err
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "node":
+				err = s.node(t)
+			case "edge":
+				err = s.edge(t)
+			case "hyperedge":
+				err = errors.New("Hyperedges are not supported.")
+			default:
+				depth++
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	return nil
+}
+
+func (s *decodeState) vertex(id string) (gogl.Vertex, error) {
+	if v, exists := s.ids[id]; exists {
+		return v, nil
+	}
+
+	var v gogl.Vertex = id
+	if s.dec.Vertex != nil {
+		var err error
+		if v, err = s.dec.Vertex(id); err != nil {
+			return nil, err
+		}
+	}
+
+	s.ids[id] = v
+	return v, nil
+}
+
+func (s *decodeState) node(el xml.StartElement) error {
+	id, ok := attrOf(el, "id")
+	if !ok {
+		return errors.New("Node element is missing an id.")
+	}
+
+	v, err := s.vertex(id)
+	if err != nil {
+		return err
+	}
+	s.src.AddVertex(v)
+
+	a, err := s.data("node")
+	if err != nil {
+		return err
+	}
+
+	if a.Props&gogl.G_WEIGHTED != 0 {
+		s.src.SetVertexWeight(v, a.Weight)
+	}
+	if a.Props&gogl.G_LABELED != 0 {
+		s.src.SetVertexLabel(v, a.Label)
+	}
+	if a.Props&gogl.G_DATA != 0 {
+		s.src.SetVertexData(v, a.Data)
+	}
+
+	return nil
+}
+
+func (s *decodeState) edge(el xml.StartElement) error {
+	sid, ok := attrOf(el, "source")
+	tid, ok2 := attrOf(el, "target")
+	if !ok || !ok2 {
+		return errors.New("Edge element is missing a source or target.")
+	}
+
+	directed := s.directed
+	if d, exists := attrOf(el, "directed"); exists {
+		directed = d == "true"
+	}
+
+	u, err := s.vertex(sid)
+	if err != nil {
+		return err
+	}
+	v, err := s.vertex(tid)
+	if err != nil {
+		return err
+	}
+
+	a, err := s.data("edge")
+	if err != nil {
+		return err
+	}
+
+	if directed {
+		s.src.AddEdge(a.Arc(u, v))
+	} else {
+		s.src.AddEdge(a.Edge(u, v))
+	}
+	return nil
+}
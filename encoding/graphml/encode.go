@@ -0,0 +1,233 @@
+// Reads and writes graphs in GraphML, the XML-based format spoken by yEd,
+// Gephi and many other graph tools.
+//
+// Edge weights, labels and data are written as GraphML data under keys named
+// "weight" (a double), "label" and "data" (strings; data is formatted via
+// fmt.Sprint). Vertex attributes are written the same way, under node keys.
+// Keys are declared only for the attributes actually present in the graph.
+//
+// Mixed graphs are written with an edgedefault of "directed"; their
+// undirected edges are marked with directed="false".
+package graphml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+const (
+	namespace = "http://graphml.graphdrawing.org/xmlns"
+	xsi       = "http://www.w3.org/2001/XMLSchema-instance"
+	schemaLoc = "http://graphml.graphdrawing.org/xmlns http://graphml.graphdrawing.org/xmlns/1.0/graphml.xsd"
+)
+
+// The ids of the keys written for each attribute, and the attribute names they declare.
+var (
+	edgeKeys = [3]key{{"weight", "weight", "double"}, {"label", "label", "string"}, {"data", "data", "string"}}
+	nodeKeys = [3]key{{"vweight", "weight", "double"}, {"vlabel", "label", "string"}, {"vdata", "data", "string"}}
+)
+
+type key struct {
+	id, name, typ string
+}
+
+// An Encoder writes graphs to an output stream as GraphML.
+//
+// The graph is streamed out as it is enumerated, rather than collected in
+// memory first. Its vertices and edges are each enumerated twice: once to
+// determine which keys must be declared, and again to write them.
+type Encoder struct {
+	// The id given to the written graph. If empty, "G" is used.
+	ID string
+	// Produces the GraphML id for a vertex. If nil, vertices are identified via
+	// fmt.Sprint. Distinct vertices must have distinct ids.
+	VertexID func(gogl.Vertex) string
+
+	w io.Writer
+}
+
+// Creates a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Returns the GraphML encoding of the provided graph, written with a default Encoder.
+func Marshal(g gogl.GraphSource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (enc *Encoder) id(v gogl.Vertex) string {
+	if enc.VertexID != nil {
+		return enc.VertexID(v)
+	}
+	return fmt.Sprint(v)
+}
+
+func attr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+func start(name string, attrs ...xml.Attr) xml.StartElement {
+	return xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs}
+}
+
+// Collects the attributes a vertex carries, in the same form as for edges.
+func vertexAttrs(vas gogl.VertexAttributeSource, v gogl.Vertex) (a encoding.Attrs) {
+	if vas == nil {
+		return
+	}
+
+	if weight, exists := vas.VertexWeight(v); exists {
+		a.SetWeight(weight)
+	}
+	if label, exists := vas.VertexLabel(v); exists {
+		a.SetLabel(label)
+	}
+	if data, exists := vas.VertexData(v); exists && data != nil {
+		a.SetData(data)
+	}
+	return
+}
+
+// Omits nil data, which cannot be meaningfully written.
+func edgeAttrs(e gogl.Edge) encoding.Attrs {
+	a := encoding.AttrsOf(e)
+	if a.Data == nil {
+		a.Props &^= gogl.G_DATA
+	}
+	return a
+}
+
+// Writes the GraphML encoding of the provided graph to the stream.
+//
+// If the graph implements DigraphSource, its edgedefault is "directed", and
+// only its arcs are written - along with, if it also enumerates undirected
+// edges in the manner of a MixedGraph, those edges. Otherwise, its
+// edgedefault is "undirected".
+func (enc *Encoder) Encode(g gogl.GraphSource) error {
+	dg, directed := g.(gogl.DigraphSource)
+	ug, _ := g.(gogl.UndirectedEdgeEnumerator)
+	vas, _ := g.(gogl.VertexAttributeSource)
+	if !directed {
+		ug = nil
+	}
+
+	// First pass: determine which keys are needed.
+	var eprops, vprops gogl.GraphProperties
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		vprops |= vertexAttrs(vas, v).Props
+		return
+	})
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		eprops |= edgeAttrs(e).Props
+		return
+	})
+
+	x := xml.NewEncoder(enc.w)
+	x.Indent("", "  ")
+
+	// Remember only the first error; the xml.Encoder will keep returning it.
+	var err error
+	tok := func(t xml.Token) {
+		if err == nil {
+			err = x.EncodeToken(t)
+		}
+	}
+
+	tok(xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8"`)})
+	root := start("graphml", attr("xmlns", namespace), attr("xmlns:xsi", xsi), attr("xsi:schemaLocation", schemaLoc))
+	tok(root)
+
+	declare := func(keys [3]key, props gogl.GraphProperties, domain string) {
+		for k, p := range []gogl.GraphProperties{gogl.G_WEIGHTED, gogl.G_LABELED, gogl.G_DATA} {
+			if props&p != 0 {
+				el := start("key", attr("id", keys[k].id), attr("for", domain), attr("attr.name", keys[k].name), attr("attr.type", keys[k].typ))
+				tok(el)
+				tok(el.End())
+			}
+		}
+	}
+	declare(nodeKeys, vprops, "node")
+	declare(edgeKeys, eprops, "edge")
+
+	gid := enc.ID
+	if gid == "" {
+		gid = "G"
+	}
+	edgedefault := "undirected"
+	if directed {
+		edgedefault = "directed"
+	}
+	graph := start("graph", attr("id", gid), attr("edgedefault", edgedefault))
+	tok(graph)
+
+	data := func(keys [3]key, a encoding.Attrs) {
+		if a.Props&gogl.G_WEIGHTED != 0 {
+			el := start("data", attr("key", keys[0].id))
+			tok(el)
+			tok(xml.CharData(strconv.FormatFloat(a.Weight, 'g', -1, 64)))
+			tok(el.End())
+		}
+		if a.Props&gogl.G_LABELED != 0 {
+			el := start("data", attr("key", keys[1].id))
+			tok(el)
+			tok(xml.CharData(a.Label))
+			tok(el.End())
+		}
+		if a.Props&gogl.G_DATA != 0 {
+			el := start("data", attr("key", keys[2].id))
+			tok(el)
+			tok(xml.CharData(fmt.Sprint(a.Data)))
+			tok(el.End())
+		}
+	}
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		el := start("node", attr("id", enc.id(v)))
+		tok(el)
+		data(nodeKeys, vertexAttrs(vas, v))
+		tok(el.End())
+		return err != nil
+	})
+
+	edge := func(e gogl.Edge, extra ...xml.Attr) bool {
+		u, v := e.Both()
+		el := start("edge", append([]xml.Attr{attr("source", enc.id(u)), attr("target", enc.id(v))}, extra...)...)
+		tok(el)
+		data(edgeKeys, edgeAttrs(e))
+		tok(el.End())
+		return err != nil
+	}
+
+	if directed {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			return edge(a)
+		})
+		if ug != nil {
+			ug.UndirectedEdges(func(e gogl.Edge) (terminate bool) {
+				return edge(e, attr("directed", "false"))
+			})
+		}
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			return edge(e)
+		})
+	}
+
+	tok(graph.End())
+	tok(root.End())
+	if err == nil {
+		err = x.Flush()
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+package graphml
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type GraphMLSuite struct{}
+
+var _ = Suite(&GraphMLSuite{})
+
+func atoi(id string) (gogl.Vertex, error) {
+	return strconv.Atoi(id)
+}
+
+func (s *GraphMLSuite) TestEncodeKeys(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{gogl.NewWeightedEdge(1, 2, 5.23)}).Create(al.G)
+
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+
+	doc := string(out)
+	c.Assert(strings.HasPrefix(doc, `<?xml version="1.0" encoding="UTF-8"?>`), Equals, true)
+	c.Assert(strings.Contains(doc, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns"`), Equals, true)
+	c.Assert(strings.Contains(doc, `<key id="weight" for="edge" attr.name="weight" attr.type="double"></key>`), Equals, true)
+	c.Assert(strings.Contains(doc, `id="label"`), Equals, false)
+	c.Assert(strings.Contains(doc, `for="node"`), Equals, false)
+	c.Assert(strings.Contains(doc, `<graph id="G" edgedefault="undirected">`), Equals, true)
+	c.Assert(strings.Contains(doc, `<data key="weight">5.23</data>`), Equals, true)
+}
+
+func (s *GraphMLSuite) TestRoundTrip(c *C) {
+	g := gogl.Spec().Directed().PropertyEdges().Using(gogl.PropertyArcList{
+		gogl.NewPropertyArc(1, 2, 5.23, "<foo> & bar", "data"),
+		gogl.NewPropertyArc(2, 3, -1, "baz", nil),
+	}).Create(al.G)
+	g.(gogl.VertexSetMutator).EnsureVertex(4)
+	g.(gogl.VertexAttributes).SetVertexLabel(1, "start")
+	g.(gogl.VertexAttributes).SetVertexWeight(4, 1.5)
+	g.(gogl.VertexAttributes).SetVertexData(4, 42)
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.ID = "roundtrip"
+	c.Assert(enc.Encode(g), IsNil)
+	c.Assert(strings.Contains(buf.String(), `<graph id="roundtrip" edgedefault="directed">`), Equals, true)
+
+	dec := NewDecoder(&buf)
+	dec.Vertex = atoi
+	src, err := dec.Decode()
+	c.Assert(err, IsNil)
+	c.Assert(src, Implements, new(gogl.DigraphSource))
+
+	g2 := gogl.Spec().Directed().PropertyEdges().Using(src).Create(al.G).(gogl.PropertyDigraph)
+	c.Assert(gogl.Order(g2), Equals, 4)
+	c.Assert(gogl.Size(g2), Equals, 2)
+	c.Assert(g2.HasPropertyArc(gogl.NewPropertyArc(1, 2, 5.23, "<foo> & bar", "data")), Equals, true)
+	c.Assert(g2.HasPropertyArc(gogl.NewPropertyArc(2, 3, -1, "baz", nil)), Equals, true)
+
+	vas := g2.(gogl.VertexAttributeSource)
+	label, exists := vas.VertexLabel(1)
+	c.Assert(exists, Equals, true)
+	c.Assert(label, Equals, "start")
+	weight, _ := vas.VertexWeight(4)
+	c.Assert(weight, Equals, 1.5)
+	data, _ := vas.VertexData(4)
+	c.Assert(data, Equals, "42")
+}
+
+func (s *GraphMLSuite) TestRoundTripMixed(c *C) {
+	g := gogl.Spec().Mixed().Using(gogl.EdgeList{gogl.NewArc(1, 2), gogl.NewEdge(2, 3)}).Create(al.G)
+
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(out), `<edge source="2" target="3" directed="false">`) ||
+		strings.Contains(string(out), `<edge source="3" target="2" directed="false">`), Equals, true)
+
+	dec := NewDecoder(bytes.NewReader(out))
+	dec.Vertex = atoi
+	src, err := dec.Decode()
+	c.Assert(err, IsNil)
+
+	g2 := gogl.Spec().Mixed().Using(src).Create(al.G).(gogl.MixedGraph)
+	c.Assert(g2.HasArc(gogl.NewArc(1, 2)), Equals, true)
+	c.Assert(g2.HasArc(gogl.NewArc(2, 3)), Equals, false)
+	c.Assert(g2.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+}
+
+// Roughly what Gephi produces, with a yEd-style extension element thrown in.
+const foreign = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:y="http://www.yworks.com/xml/graphml">
+  <key attr.name="label" attr.type="string" for="node" id="label"/>
+  <key attr.name="weight" attr.type="double" for="edge" id="weight">
+    <default>1.0</default>
+  </key>
+  <key attr.name="color" attr.type="string" for="node" id="color"/>
+  <key for="node" id="d6" yfiles.type="nodegraphics"/>
+  <graph defaultedgetype="undirected" edgedefault="undirected">
+    <node id="a">
+      <data key="label">Alpha</data>
+      <data key="color">red</data>
+      <data key="d6"><y:ShapeNode><y:NodeLabel>ignored</y:NodeLabel></y:ShapeNode></data>
+    </node>
+    <node id="b">
+      <graph id="nested" edgedefault="undirected">
+        <node id="c"/>
+        <edge source="b" target="c"/>
+      </graph>
+    </node>
+    <edge id="e0" source="a" target="b">
+      <data key="weight">2.5</data>
+    </edge>
+    <edge id="e1" source="c" target="a" directed="true"/>
+  </graph>
+</graphml>`
+
+func (s *GraphMLSuite) TestDecodeForeign(c *C) {
+	src, err := Unmarshal([]byte(foreign))
+	c.Assert(err, IsNil)
+
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{"a", "b", "c"})
+
+	var edges []gogl.Edge
+	src.Edges(func(e gogl.Edge) (terminate bool) {
+		edges = append(edges, e)
+		return
+	})
+
+	c.Assert(edges, DeepEquals, []gogl.Edge{
+		gogl.NewWeightedEdge("b", "c", 1),
+		gogl.NewWeightedEdge("a", "b", 2.5),
+		gogl.NewWeightedArc("c", "a", 1),
+	})
+
+	// The single arc makes this a mixed graph
+	c.Assert(src, Implements, new(gogl.UndirectedEdgeEnumerator))
+
+	label, exists := src.(gogl.VertexAttributeSource).VertexLabel("a")
+	c.Assert(exists, Equals, true)
+	c.Assert(label, Equals, "Alpha")
+}
+
+func (s *GraphMLSuite) TestDecodeErrors(c *C) {
+	_, err := Unmarshal([]byte(`<graphml></graphml>`))
+	c.Assert(err, ErrorMatches, "No graph element found in GraphML document.")
+
+	_, err = Unmarshal([]byte(`<graphml><graph edgedefault="sideways"/></graphml>`))
+	c.Assert(err, ErrorMatches, `Unrecognized edgedefault "sideways".`)
+
+	_, err = Unmarshal([]byte(`<graphml><key id="w" for="edge" attr.name="weight"/><graph>
+		<edge source="a" target="b"><data key="w">heavy</data></edge></graph></graphml>`))
+	c.Assert(err, ErrorMatches, `Weight "heavy" is not a number.`)
+
+	_, err = Unmarshal([]byte(`<graphml><graph><hyperedge/></graph></graphml>`))
+	c.Assert(err, ErrorMatches, "Hyperedges are not supported.")
+
+	_, err = Unmarshal([]byte(`<graphml><graph><node id="a">`))
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,277 @@
+package json
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+type rawNode struct {
+	ID     stdjson.RawMessage `json:"id"`
+	Label  *string            `json:"label"`
+	Weight *float64           `json:"weight"`
+	Data   interface{}        `json:"data"`
+}
+
+type rawLink struct {
+	Source   stdjson.RawMessage `json:"source"`
+	Target   stdjson.RawMessage `json:"target"`
+	Directed *bool              `json:"directed"`
+	Label    *string            `json:"label"`
+	Weight   *float64           `json:"weight"`
+	Data     interface{}        `json:"data"`
+}
+
+// A Decoder reads a node-link JSON graph from an input stream.
+//
+// Decoding produces a GraphSource suitable for passing to GraphSpec.Using;
+// it is a DigraphSource if the graph is directed, and an
+// encoding.PropertiesReporter that reports the GraphProperties recorded in
+// the input, if any, so encoding.Spec can recreate the original graph.
+//
+// The input is consumed as a stream: nodes and links are decoded one at a
+// time, and only the resulting graph is held in memory. Members other than
+// those described in the package documentation are ignored, as is a
+// "multigraph" flag, and links may also be listed under "edges", as newer
+// versions of networkx do. The "directed" member must precede the links.
+//
+// Edge and vertex data are decoded as encoding/json decodes into an
+// interface{}; numbers, for example, become float64s.
+type Decoder struct {
+	// Produces a vertex from the JSON value of a node id or link endpoint. If
+	// nil, strings, booleans and numbers are accepted; numbers are decoded as
+	// ints where they are integral and fit, else as float64s. It is called only
+	// once for each distinct id.
+	Vertex func(id stdjson.RawMessage) (gogl.Vertex, error)
+
+	d *stdjson.Decoder
+}
+
+// Creates a new Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{d: stdjson.NewDecoder(r)}
+}
+
+// Decodes the provided node-link JSON.
+func Unmarshal(data []byte) (gogl.GraphSource, error) {
+	return NewDecoder(bytes.NewReader(data)).Decode()
+}
+
+// The default vertex decoder.
+func decodeVertex(id stdjson.RawMessage) (gogl.Vertex, error) {
+	var v interface{}
+	d := stdjson.NewDecoder(bytes.NewReader(id))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	switch v := v.(type) {
+	case string, bool:
+		return v, nil
+	case stdjson.Number:
+		if i, err := strconv.Atoi(v.String()); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	}
+
+	return nil, fmt.Errorf("Cannot use %s as a vertex; ids must be strings, numbers or booleans.", id)
+}
+
+type decodeState struct {
+	dec      *Decoder
+	ids      map[string]gogl.Vertex
+	src      *encoding.Source
+	declared gogl.GraphProperties
+	directed bool
+}
+
+func (s *decodeState) vertex(id stdjson.RawMessage) (gogl.Vertex, error) {
+	if len(id) == 0 {
+		return nil, errors.New("Missing vertex id.")
+	}
+
+	if v, exists := s.ids[string(id)]; exists {
+		return v, nil
+	}
+
+	f := s.dec.Vertex
+	if f == nil {
+		f = decodeVertex
+	}
+
+	v, err := f(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.ids[string(id)] = v
+	return v, nil
+}
+
+func (s *decodeState) source() *encoding.Source {
+	if s.src == nil {
+		s.src = encoding.NewSource(s.directed)
+	}
+	return s.src
+}
+
+// Reads a graph from the stream. Successive calls read successive graphs.
+func (dec *Decoder) Decode() (gogl.GraphSource, error) {
+	s := &decodeState{dec: dec, ids: make(map[string]gogl.Vertex)}
+
+	if err := expectDelim(dec.d, '{'); err != nil {
+		return nil, err
+	}
+
+	for dec.d.More() {
+		t, err := dec.d.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t {
+		case "directed":
+			var directed bool
+			if err = dec.d.Decode(&directed); err != nil {
+				return nil, err
+			}
+			if s.src != nil && directed != s.directed {
+				return nil, errors.New(`The "directed" member must precede "links".`)
+			}
+			s.directed = directed
+		case "graph":
+			var attrs struct {
+				Properties *gogl.GraphProperties `json:"properties"`
+			}
+			if err = dec.d.Decode(&attrs); err != nil {
+				return nil, err
+			}
+			if attrs.Properties != nil {
+				s.declared = *attrs.Properties
+			}
+		case "nodes":
+			err = s.each(func() error {
+				var n rawNode
+				if err := dec.d.Decode(&n); err != nil {
+					return err
+				}
+				return s.node(n)
+			})
+		case "links", "edges":
+			err = s.each(func() error {
+				var l rawLink
+				if err := dec.d.Decode(&l); err != nil {
+					return err
+				}
+				return s.link(l)
+			})
+		default:
+			var discard stdjson.RawMessage
+			err = dec.d.Decode(&discard)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := dec.d.Token(); err != nil {
+		return nil, err
+	}
+
+	src := s.source()
+	if s.declared != 0 {
+		src.Declare(s.declared)
+	}
+	return src.GraphSource(), nil
+}
+
+func expectDelim(d *stdjson.Decoder, delim stdjson.Delim) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if t != delim {
+		return fmt.Errorf("Expected '%s' but found %v.", delim, t)
+	}
+	return nil
+}
+
+// Calls f once per element of the array at the head of the stream.
+func (s *decodeState) each(f func() error) error {
+	if err := expectDelim(s.dec.d, '['); err != nil {
+		return err
+	}
+
+	for s.dec.d.More() {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.dec.d.Token()
+	return err
+}
+
+func (s *decodeState) node(n rawNode) error {
+	v, err := s.vertex(n.ID)
+	if err != nil {
+		return err
+	}
+
+	src := s.source()
+	src.AddVertex(v)
+	if n.Label != nil {
+		src.SetVertexLabel(v, *n.Label)
+	}
+	if n.Weight != nil {
+		src.SetVertexWeight(v, *n.Weight)
+	}
+	if n.Data != nil {
+		src.SetVertexData(v, n.Data)
+	}
+
+	return nil
+}
+
+func (s *decodeState) link(l rawLink) error {
+	u, err := s.vertex(l.Source)
+	if err != nil {
+		return err
+	}
+	v, err := s.vertex(l.Target)
+	if err != nil {
+		return err
+	}
+
+	var a encoding.Attrs
+	if l.Weight != nil {
+		a.SetWeight(*l.Weight)
+	}
+	if l.Label != nil {
+		a.SetLabel(*l.Label)
+	}
+	if l.Data != nil {
+		a.SetData(l.Data)
+	}
+
+	directed := s.directed
+	if l.Directed != nil {
+		directed = *l.Directed
+	}
+
+	if directed {
+		s.source().AddEdge(a.Arc(u, v))
+	} else {
+		s.source().AddEdge(a.Edge(u, v))
+	}
+	return nil
+}
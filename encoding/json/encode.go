@@ -0,0 +1,190 @@
+// Reads and writes graphs as node-link JSON, the format used by D3 and by
+// networkx's node_link_data:
+//
+//	{
+//	  "directed": true,
+//	  "multigraph": false,
+//	  "graph": {"properties": "G_DIRECTED|G_WEIGHTED|G_SIMPLE|G_MUTABLE"},
+//	  "nodes": [{"id": 1, "label": "start"}, {"id": 2}],
+//	  "links": [{"source": 1, "target": 2, "weight": 5.23}]
+//	}
+//
+// The graph's GraphProperties are recorded under "graph", so that a decoded
+// graph can be recreated with the same properties. Edge weights, labels and
+// data become the "weight", "label" and "data" members of links, and vertex
+// attributes the same members of nodes. Mixed graphs are written as directed,
+// with "directed": false on each of their undirected links.
+package json
+
+import (
+	"bufio"
+	"bytes"
+	stdjson "encoding/json"
+	"io"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+type graphAttrs struct {
+	Properties gogl.GraphProperties `json:"properties"`
+}
+
+type node struct {
+	ID     interface{} `json:"id"`
+	Label  *string     `json:"label,omitempty"`
+	Weight *float64    `json:"weight,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+}
+
+type link struct {
+	Source   interface{} `json:"source"`
+	Target   interface{} `json:"target"`
+	Directed *bool       `json:"directed,omitempty"`
+	Label    *string     `json:"label,omitempty"`
+	Weight   *float64    `json:"weight,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
+}
+
+// An Encoder writes graphs to an output stream as node-link JSON.
+//
+// Nodes and links are written out one at a time, rather than being collected
+// in memory first. Recording the graph's properties may still take memory
+// proportional to its size, though; see Encode.
+type Encoder struct {
+	// Produces the value used as a vertex's id. If nil, the vertex itself is
+	// used. Either way, it must be encodable by encoding/json.
+	VertexID func(gogl.Vertex) interface{}
+
+	w io.Writer
+}
+
+// Creates a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Returns the node-link JSON encoding of the provided graph, written with a
+// default Encoder.
+func Marshal(g gogl.GraphSource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (enc *Encoder) id(v gogl.Vertex) interface{} {
+	if enc.VertexID != nil {
+		return enc.VertexID(v)
+	}
+	return v
+}
+
+// Writes the node-link JSON encoding of the provided graph to the stream.
+//
+// The recorded GraphProperties are those reported by encoding.Infer. Graphs
+// that are encoding.PropertiesReporters, as al's are, report them directly;
+// for any other graph, Infer enumerates the edges an extra time, holding each
+// vertex pair in memory to detect parallel edges. If the
+// graph implements DigraphSource, only its arcs are written - along with, if
+// it also enumerates undirected edges in the manner of a MixedGraph, those
+// edges. Otherwise, all its edges are written.
+func (enc *Encoder) Encode(g gogl.GraphSource) error {
+	gp := encoding.Infer(g)
+	w := bufio.NewWriter(enc.w)
+
+	// Remember only the first error, and stop writing once there is one.
+	var err error
+	write := func(s string) {
+		if err == nil {
+			_, err = w.WriteString(s)
+		}
+	}
+	value := func(v interface{}) {
+		if err == nil {
+			var b []byte
+			if b, err = stdjson.Marshal(v); err == nil {
+				_, err = w.Write(b)
+			}
+		}
+	}
+
+	write(`{"directed": `)
+	value(gp&gogl.G_DIRECTED != 0)
+	write(`, "multigraph": `)
+	value(gp&gogl.G_PARALLEL != 0)
+	write(`, "graph": `)
+	value(graphAttrs{gp})
+
+	write(`, "nodes": [`)
+	vas, _ := g.(gogl.VertexAttributeSource)
+	first := true
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		n := node{ID: enc.id(v)}
+		if vas != nil {
+			if label, exists := vas.VertexLabel(v); exists {
+				n.Label = &label
+			}
+			if weight, exists := vas.VertexWeight(v); exists {
+				n.Weight = &weight
+			}
+			n.Data, _ = vas.VertexData(v)
+		}
+
+		if !first {
+			write(",")
+		}
+		first = false
+		write("\n  ")
+		value(n)
+		return err != nil
+	})
+
+	write("\n], \"links\": [")
+	first = true
+	edge := func(e gogl.Edge, directed *bool) bool {
+		u, v := e.Both()
+		l := link{Source: enc.id(u), Target: enc.id(v), Directed: directed}
+
+		a := encoding.AttrsOf(e)
+		if a.Props&gogl.G_WEIGHTED != 0 {
+			l.Weight = &a.Weight
+		}
+		if a.Props&gogl.G_LABELED != 0 {
+			l.Label = &a.Label
+		}
+		l.Data = a.Data
+
+		if !first {
+			write(",")
+		}
+		first = false
+		write("\n  ")
+		value(l)
+		return err != nil
+	}
+
+	if dg, ok := g.(gogl.DigraphSource); ok {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			return edge(a, nil)
+		})
+
+		if ug, ok := g.(gogl.UndirectedEdgeEnumerator); ok {
+			undirected := false
+			ug.UndirectedEdges(func(e gogl.Edge) (terminate bool) {
+				return edge(e, &undirected)
+			})
+		}
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			return edge(e, nil)
+		})
+	}
+
+	write("\n]}\n")
+	if err == nil {
+		err = w.Flush()
+	}
+	return err
+}
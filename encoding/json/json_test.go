@@ -0,0 +1,179 @@
+package json
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type JSONSuite struct{}
+
+var _ = Suite(&JSONSuite{})
+
+func roundTrip(c *C, g gogl.GraphSource) gogl.Graph {
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+
+	// Must always be valid JSON
+	var v interface{}
+	c.Assert(stdjson.Unmarshal(out, &v), IsNil)
+
+	src, err := Unmarshal(out)
+	c.Assert(err, IsNil)
+
+	g2, err := encoding.Spec(src).TryCreate(al.TryG)
+	c.Assert(err, IsNil)
+	return g2
+}
+
+func (s *JSONSuite) TestEncode(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{gogl.NewWeightedEdge(1, 2, 5.23)}).Create(al.G)
+
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+
+	doc := string(out)
+	c.Assert(strings.HasPrefix(doc, `{"directed": false, "multigraph": false, "graph": {"properties":"G_UNDIRECTED|G_WEIGHTED|G_SIMPLE|G_MUTABLE"}, "nodes": [`), Equals, true)
+	c.Assert(strings.Contains(doc, `{"id":1}`), Equals, true)
+	c.Assert(strings.Contains(doc, `{"source":1,"target":2,"weight":5.23}`) || strings.Contains(doc, `{"source":2,"target":1,"weight":5.23}`), Equals, true)
+}
+
+func (s *JSONSuite) TestRoundTrip(c *C) {
+	g := gogl.Spec().Directed().PropertyEdges().Immutable().Using(gogl.PropertyArcList{
+		gogl.NewPropertyArc(1, 2, 5.23, "foo", "bar"),
+		gogl.NewPropertyArc(2, 3, 0, "", nil),
+	}).Create(al.G)
+
+	g2 := roundTrip(c, g)
+	c.Assert(g2, Not(Implements), new(gogl.VertexSetMutator))
+
+	pg := g2.(gogl.PropertyDigraph)
+	c.Assert(gogl.Order(pg), Equals, 3)
+	c.Assert(pg.HasPropertyArc(gogl.NewPropertyArc(1, 2, 5.23, "foo", "bar")), Equals, true)
+	c.Assert(pg.HasPropertyArc(gogl.NewPropertyArc(2, 3, 0, "", nil)), Equals, true)
+}
+
+func (s *JSONSuite) TestRoundTripVertexAttributes(c *C) {
+	g := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{gogl.NewLabeledEdge("a", "b", "foo")}).Create(al.G)
+	g.(gogl.VertexSetMutator).EnsureVertex("isolate")
+	g.(gogl.VertexAttributes).SetVertexLabel("a", "start")
+	g.(gogl.VertexAttributes).SetVertexWeight("isolate", 1.5)
+	g.(gogl.VertexAttributes).SetVertexData("b", map[string]interface{}{"x": 1.0})
+
+	g2 := roundTrip(c, g)
+	c.Assert(g2.(gogl.LabeledGraph).HasLabeledEdge(gogl.NewLabeledEdge("b", "a", "foo")), Equals, true)
+	c.Assert(g2.HasVertex("isolate"), Equals, true)
+
+	vas := g2.(gogl.VertexAttributeSource)
+	label, _ := vas.VertexLabel("a")
+	c.Assert(label, Equals, "start")
+	weight, _ := vas.VertexWeight("isolate")
+	c.Assert(weight, Equals, 1.5)
+	data, _ := vas.VertexData("b")
+	c.Assert(data, DeepEquals, map[string]interface{}{"x": 1.0})
+	_, exists := vas.VertexLabel("b")
+	c.Assert(exists, Equals, false)
+}
+
+func (s *JSONSuite) TestRoundTripMixed(c *C) {
+	g := gogl.Spec().Mixed().Using(gogl.EdgeList{gogl.NewArc(1, 2), gogl.NewEdge(2, 3)}).Create(al.G)
+
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(out), `"directed":false`), Equals, true)
+
+	g2 := roundTrip(c, g).(gogl.MixedGraph)
+	c.Assert(g2.HasArc(gogl.NewArc(1, 2)), Equals, true)
+	c.Assert(g2.HasArc(gogl.NewArc(2, 3)), Equals, false)
+	c.Assert(g2.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+}
+
+func (s *JSONSuite) TestDecodeNetworkx(c *C) {
+	// As written by networkx.node_link_data, which records no properties
+	src, err := Unmarshal([]byte(`{"directed": true, "multigraph": false, "graph": {"name": "demo"},
+		"nodes": [{"color": "red", "id": "a"}, {"id": 2.5}, {"id": true}],
+		"links": [{"weight": 3, "source": "a", "target": 2.5}, {"source": 2.5, "target": true, "key": 0}]}`))
+	c.Assert(err, IsNil)
+
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{"a", 2.5, true})
+	c.Assert(encoding.Infer(src), Equals, gogl.Spec().Directed().Weighted().Props)
+
+	var edges []gogl.Edge
+	src.Edges(func(e gogl.Edge) (terminate bool) {
+		edges = append(edges, e)
+		return
+	})
+	c.Assert(edges, DeepEquals, []gogl.Edge{gogl.NewWeightedArc("a", 2.5, 3), gogl.NewArc(2.5, true)})
+}
+
+func (s *JSONSuite) TestDecodeStream(c *C) {
+	dec := NewDecoder(strings.NewReader(`{"edges": [{"source": 1, "target": 2}]}
+		{"directed": true, "links": [{"source": 3, "target": 4}]}`))
+	dec.Vertex = func(id stdjson.RawMessage) (gogl.Vertex, error) {
+		return "v" + string(id), nil
+	}
+
+	src, err := dec.Decode()
+	c.Assert(err, IsNil)
+	c.Assert(src, Not(Implements), new(gogl.DigraphSource))
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{"v1", "v2"})
+
+	src, err = dec.Decode()
+	c.Assert(err, IsNil)
+	c.Assert(src, Implements, new(gogl.DigraphSource))
+
+	_, err = dec.Decode()
+	c.Assert(err, Equals, io.EOF)
+}
+
+func (s *JSONSuite) TestDecodeErrors(c *C) {
+	_, err := Unmarshal([]byte(`[]`))
+	c.Assert(err, ErrorMatches, `Expected '\{' but found \[.`)
+
+	_, err = Unmarshal([]byte(`{"links": [{"source": 1, "target": 2}], "directed": true}`))
+	c.Assert(err, ErrorMatches, `The "directed" member must precede "links".`)
+
+	_, err = Unmarshal([]byte(`{"nodes": [{"id": [1]}]}`))
+	c.Assert(err, ErrorMatches, `Cannot use \[1\] as a vertex; ids must be strings, numbers or booleans.`)
+
+	_, err = Unmarshal([]byte(`{"links": [{"target": 2}]}`))
+	c.Assert(err, ErrorMatches, "Missing vertex id.")
+
+	_, err = Unmarshal([]byte(`{"graph": {"properties": "G_BOGUS"}}`))
+	c.Assert(err, ErrorMatches, `Unrecognized graph property "G_BOGUS".`)
+
+	_, err = Unmarshal([]byte(`{"nodes": [{"id": 1}`))
+	c.Assert(err, NotNil)
+}
+
+func (s *JSONSuite) TestEncodeVertexID(c *C) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.VertexID = func(v gogl.Vertex) interface{} {
+		return map[string]interface{}{"n": v}
+	}
+
+	c.Assert(enc.Encode(gogl.EdgeList{gogl.NewEdge(1, 2)}), IsNil)
+	c.Assert(strings.Contains(buf.String(), `{"id":{"n":1}}`), Equals, true)
+}
+
+func (s *JSONSuite) TestReportedProperties(c *C) {
+	// With no edges to infer from, only a reported edge type survives
+	g := gogl.Spec().Directed().Weighted().Create(al.G)
+	out, err := Marshal(g)
+	c.Assert(err, IsNil)
+
+	src, err := Unmarshal(out)
+	c.Assert(err, IsNil)
+	c.Assert(encoding.Infer(src), Equals, gogl.Spec().Directed().Weighted().Props)
+}
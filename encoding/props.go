@@ -0,0 +1,58 @@
+package encoding
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// A PropertiesReporter reports the GraphProperties that describe a graph.
+// Sources produced by decoders implement it.
+type PropertiesReporter interface {
+	Properties() gogl.GraphProperties
+}
+
+// Determines the GraphProperties describing the provided graph.
+//
+// If the graph is a PropertiesReporter, its report is used. Otherwise, the
+// properties are inferred: directedness from the interfaces the graph
+// implements, edge types, loops and parallel edges by enumerating its edges,
+// and mutability from whether it is a VertexSetMutator. Sources that are not
+// full Graphs are assumed to be mutable, as with Spec().
+func Infer(g gogl.GraphSource) gogl.GraphProperties {
+	if pr, ok := g.(PropertiesReporter); ok {
+		return pr.Properties()
+	}
+
+	s := NewSource(false)
+	if _, ok := g.(gogl.DigraphSource); ok {
+		s.dir = gogl.G_DIRECTED
+		if _, ok := g.(gogl.UndirectedEdgeEnumerator); ok {
+			s.dir |= gogl.G_UNDIRECTED
+		}
+	}
+
+	// Only the inference is wanted from the Source, not the edges themselves.
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		s.note(e)
+		return
+	})
+
+	gp := s.Properties() &^ gogl.G_MUTABLE
+	_, graph := g.(gogl.Graph)
+	if _, mutable := g.(gogl.VertexSetMutator); mutable || !graph {
+		gp |= gogl.G_MUTABLE
+	} else {
+		gp |= gogl.G_IMMUTABLE
+	}
+
+	return gp
+}
+
+// Returns a GraphSpec for a graph able to hold the contents of the provided
+// source, as determined by Infer, and populated from it.
+//
+// This is the simplest way to load a decoded graph:
+//
+//	g := encoding.Spec(src).Create(al.G)
+func Spec(g gogl.GraphSource) gogl.GraphSpec {
+	return gogl.GraphSpec{Props: Infer(g)}.Using(g)
+}
@@ -0,0 +1,275 @@
+package encoding
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+type pair struct {
+	u, v gogl.Vertex
+}
+
+// A Source is an in-memory GraphSource, assembled by a decoder as it reads
+// an encoded graph. Vertices and edges are enumerated in the order in which
+// they were added, and any vertex attributes that were set are reported via
+// VertexAttributeSource.
+//
+// As it is assembled, a Source also infers the GraphProperties that describe
+// the graph it holds - which edge types it contains, and whether it has loops
+// or parallel edges.
+//
+// A Source is not safe for concurrent use while it is being assembled.
+type Source struct {
+	declared gogl.GraphProperties
+	dir      gogl.GraphProperties
+	types    gogl.GraphProperties
+	loops    bool
+	parallel bool
+	vertices []gogl.Vertex
+	vset     map[gogl.Vertex]struct{}
+	edges    []gogl.Edge
+	pairs    map[pair]struct{}
+	vdata    map[gogl.Vertex]interface{}
+	vlabels  map[gogl.Vertex]string
+	vweights map[gogl.Vertex]float64
+}
+
+// Creates a new, empty Source. If directed is true, the Source will report
+// itself as a DigraphSource.
+func NewSource(directed bool) *Source {
+	s := &Source{
+		vset:  make(map[gogl.Vertex]struct{}),
+		pairs: make(map[pair]struct{}),
+		dir:   gogl.G_UNDIRECTED,
+	}
+
+	if directed {
+		s.dir = gogl.G_DIRECTED
+	}
+
+	return s
+}
+
+// Adds a vertex to the Source, if it is not already present.
+func (s *Source) AddVertex(v gogl.Vertex) {
+	if _, exists := s.vset[v]; !exists {
+		s.vset[v] = struct{}{}
+		s.vertices = append(s.vertices, v)
+	}
+}
+
+// Adds an edge, and both its vertices, to the Source.
+//
+// Adding an edge that is not an Arc to a directed Source, or an Arc to an
+// undirected one, makes it a mixed graph.
+func (s *Source) AddEdge(e gogl.Edge) {
+	u, v := e.Both()
+	s.AddVertex(u)
+	s.AddVertex(v)
+
+	if _, ok := e.(gogl.Arc); ok {
+		s.dir |= gogl.G_DIRECTED
+	} else {
+		s.dir |= gogl.G_UNDIRECTED
+	}
+
+	s.note(e)
+	s.edges = append(s.edges, e)
+}
+
+// Updates the inferred properties to account for the provided edge.
+func (s *Source) note(e gogl.Edge) {
+	u, v := e.Both()
+	if u == v {
+		s.loops = true
+	}
+	if s.HasEdge(e) {
+		s.parallel = true
+	}
+
+	s.types |= AttrsOf(e).Props
+	s.pairs[pair{u, v}] = struct{}{}
+}
+
+// Indicates whether an edge connecting the same vertices as the provided edge
+// has already been added. If the provided edge is an Arc in a directed Source,
+// only an arc in the same direction counts.
+func (s *Source) HasEdge(e gogl.Edge) bool {
+	u, v := e.Both()
+	if _, exists := s.pairs[pair{u, v}]; exists {
+		return true
+	}
+
+	if _, ok := e.(gogl.Arc); ok && s.dir == gogl.G_DIRECTED {
+		return false
+	}
+
+	_, exists := s.pairs[pair{v, u}]
+	return exists
+}
+
+// Attaches arbitrary data to a vertex, adding the vertex if necessary.
+func (s *Source) SetVertexData(v gogl.Vertex, data interface{}) {
+	s.AddVertex(v)
+	if s.vdata == nil {
+		s.vdata = make(map[gogl.Vertex]interface{})
+	}
+	s.vdata[v] = data
+}
+
+// Attaches a label to a vertex, adding the vertex if necessary.
+func (s *Source) SetVertexLabel(v gogl.Vertex, label string) {
+	s.AddVertex(v)
+	if s.vlabels == nil {
+		s.vlabels = make(map[gogl.Vertex]string)
+	}
+	s.vlabels[v] = label
+}
+
+// Attaches a weight to a vertex, adding the vertex if necessary.
+func (s *Source) SetVertexWeight(v gogl.Vertex, weight float64) {
+	s.AddVertex(v)
+	if s.vweights == nil {
+		s.vweights = make(map[gogl.Vertex]float64)
+	}
+	s.vweights[v] = weight
+}
+
+// Records the GraphProperties that the encoded graph declared for itself, if
+// the format carries them. They take precedence over inferred properties,
+// except where the Source holds something the declared properties do not
+// allow for.
+func (s *Source) Declare(gp gogl.GraphProperties) {
+	s.declared = gp
+}
+
+// Returns the GraphProperties describing the graph held in the Source.
+//
+// These are suitable for passing to GraphSpec.Props in order to obtain a
+// graph capable of holding everything in the Source. Unless declared
+// otherwise, Sources are described as mutable.
+func (s *Source) Properties() gogl.GraphProperties {
+	gp := s.declared
+	if gp == 0 {
+		gp = gogl.G_MUTABLE
+		if s.types == 0 {
+			gp |= gogl.G_BASIC
+		}
+	}
+
+	gp |= s.dir | s.types
+	if s.types != 0 {
+		gp &^= gogl.G_BASIC
+	}
+
+	if s.loops {
+		gp |= gogl.G_LOOPS
+	}
+	if s.parallel {
+		gp |= gogl.G_PARALLEL
+	}
+	if gp&(gogl.G_LOOPS|gogl.G_PARALLEL) == 0 {
+		gp |= gogl.G_SIMPLE
+	} else {
+		gp &^= gogl.G_SIMPLE
+	}
+
+	return gp
+}
+
+// Returns the Source as a GraphSource that also implements DigraphSource if
+// the graph is directed, or the arc and undirected edge enumerators of a
+// MixedGraph if the graph is mixed. This is the value decoders should return.
+func (s *Source) GraphSource() gogl.GraphSource {
+	switch s.dir {
+	case gogl.G_DIRECTED:
+		return digraphSource{s}
+	case gogl.G_DIRECTED | gogl.G_UNDIRECTED:
+		return mixedSource{s}
+	default:
+		return s
+	}
+}
+
+func (s *Source) Vertices(f gogl.VertexStep) {
+	for _, v := range s.vertices {
+		if f(v) {
+			return
+		}
+	}
+}
+
+func (s *Source) Edges(f gogl.EdgeStep) {
+	for _, e := range s.edges {
+		if f(e) {
+			return
+		}
+	}
+}
+
+func (s *Source) Order() int {
+	return len(s.vertices)
+}
+
+func (s *Source) Size() int {
+	return len(s.edges)
+}
+
+func (s *Source) VertexData(v gogl.Vertex) (data interface{}, exists bool) {
+	data, exists = s.vdata[v]
+	return
+}
+
+func (s *Source) VertexLabel(v gogl.Vertex) (label string, exists bool) {
+	label, exists = s.vlabels[v]
+	return
+}
+
+func (s *Source) VertexWeight(v gogl.Vertex) (weight float64, exists bool) {
+	weight, exists = s.vweights[v]
+	return
+}
+
+func (s *Source) arcs(f gogl.ArcStep) {
+	for _, e := range s.edges {
+		if a, ok := e.(gogl.Arc); ok {
+			if f(a) {
+				return
+			}
+		}
+	}
+}
+
+type digraphSource struct {
+	*Source
+}
+
+func (s digraphSource) Arcs(f gogl.ArcStep) {
+	s.arcs(f)
+}
+
+type mixedSource struct {
+	*Source
+}
+
+func (s mixedSource) Arcs(f gogl.ArcStep) {
+	s.arcs(f)
+}
+
+func (s mixedSource) UndirectedEdges(f gogl.EdgeStep) {
+	for _, e := range s.edges {
+		if _, ok := e.(gogl.Arc); !ok {
+			if f(e) {
+				return
+			}
+		}
+	}
+}
+
+func (s mixedSource) UndirectedIncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	s.UndirectedEdges(func(e gogl.Edge) (terminate bool) {
+		if u, w := e.Both(); u == v || w == v {
+			return f(e)
+		}
+		return
+	})
+}
@@ -0,0 +1,116 @@
+package encoding
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+)
+
+// Hook gocheck into the go test runner
+func Test(t *testing.T) { TestingT(t) }
+
+type SourceSuite struct{}
+
+var _ = Suite(&SourceSuite{})
+
+func (s *SourceSuite) TestAttrs(c *C) {
+	a := AttrsOf(gogl.NewPropertyEdge(1, 2, 5.23, "foo", nil))
+	c.Assert(a.Props, Equals, gogl.GraphProperties(gogl.G_WEIGHTED|gogl.G_LABELED|gogl.G_DATA))
+
+	a = AttrsOf(gogl.NewLabeledArc(1, 2, "foo"))
+	c.Assert(a.Props, Equals, gogl.GraphProperties(gogl.G_LABELED))
+	c.Assert(a.Edge(1, 2), DeepEquals, gogl.NewLabeledEdge(1, 2, "foo"))
+	c.Assert(a.Arc(1, 2), DeepEquals, gogl.NewLabeledArc(1, 2, "foo"))
+
+	a.SetWeight(2)
+	c.Assert(a.Arc(1, 2), DeepEquals, gogl.NewPropertyArc(1, 2, 2, "foo", nil))
+
+	c.Assert(Attrs{}.Edge(1, 2), DeepEquals, gogl.NewEdge(1, 2))
+}
+
+func (s *SourceSuite) TestProperties(c *C) {
+	src := NewSource(false)
+	src.AddVertex("isolate")
+	c.Assert(src.Properties(), Equals, gogl.GraphProperties(gogl.G_UNDIRECTED|gogl.G_BASIC|gogl.G_SIMPLE|gogl.G_MUTABLE))
+
+	src.AddEdge(gogl.NewWeightedEdge(1, 2, 1))
+	src.AddEdge(gogl.NewLabeledEdge(2, 1, "foo"))
+	c.Assert(src.Properties(), Equals, gogl.GraphProperties(gogl.G_UNDIRECTED|gogl.G_WEIGHTED|gogl.G_LABELED|gogl.G_PARALLEL|gogl.G_MUTABLE))
+
+	src.AddEdge(gogl.NewEdge(3, 3))
+	c.Assert(src.Properties()&gogl.G_LOOPS, Equals, gogl.GraphProperties(gogl.G_LOOPS))
+
+	c.Assert(gogl.Order(src), Equals, 4)
+	c.Assert(gogl.Size(src), Equals, 3)
+	c.Assert(gogl.CollectVertices(src), DeepEquals, []gogl.Vertex{"isolate", 1, 2, 3})
+}
+
+func (s *SourceSuite) TestDeclared(c *C) {
+	src := NewSource(true)
+	src.Declare(gogl.Spec().Directed().PropertyEdges().Immutable().Props)
+	src.AddEdge(gogl.NewWeightedArc(1, 2, 1))
+	c.Assert(src.Properties(), Equals, gogl.Spec().Directed().PropertyEdges().Immutable().Props)
+
+	// Declarations can't hide what the source actually holds
+	src.AddEdge(gogl.NewWeightedArc(1, 2, 1))
+	c.Assert(src.Properties(), Equals, gogl.Spec().Directed().PropertyEdges().Immutable().Parallel().Props)
+}
+
+func (s *SourceSuite) TestInfer(c *C) {
+	c.Assert(Infer(gogl.EdgeList{gogl.NewEdge(1, 2)}), Equals, gogl.Spec().Props)
+	c.Assert(Infer(gogl.ArcList{gogl.NewArc(1, 2), gogl.NewArc(1, 2)}), Equals, gogl.Spec().Directed().MultiGraph().Props)
+	c.Assert(Infer(gogl.LabeledEdgeList{gogl.NewLabeledEdge(1, 1, "foo")}), Equals, gogl.Spec().Labeled().Loop().Props)
+
+	src := NewSource(false)
+	src.Declare(gogl.Spec().Immutable().Props)
+	c.Assert(Infer(src.GraphSource()), Equals, gogl.Spec().Immutable().Props)
+
+	spec := Spec(src.GraphSource())
+	c.Assert(spec.Props, Equals, gogl.Spec().Immutable().Props)
+	c.Assert(spec.Source, Equals, src.GraphSource())
+}
+
+func (s *SourceSuite) TestDirectedness(c *C) {
+	src := NewSource(true)
+	src.AddEdge(gogl.NewArc(1, 2))
+	src.AddEdge(gogl.NewArc(2, 1))
+	c.Assert(src.Properties()&gogl.G_PARALLEL, Equals, gogl.GraphProperties(0))
+	c.Assert(src.GraphSource(), Implements, new(gogl.DigraphSource))
+	c.Assert(src.GraphSource(), Not(Implements), new(gogl.UndirectedEdgeEnumerator))
+
+	src.AddEdge(gogl.NewEdge(2, 3))
+	c.Assert(src.Properties()&(gogl.G_DIRECTED|gogl.G_UNDIRECTED), Equals, gogl.GraphProperties(gogl.G_DIRECTED|gogl.G_UNDIRECTED))
+
+	mg := src.GraphSource().(gogl.UndirectedEdgeEnumerator)
+	var hit int
+	mg.UndirectedIncidentTo(3, func(e gogl.Edge) (terminate bool) {
+		hit++
+		return
+	})
+	c.Assert(hit, Equals, 1)
+
+	c.Assert(NewSource(false).GraphSource(), Not(Implements), new(gogl.DigraphSource))
+}
+
+func (s *SourceSuite) TestVertexAttributes(c *C) {
+	src := NewSource(false)
+	src.SetVertexLabel(1, "foo")
+	src.SetVertexWeight(2, 1.5)
+	src.SetVertexData(1, 42)
+
+	c.Assert(gogl.Order(src), Equals, 2)
+
+	label, exists := src.VertexLabel(1)
+	c.Assert(exists, Equals, true)
+	c.Assert(label, Equals, "foo")
+
+	_, exists = src.VertexLabel(2)
+	c.Assert(exists, Equals, false)
+
+	weight, _ := src.VertexWeight(2)
+	c.Assert(weight, Equals, 1.5)
+
+	data, _ := src.VertexData(1)
+	c.Assert(data, Equals, 42)
+}
@@ -0,0 +1,206 @@
+package gen
+
+// Returns the empty graph on n vertices: n isolates, and no edges.
+func Empty(n int) Source {
+	return Source{order: n, pairs: func(f func(u, v int) bool) {}}
+}
+
+// Returns the complete graph K_n, in which every pair of distinct vertices is
+// joined by an edge. Directed, each arc points from the lower-numbered vertex
+// to the higher, giving a transitive tournament.
+func Complete(n int) Source {
+	return Source{order: n, pairs: func(f func(u, v int) bool) {
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if f(u, v) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// Returns the complete bipartite graph K_{m,n}. Vertices 0 through m-1 form
+// one part and vertices m through m+n-1 the other; every vertex is joined to
+// every vertex in the opposite part. Directed, arcs point from the first part
+// to the second.
+func CompleteBipartite(m, n int) Source {
+	return Source{order: m + n, pairs: func(f func(u, v int) bool) {
+		for u := 0; u < m; u++ {
+			for v := m; v < m+n; v++ {
+				if f(u, v) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// Returns the path graph P_n, on which vertex i is joined to vertex i+1.
+// Directed, arcs point from i to i+1.
+func Path(n int) Source {
+	return Source{order: n, pairs: func(f func(u, v int) bool) {
+		for u := 0; u+1 < n; u++ {
+			if f(u, u+1) {
+				return
+			}
+		}
+	}}
+}
+
+// Returns the cycle graph C_n: the path P_n, plus an edge joining vertex n-1
+// back to vertex 0. Directed, arcs point from i to i+1, and from n-1 to 0.
+//
+// n must be at least 3, else panic.
+func Cycle(n int) Source {
+	if n < 3 {
+		panic("A cycle must have at least 3 vertices.")
+	}
+
+	return Source{order: n, pairs: func(f func(u, v int) bool) {
+		for u := 0; u < n; u++ {
+			if f(u, (u+1)%n) {
+				return
+			}
+		}
+	}}
+}
+
+// Returns the star graph on n vertices, in which vertex 0 is joined to each of
+// the n-1 others. Directed, arcs point outward from vertex 0.
+func Star(n int) Source {
+	return Source{order: n, pairs: func(f func(u, v int) bool) {
+		for v := 1; v < n; v++ {
+			if f(0, v) {
+				return
+			}
+		}
+	}}
+}
+
+// Returns the wheel graph on n vertices: a hub, vertex 0, joined to each vertex
+// of a cycle formed by vertices 1 through n-1. Directed, the spokes point
+// outward from the hub and the rim is oriented as for Cycle.
+//
+// n must be at least 4, else panic.
+func Wheel(n int) Source {
+	if n < 4 {
+		panic("A wheel must have at least 4 vertices.")
+	}
+
+	return Source{order: n, pairs: func(f func(u, v int) bool) {
+		for v := 1; v < n; v++ {
+			if f(0, v) {
+				return
+			}
+		}
+		for u := 1; u < n; u++ {
+			v := u + 1
+			if v == n {
+				v = 1
+			}
+			if f(u, v) {
+				return
+			}
+		}
+	}}
+}
+
+// Returns the grid, or lattice, graph with the given dimensions; Grid(3, 4) is
+// a 3x4 grid with 12 vertices. Vertices are numbered in row-major order - the
+// last coordinate varies fastest - and each is joined to its successor along
+// every dimension. Directed, arcs point from a vertex to those successors.
+//
+// Dimensions must be positive, else panic.
+func Grid(dims ...int) Source {
+	order := 1
+	for _, d := range dims {
+		if d < 1 {
+			panic("Grid dimensions must be positive.")
+		}
+		order *= d
+	}
+	if len(dims) == 0 {
+		order = 0
+	}
+
+	return Source{order: order, pairs: func(f func(u, v int) bool) {
+		for u := 0; u < order; u++ {
+			// stride is the distance between successive vertices along dimension i
+			rest, stride := u, 1
+			for i := len(dims) - 1; i >= 0; i-- {
+				if rest%dims[i] < dims[i]-1 && f(u, u+stride) {
+					return
+				}
+				rest /= dims[i]
+				stride *= dims[i]
+			}
+		}
+	}}
+}
+
+// Returns the d-dimensional hypercube Q_d, on 2^d vertices. Two vertices are
+// joined if their numbers differ in exactly one bit. Directed, arcs point from
+// the lower-numbered vertex to the higher.
+func Hypercube(d uint) Source {
+	order := 1 << d
+	return Source{order: order, pairs: func(f func(u, v int) bool) {
+		for u := 0; u < order; u++ {
+			for bit := 1; bit < order; bit <<= 1 {
+				if u&bit == 0 && f(u, u|bit) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// Returns the complete k-ary tree on n vertices: the tree in which every level
+// but the last is full, and the last is filled from the left. Vertex 0 is the
+// root, and the children of vertex i are vertices ki+1 through ki+k. Directed,
+// arcs point from parent to child.
+//
+// k must be positive, else panic.
+func Tree(k, n int) Source {
+	if k < 1 {
+		panic("A tree's branching factor must be positive.")
+	}
+
+	return Source{order: n, pairs: func(f func(u, v int) bool) {
+		for v := 1; v < n; v++ {
+			if f((v-1)/k, v) {
+				return
+			}
+		}
+	}}
+}
+
+// Returns the complete binary tree on n vertices. It is equivalent to Tree(2, n).
+func BinaryTree(n int) Source {
+	return Tree(2, n)
+}
+
+// Returns the Petersen graph. Vertices 0 through 4 form the outer cycle,
+// joined in order; vertex i is joined by a spoke to vertex i+5; and vertices 5
+// through 9 form the inner pentagram, 5+i joined to 5+(i+2)%5. Directed, the
+// outer cycle is oriented as for Cycle, spokes point inward, and the
+// pentagram's arcs point from 5+i to 5+(i+2)%5.
+func Petersen() Source {
+	return Source{order: 10, pairs: func(f func(u, v int) bool) {
+		for i := 0; i < 5; i++ {
+			if f(i, (i+1)%5) {
+				return
+			}
+		}
+		for i := 0; i < 5; i++ {
+			if f(i, i+5) {
+				return
+			}
+		}
+		for i := 0; i < 5; i++ {
+			if f(5+i, 5+(i+2)%5) {
+				return
+			}
+		}
+	}}
+}
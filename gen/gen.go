@@ -0,0 +1,125 @@
+// Constructs the classic, deterministic graph families: complete graphs, paths,
+// cycles, stars, wheels, grids, hypercubes, trees, the Petersen graph and so on.
+//
+// Every family's vertices are the ints 0 through n-1, and both vertices and
+// edges are always enumerated in the same order, which makes these graphs
+// convenient fixtures. Each constructor documents how it numbers its vertices.
+//
+// Constructors return a Source, which is an undirected GraphSource. Its
+// Directed() method orients each edge as documented by the constructor and
+// returns a DigraphSource; Weighted() and Labeled() attach weights or labels,
+// computed from each edge's endpoints, to either.
+package gen
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Passes the endpoints of each edge in a family to the step function, until
+// the family is exhausted or the step function returns true.
+type pairs func(f func(u, v int) (terminate bool))
+
+// An undirected graph from one of the classic families. Source values are
+// immutable; Weighted() and Labeled() return modified copies.
+type Source struct {
+	order  int
+	pairs  pairs
+	weight func(u, v int) float64
+	label  func(u, v int) string
+}
+
+// Returns a copy of the graph whose edges carry the weight computed by the
+// provided function from their endpoints.
+func (g Source) Weighted(weight func(u, v int) float64) Source {
+	g.weight = weight
+	return g
+}
+
+// Returns a copy of the graph whose edges carry the label computed by the
+// provided function from their endpoints.
+func (g Source) Labeled(label func(u, v int) string) Source {
+	g.label = label
+	return g
+}
+
+// Returns a directed version of the graph, in which each edge is replaced by a
+// single arc oriented as described by the graph's constructor.
+func (g Source) Directed() DigraphSource {
+	return DigraphSource{g}
+}
+
+func (g Source) Vertices(f gogl.VertexStep) {
+	for i := 0; i < g.order; i++ {
+		if f(i) {
+			return
+		}
+	}
+}
+
+func (g Source) Edges(f gogl.EdgeStep) {
+	g.pairs(func(u, v int) bool {
+		switch {
+		case g.weight != nil && g.label != nil:
+			return f(gogl.NewPropertyEdge(u, v, g.weight(u, v), g.label(u, v), nil))
+		case g.weight != nil:
+			return f(gogl.NewWeightedEdge(u, v, g.weight(u, v)))
+		case g.label != nil:
+			return f(gogl.NewLabeledEdge(u, v, g.label(u, v)))
+		default:
+			return f(gogl.NewEdge(u, v))
+		}
+	})
+}
+
+func (g Source) Order() int {
+	return g.order
+}
+
+func (g Source) Size() (size int) {
+	g.pairs(func(u, v int) bool {
+		size++
+		return false
+	})
+	return
+}
+
+// A directed graph from one of the classic families. It yields arcs from both
+// Edges() and Arcs().
+type DigraphSource struct {
+	Source
+}
+
+// Returns a copy of the digraph whose arcs carry the weight computed by the
+// provided function from their source and target.
+func (g DigraphSource) Weighted(weight func(u, v int) float64) DigraphSource {
+	g.weight = weight
+	return g
+}
+
+// Returns a copy of the digraph whose arcs carry the label computed by the
+// provided function from their source and target.
+func (g DigraphSource) Labeled(label func(u, v int) string) DigraphSource {
+	g.label = label
+	return g
+}
+
+func (g DigraphSource) Edges(f gogl.EdgeStep) {
+	g.Arcs(func(a gogl.Arc) bool {
+		return f(a)
+	})
+}
+
+func (g DigraphSource) Arcs(f gogl.ArcStep) {
+	g.pairs(func(u, v int) bool {
+		switch {
+		case g.weight != nil && g.label != nil:
+			return f(gogl.NewPropertyArc(u, v, g.weight(u, v), g.label(u, v), nil))
+		case g.weight != nil:
+			return f(gogl.NewWeightedArc(u, v, g.weight(u, v)))
+		case g.label != nil:
+			return f(gogl.NewLabeledArc(u, v, g.label(u, v)))
+		default:
+			return f(gogl.NewArc(u, v))
+		}
+	})
+}
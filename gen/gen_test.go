@@ -0,0 +1,164 @@
+package gen
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+func TestGen(t *testing.T) { TestingT(t) }
+
+type GenSuite struct{}
+
+var _ = Suite(&GenSuite{})
+
+func pairsOf(g gogl.GraphSource) (pairs [][2]int) {
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		pairs = append(pairs, [2]int{u.(int), v.(int)})
+		return
+	})
+	return
+}
+
+func degrees(g gogl.GraphSource) []int {
+	deg := make([]int, gogl.Order(g))
+	for _, p := range pairsOf(g) {
+		deg[p[0]]++
+		deg[p[1]]++
+	}
+	return deg
+}
+
+func (s *GenSuite) TestCounts(c *C) {
+	for name, tc := range map[string]struct {
+		g           Source
+		order, size int
+	}{
+		"empty":     {Empty(4), 4, 0},
+		"complete":  {Complete(6), 6, 15},
+		"bipartite": {CompleteBipartite(2, 3), 5, 6},
+		"path":      {Path(5), 5, 4},
+		"cycle":     {Cycle(5), 5, 5},
+		"star":      {Star(5), 5, 4},
+		"wheel":     {Wheel(6), 6, 10},
+		"grid":      {Grid(3, 4), 12, 17},
+		"grid3":     {Grid(2, 2, 2), 8, 12},
+		"hypercube": {Hypercube(4), 16, 32},
+		"tree":      {Tree(3, 13), 13, 12},
+		"binary":    {BinaryTree(7), 7, 6},
+		"petersen":  {Petersen(), 10, 15},
+	} {
+		c.Assert(tc.g.Order(), Equals, tc.order, Commentf(name))
+		c.Assert(gogl.Order(tc.g), Equals, tc.order, Commentf(name))
+		c.Assert(tc.g.Size(), Equals, tc.size, Commentf(name))
+		c.Assert(len(pairsOf(tc.g)), Equals, tc.size, Commentf(name))
+	}
+}
+
+func (s *GenSuite) TestStructure(c *C) {
+	c.Assert(pairsOf(Path(4)), DeepEquals, [][2]int{{0, 1}, {1, 2}, {2, 3}})
+	c.Assert(pairsOf(Cycle(3)), DeepEquals, [][2]int{{0, 1}, {1, 2}, {2, 0}})
+	c.Assert(pairsOf(BinaryTree(5)), DeepEquals, [][2]int{{0, 1}, {0, 2}, {1, 3}, {1, 4}})
+	c.Assert(pairsOf(Grid(2, 3)), DeepEquals, [][2]int{{0, 1}, {0, 3}, {1, 2}, {1, 4}, {2, 5}, {3, 4}, {4, 5}})
+
+	c.Assert(degrees(Wheel(5)), DeepEquals, []int{4, 3, 3, 3, 3})
+	for _, d := range degrees(Petersen()) {
+		c.Assert(d, Equals, 3)
+	}
+	for _, d := range degrees(Hypercube(3)) {
+		c.Assert(d, Equals, 3)
+	}
+	for _, p := range pairsOf(CompleteBipartite(3, 2)) {
+		c.Assert(p[0] < 3 && p[1] >= 3, Equals, true)
+	}
+	for _, p := range pairsOf(Hypercube(5)) {
+		x := p[0] ^ p[1]
+		c.Assert(x != 0 && x&(x-1) == 0, Equals, true)
+	}
+}
+
+func (s *GenSuite) TestPanics(c *C) {
+	c.Assert(func() { Cycle(2) }, PanicMatches, "A cycle must have at least 3 vertices.")
+	c.Assert(func() { Wheel(3) }, PanicMatches, "A wheel must have at least 4 vertices.")
+	c.Assert(func() { Grid(3, 0) }, PanicMatches, "Grid dimensions must be positive.")
+	c.Assert(func() { Tree(0, 3) }, PanicMatches, "A tree's branching factor must be positive.")
+}
+
+func (s *GenSuite) TestTermination(c *C) {
+	for _, g := range []Source{Complete(5), Grid(3, 3), Hypercube(3), Petersen(), Wheel(5)} {
+		hit := 0
+		g.Edges(func(e gogl.Edge) bool {
+			hit++
+			return true
+		})
+		c.Assert(hit, Equals, 1)
+	}
+}
+
+func (s *GenSuite) TestDirected(c *C) {
+	g := Cycle(4).Directed()
+	c.Assert(g, Implements, new(gogl.DigraphSource))
+
+	var arcs []gogl.Arc
+	g.Arcs(func(a gogl.Arc) (terminate bool) {
+		arcs = append(arcs, a)
+		return
+	})
+	c.Assert(arcs, DeepEquals, []gogl.Arc{gogl.NewArc(0, 1), gogl.NewArc(1, 2), gogl.NewArc(2, 3), gogl.NewArc(3, 0)})
+
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e, Implements, new(gogl.Arc))
+		return
+	})
+
+	var _ gogl.GraphSource = Path(3)
+	c.Assert(Path(3), Not(Implements), new(gogl.DigraphSource))
+}
+
+func (s *GenSuite) TestAttributes(c *C) {
+	w := func(u, v int) float64 { return float64(u + v) }
+	l := func(u, v int) string { return fmt.Sprintf("%d-%d", u, v) }
+
+	c.Assert(pairsOf(Path(3).Weighted(w)), HasLen, 2)
+	Path(3).Weighted(w).Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e, Implements, new(gogl.WeightedEdge))
+		c.Assert(e, Not(Implements), new(gogl.LabeledEdge))
+		u, v := e.Both()
+		c.Assert(e.(gogl.WeightedEdge).Weight(), Equals, float64(u.(int)+v.(int)))
+		return
+	})
+
+	Star(3).Labeled(l).Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e, Implements, new(gogl.LabeledEdge))
+		c.Assert(e, Not(Implements), new(gogl.WeightedEdge))
+		return
+	})
+
+	var arcs []gogl.Arc
+	Path(3).Directed().Weighted(w).Labeled(l).Arcs(func(a gogl.Arc) (terminate bool) {
+		arcs = append(arcs, a)
+		return
+	})
+	c.Assert(arcs, DeepEquals, []gogl.Arc{
+		gogl.NewPropertyArc(0, 1, 1, "0-1", nil),
+		gogl.NewPropertyArc(1, 2, 3, "1-2", nil),
+	})
+}
+
+func (s *GenSuite) TestPopulate(c *C) {
+	g := gogl.Spec().Using(Petersen()).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 10)
+	c.Assert(gogl.Size(g), Equals, 15)
+
+	dg := gogl.Spec().Directed().Weighted().Using(Complete(4).Directed().Weighted(func(u, v int) float64 {
+		return 1
+	})).Create(al.G).(gogl.Digraph)
+	out, _ := dg.OutDegreeOf(0)
+	in, _ := dg.InDegreeOf(0)
+	c.Assert(out, Equals, 3)
+	c.Assert(in, Equals, 0)
+}
@@ -70,6 +70,44 @@ type DigraphSource interface {
 	ArcEnumerator
 }
 
+// MixedGraph describes a graph containing both undirected edges and directed
+// edges (arcs); for example, a road network with both one-way and two-way streets.
+//
+// The methods inherited from Graph are concerned with all of the graph's
+// members, regardless of directionality. Edges() enumerates every member;
+// arcs are passed as values implementing Arc, whereas undirected edges never
+// implement Arc, so the two can be distinguished with a type assertion.
+// Similarly, IncidentTo(), AdjacentTo(), HasEdge() and DegreeOf() consider
+// both arcs and undirected edges.
+//
+// The arc-specific methods (Arcs(), ArcsFrom(), ArcsTo(), HasArc()) consider
+// only arcs, and the undirected-specific methods consider only undirected
+// edges. SuccessorsOf() and PredecessorsOf() are the exception: they enumerate
+// the vertices that can be reached from (or can reach) the given vertex by
+// traversing one member in a permissible direction - that is, via an arc in
+// its direction, or via an undirected edge in either direction. This makes
+// them the natural basis for traversal algorithms.
+type MixedGraph interface {
+	Graph
+	ArcEnumerator            // Enumerates only the graph's arcs to an injected step function
+	UndirectedEdgeEnumerator // Enumerates only the graph's undirected edges to an injected step function
+	IncidentArcEnumerator    // Enumerates a vertex's incident in- or out-arcs to an injected step function
+	ProcessionEnumerator     // Enumerates the vertices reachable to or from a vertex in one step
+	ArcMembershipChecker     // Allows inspection of contained arcs
+}
+
+// MutableMixedGraph describes a mixed graph with basic edges and arcs that
+// can be modified freely by adding or removing vertices, edges, or arcs.
+//
+// AddEdges() and RemoveEdges() deal only in undirected edges, and
+// AddArcs() and RemoveArcs() deal only in arcs.
+type MutableMixedGraph interface {
+	MixedGraph
+	VertexSetMutator
+	EdgeSetMutator
+	ArcSetMutator
+}
+
 // MutableGraph describes a graph with basic edges (no weighting, labeling, etc.)
 // that can be modified freely by adding or removing vertices or edges.
 type MutableGraph interface {
@@ -198,6 +236,48 @@ type MutableDataGraph interface {
 	DataEdgeSetMutator
 }
 
+// A property graph is a graph subtype where every edge carries a weight, a
+// label and arbitrary data simultaneously, as described by the PropertyEdge
+// interface. PropertyGraphs are thus also WeightedGraphs, LabeledGraphs and
+// DataGraphs.
+//
+// Each of the Has*Edge() methods should return true iff an edge exists
+// connecting the two given vertices (respecting directed or undirected as
+// appropriate), AND every property the provided edge carries is the same as
+// on the contained edge. Properties the provided edge does not carry are
+// disregarded: HasWeightedEdge() compares only weights when given a plain
+// WeightedEdge, but compares all three properties when given a PropertyEdge.
+// As with DataGraphs, noncomparable data will cause a panic.
+type PropertyGraph interface {
+	Graph
+	HasWeightedEdge(e WeightedEdge) bool
+	HasLabeledEdge(e LabeledEdge) bool
+	HasDataEdge(e DataEdge) bool
+	HasPropertyEdge(e PropertyEdge) bool
+}
+
+// PropertyDigraph describes a graph where all edges are property arcs (directed).
+type PropertyDigraph interface {
+	Digraph
+	HasWeightedEdge(e WeightedEdge) bool
+	HasLabeledEdge(e LabeledEdge) bool
+	HasDataEdge(e DataEdge) bool
+	HasPropertyEdge(e PropertyEdge) bool
+	HasWeightedArc(a WeightedArc) bool
+	HasLabeledArc(a LabeledArc) bool
+	HasDataArc(a DataArc) bool
+	HasPropertyArc(a PropertyArc) bool
+}
+
+// MutablePropertyGraph is the mutable version of a property graph. Its
+// AddEdges() method is incompatible with MutableGraph, guaranteeing
+// only property edges can be present in the graph.
+type MutablePropertyGraph interface {
+	PropertyGraph
+	VertexSetMutator
+	PropertyEdgeSetMutator
+}
+
 /* Atomic graph interfaces */
 
 // EdgeSteps are used as arguments to various enumerators. They are called once for each edge produced by the enumerator.
@@ -238,6 +318,16 @@ type ArcEnumerator interface {
 	Arcs(ArcStep)
 }
 
+// An UndirectedEdgeEnumerator iteratively enumerates the undirected edges in a
+// mixed graph, ignoring any arcs.
+type UndirectedEdgeEnumerator interface {
+	// Calls the provided step function once with each undirected edge in the graph.
+	UndirectedEdges(EdgeStep)
+	// Calls the provided step function once with each undirected edge
+	// incident to the provided vertex.
+	UndirectedIncidentTo(v Vertex, incidentEdgeStep EdgeStep)
+}
+
 // An IncidentEdgeEnumerator iteratively enumerates a given vertex's incident edges.
 type IncidentEdgeEnumerator interface {
 	// Calls the provided step function once with each edge incident to the
@@ -299,6 +389,29 @@ type ArcMembershipChecker interface {
 	HasArc(Arc) bool
 }
 
+// A VertexAttributeSource reports the attributes attached to vertices. Each
+// attribute is independent of the others; a vertex may have a label but no
+// data, for example. The second return value indicates whether the attribute
+// has been set for the vertex.
+type VertexAttributeSource interface {
+	VertexData(Vertex) (data interface{}, exists bool)
+	VertexLabel(Vertex) (label string, exists bool)
+	VertexWeight(Vertex) (weight float64, exists bool)
+}
+
+// VertexAttributes allows arbitrary data, a label, and a weight to be attached
+// to the vertices in a graph, without the need to maintain a parallel map.
+//
+// Attributes can only be attached to vertices already present in the graph;
+// each setter returns false, without effect, if the vertex is absent. Removing
+// a vertex from the graph also removes its attributes.
+type VertexAttributes interface {
+	VertexAttributeSource
+	SetVertexData(v Vertex, data interface{}) (exists bool)
+	SetVertexLabel(v Vertex, label string) (exists bool)
+	SetVertexWeight(v Vertex, weight float64) (exists bool)
+}
+
 // A VertexSetMutator allows the addition and removal of vertices from a set.
 type VertexSetMutator interface {
 	// Ensures the provided vertices are present in the graph.
@@ -355,6 +468,18 @@ type DataArcSetMutator interface {
 	RemoveArcs(arcs ...DataArc)
 }
 
+// A PropertyEdgeSetMutator allows the addition and removal of property edges from a set.
+type PropertyEdgeSetMutator interface {
+	AddEdges(edges ...PropertyEdge)
+	RemoveEdges(edges ...PropertyEdge)
+}
+
+// A PropertyArcSetMutator allows the addition and removal of property arcs from a set.
+type PropertyArcSetMutator interface {
+	AddArcs(arcs ...PropertyArc)
+	RemoveArcs(arcs ...PropertyArc)
+}
+
 /* Optional optimization interfaces
 
 These interfaces describe behaviors and information about a graph which can be
@@ -1,8 +1,10 @@
 package al
 
 import (
-	. "github.com/sdboyer/gogl"
+	"errors"
 	"sync"
+
+	. "github.com/sdboyer/gogl"
 )
 
 /*
@@ -16,17 +18,60 @@ complex graph types, such as multigraphs.
 gogl's adjacency lists are space-efficient; in a directed graph, the memory
 cost for the entire graph G is proportional to V + E; in an undirected graph,
 it is V + 2E.
+
+Every edge type is available in both mutable and immutable variants. Immutable
+adjacency lists are populated once, at creation time, from the GraphSpec's
+source; because they can never change afterwards, they do not take locks on
+reads, making them a good fit for read-heavy, shared graphs.
+
+A mutable, basic mixed graph - one holding both undirected edges and arcs -
+is also provided. It keeps its arcs and its undirected edges in two separate
+lists, so its memory cost is proportional to V + A + 2E.
+
+Property graphs, whose edges carry a weight, a label and data all at once, are
+available in all the same variants as the single-property edge types. Specs
+asking for any two or three of those properties resolve to them.
 */
 
 var alCreators = map[GraphProperties]func() Graph{
 	GraphProperties(G_IMMUTABLE | G_DIRECTED | G_BASIC | G_SIMPLE): func() Graph {
 		return &immutableDirected{al_basic_immut{al_basic{list: make(map[Vertex]map[Vertex]struct{})}}}
 	},
+	GraphProperties(G_IMMUTABLE | G_UNDIRECTED | G_BASIC | G_SIMPLE): func() Graph {
+		return &immutableUndirected{al_basic_immut{al_basic{list: make(map[Vertex]map[Vertex]struct{})}}}
+	},
+	GraphProperties(G_IMMUTABLE | G_DIRECTED | G_WEIGHTED | G_SIMPLE): func() Graph {
+		return &immutableWeightedDirected{baseWeightedImmut{list: make(map[Vertex]map[Vertex]float64)}}
+	},
+	GraphProperties(G_IMMUTABLE | G_UNDIRECTED | G_WEIGHTED | G_SIMPLE): func() Graph {
+		return &immutableWeightedUndirected{baseWeightedImmut{list: make(map[Vertex]map[Vertex]float64)}}
+	},
+	GraphProperties(G_IMMUTABLE | G_DIRECTED | G_LABELED | G_SIMPLE): func() Graph {
+		return &immutableLabeledDirected{baseLabeledImmut{list: make(map[Vertex]map[Vertex]string)}}
+	},
+	GraphProperties(G_IMMUTABLE | G_UNDIRECTED | G_LABELED | G_SIMPLE): func() Graph {
+		return &immutableLabeledUndirected{baseLabeledImmut{list: make(map[Vertex]map[Vertex]string)}}
+	},
+	GraphProperties(G_IMMUTABLE | G_DIRECTED | G_DATA | G_SIMPLE): func() Graph {
+		return &immutableDataDirected{baseDataImmut{list: make(map[Vertex]map[Vertex]interface{})}}
+	},
+	GraphProperties(G_IMMUTABLE | G_UNDIRECTED | G_DATA | G_SIMPLE): func() Graph {
+		return &immutableDataUndirected{baseDataImmut{list: make(map[Vertex]map[Vertex]interface{})}}
+	},
+	GraphProperties(G_IMMUTABLE | G_DIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE): func() Graph {
+		return &immutablePropertyDirected{basePropertyImmut{list: make(map[Vertex]map[Vertex]edgeProps)}}
+	},
+	GraphProperties(G_IMMUTABLE | G_UNDIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE): func() Graph {
+		return &immutablePropertyUndirected{basePropertyImmut{list: make(map[Vertex]map[Vertex]edgeProps)}}
+	},
 	GraphProperties(G_MUTABLE | G_DIRECTED | G_BASIC | G_SIMPLE): func() Graph {
-		return &mutableDirected{al_basic_mut{al_basic{list: make(map[Vertex]map[Vertex]struct{})}, sync.RWMutex{}}}
+		return &mutableDirected{al_basic_mut{al_basic: al_basic{list: make(map[Vertex]map[Vertex]struct{})}}}
 	},
 	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_BASIC | G_SIMPLE): func() Graph {
-		return &mutableUndirected{al_basic_mut{al_basic{list: make(map[Vertex]map[Vertex]struct{})}, sync.RWMutex{}}}
+		return &mutableUndirected{al_basic_mut{al_basic: al_basic{list: make(map[Vertex]map[Vertex]struct{})}}}
+	},
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_UNDIRECTED | G_BASIC | G_SIMPLE): func() Graph {
+		return &mutableMixed{arcs: make(map[Vertex]map[Vertex]struct{}), edges: make(map[Vertex]map[Vertex]struct{})}
 	},
 	GraphProperties(G_MUTABLE | G_DIRECTED | G_WEIGHTED | G_SIMPLE): func() Graph {
 		return &weightedDirected{baseWeighted{list: make(map[Vertex]map[Vertex]float64), size: 0, mu: sync.RWMutex{}}}
@@ -46,40 +91,78 @@ var alCreators = map[GraphProperties]func() Graph{
 	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_DATA | G_SIMPLE): func() Graph {
 		return &dataUndirected{baseData{list: make(map[Vertex]map[Vertex]interface{}), size: 0, mu: sync.RWMutex{}}}
 	},
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE): func() Graph {
+		return &propertyDirected{baseProperty{list: make(map[Vertex]map[Vertex]edgeProps), size: 0, mu: sync.RWMutex{}}}
+	},
+	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE): func() Graph {
+		return &propertyUndirected{baseProperty{list: make(map[Vertex]map[Vertex]edgeProps), size: 0, mu: sync.RWMutex{}}}
+	},
+}
+
+// The registry against which al's own graph implementations are resolved.
+var alRegistry = registerCreators()
+
+// Builds al's private registry, also registering each implementation with
+// gogl's DefaultRegistry as a fallback, so that other implementations
+// registered there for the same properties take precedence. This is done as
+// part of variable initialization rather than in init() so that package-level
+// graphs can be created with G.
+func registerCreators() *Registry {
+	r := &Registry{}
+	for gp, gf := range alCreators {
+		f := populator(gp, gf)
+		r.Register(gp, f)
+		RegisterFallback(gp, f)
+	}
+	return r
+}
+
+// Wraps an adjacency list creator in a GraphCreator that imports the
+// GraphSpec's source, if any, into the newly created graph.
+func populator(gp GraphProperties, gf func() Graph) GraphCreator {
+	return func(gs GraphSpec) (Graph, error) {
+		if gs.Source == nil {
+			return gf(), nil
+		}
+
+		if gp&(G_DIRECTED|G_UNDIRECTED) == G_DIRECTED {
+			dgs, ok := gs.Source.(DigraphSource)
+			if !ok {
+				return nil, errors.New("Cannot create a digraph from a graph.")
+			}
+			return functorToDirectedAdjacencyList(dgs, gf().(al_digraph)), nil
+		}
+		return functorToAdjacencyList(gs.Source, gf().(al_graph)), nil
+	}
 }
 
 // Create a graph implementation in the adjacency list style from the provided GraphSpec.
 //
 // If the GraphSpec contains a GraphSource, it will be imported into the provided graph.
 // If the GraphSpec indicates a graph type that is not currently implemented, this function
-// will panic.
+// will panic; use TryG to receive an error instead.
 func G(gs GraphSpec) Graph {
-	for gp, gf := range alCreators {
-
-		// TODO satisfiability here is not so narrow
-		if gp&^gs.Props == 0 {
-			if gs.Source != nil {
-				if gs.Props&G_DIRECTED == G_DIRECTED {
-					if dgs, ok := gs.Source.(DigraphSource); ok {
-						return functorToDirectedAdjacencyList(dgs, gf().(al_digraph))
-					} else {
-						panic("Cannot create a digraph from a graph.")
-					}
-				} else {
-					return functorToAdjacencyList(gs.Source, gf().(al_graph))
-				}
-			} else {
-				return gf()
-			}
-		}
+	g, err := TryG(gs)
+	if err != nil {
+		panic(err)
 	}
+	return g
+}
 
-	panic("No graph implementation found for spec")
+// Create a graph implementation in the adjacency list style from the provided GraphSpec,
+// returning an error if the spec cannot be satisfied by any adjacency list.
+//
+// The implementation that most narrowly satisfies the spec is chosen; see Registry for
+// the details of resolution. All of al's implementations are also registered with
+// gogl's DefaultRegistry, as fallbacks; TryG always uses al's own.
+func TryG(gs GraphSpec) (Graph, error) {
+	return alRegistry.Create(gs)
 }
 
 type al_basic struct {
 	list map[Vertex]map[Vertex]struct{}
 	size int
+	vertexAttrs
 }
 
 // Helper to not have to write struct{} everywhere.
@@ -133,13 +216,40 @@ func (g *al_basic_immut) Order() int {
 	return len(g.list)
 }
 
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *al_basic_immut) VertexData(v Vertex) (data interface{}, exists bool) {
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *al_basic_immut) VertexLabel(v Vertex) (label string, exists bool) {
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *al_basic_immut) VertexWeight(v Vertex) (weight float64, exists bool) {
+	return g.vertexWeight(v)
+}
+
 type al_basic_mut struct {
 	al_basic
 	mu sync.RWMutex
+	observers
 }
 
 /* Base al_basic_mut methods */
 
+// Adds the provided vertices to the graph, announcing each that is new. Shadows
+// al_basic's implementation, which immutable graphs also use.
+func (g *al_basic_mut) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			g.al_basic.ensureVertex(vertex)
+			g.emit(VertexAdded, vertex, nil)
+		}
+	}
+}
+
 // Traverses the graph's vertices in random order, passing each vertex to the
 // provided closure.
 func (g *al_basic_mut) Vertices(f VertexStep) {
@@ -178,7 +288,155 @@ func (g *al_basic_mut) EnsureVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.ensureVertex(vertices...)
 }
+
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *al_basic_mut) VertexData(v Vertex) (data interface{}, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *al_basic_mut) VertexLabel(v Vertex) (label string, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *al_basic_mut) VertexWeight(v Vertex) (weight float64, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexWeight(v)
+}
+
+// Attaches arbitrary data to the provided vertex, replacing any existing data.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *al_basic_mut) SetVertexData(v Vertex, data interface{}) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexData(v, data)
+	}
+	return
+}
+
+// Attaches a label to the provided vertex, replacing any existing label.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *al_basic_mut) SetVertexLabel(v Vertex, label string) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexLabel(v, label)
+	}
+	return
+}
+
+// Attaches a weight to the provided vertex, replacing any existing weight.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *al_basic_mut) SetVertexWeight(v Vertex, weight float64) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexWeight(v, weight)
+	}
+	return
+}
+
+// Each implementation reports the GraphProperties it is registered under, making
+// it an encoding.PropertiesReporter; encoders can then record a graph's
+// properties without enumerating its edges to infer them.
+
+func (g *immutableDirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_DIRECTED | G_BASIC | G_SIMPLE
+}
+
+func (g *immutableUndirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_UNDIRECTED | G_BASIC | G_SIMPLE
+}
+
+func (g *immutableWeightedDirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_DIRECTED | G_WEIGHTED | G_SIMPLE
+}
+
+func (g *immutableWeightedUndirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_UNDIRECTED | G_WEIGHTED | G_SIMPLE
+}
+
+func (g *immutableLabeledDirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_DIRECTED | G_LABELED | G_SIMPLE
+}
+
+func (g *immutableLabeledUndirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_UNDIRECTED | G_LABELED | G_SIMPLE
+}
+
+func (g *immutableDataDirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_DIRECTED | G_DATA | G_SIMPLE
+}
+
+func (g *immutableDataUndirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_UNDIRECTED | G_DATA | G_SIMPLE
+}
+
+func (g *immutablePropertyDirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_DIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE
+}
+
+func (g *immutablePropertyUndirected) Properties() GraphProperties {
+	return G_IMMUTABLE | G_UNDIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE
+}
+
+func (g *mutableDirected) Properties() GraphProperties {
+	return G_MUTABLE | G_DIRECTED | G_BASIC | G_SIMPLE
+}
+
+func (g *mutableUndirected) Properties() GraphProperties {
+	return G_MUTABLE | G_UNDIRECTED | G_BASIC | G_SIMPLE
+}
+
+func (g *mutableMixed) Properties() GraphProperties {
+	return G_MUTABLE | G_DIRECTED | G_UNDIRECTED | G_BASIC | G_SIMPLE
+}
+
+func (g *weightedDirected) Properties() GraphProperties {
+	return G_MUTABLE | G_DIRECTED | G_WEIGHTED | G_SIMPLE
+}
+
+func (g *weightedUndirected) Properties() GraphProperties {
+	return G_MUTABLE | G_UNDIRECTED | G_WEIGHTED | G_SIMPLE
+}
+
+func (g *labeledDirected) Properties() GraphProperties {
+	return G_MUTABLE | G_DIRECTED | G_LABELED | G_SIMPLE
+}
+
+func (g *labeledUndirected) Properties() GraphProperties {
+	return G_MUTABLE | G_UNDIRECTED | G_LABELED | G_SIMPLE
+}
+
+func (g *dataDirected) Properties() GraphProperties {
+	return G_MUTABLE | G_DIRECTED | G_DATA | G_SIMPLE
+}
+
+func (g *dataUndirected) Properties() GraphProperties {
+	return G_MUTABLE | G_UNDIRECTED | G_DATA | G_SIMPLE
+}
+
+func (g *propertyDirected) Properties() GraphProperties {
+	return G_MUTABLE | G_DIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE
+}
+
+func (g *propertyUndirected) Properties() GraphProperties {
+	return G_MUTABLE | G_UNDIRECTED | G_WEIGHTED | G_LABELED | G_DATA | G_SIMPLE
+}
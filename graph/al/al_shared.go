@@ -10,12 +10,14 @@ type al_graph interface {
 	Graph
 	ensureVertex(...Vertex)
 	hasVertex(Vertex) bool
+	attrs() *vertexAttrs
 }
 
 type al_digraph interface {
 	Digraph
 	ensureVertex(...Vertex)
 	hasVertex(Vertex) bool
+	attrs() *vertexAttrs
 }
 
 type al_ea interface {
@@ -28,6 +30,12 @@ type al_dea interface {
 	addArcs(...Arc)
 }
 
+type al_mea interface {
+	al_graph
+	addEdges(...Edge)
+	addArcs(...Arc)
+}
+
 type al_wea interface {
 	al_graph
 	addEdges(...WeightedEdge)
@@ -58,10 +66,26 @@ type al_dpea interface {
 	addArcs(...DataArc)
 }
 
+type al_propea interface {
+	al_graph
+	addEdges(...PropertyEdge)
+}
+
+type al_dpropea interface {
+	al_digraph
+	addArcs(...PropertyArc)
+}
+
+// A source that can distinguish its arcs from its undirected edges.
+type mixedSource interface {
+	ArcEnumerator
+	UndirectedEdgeEnumerator
+}
+
 // Copies an incoming graph into any of the implemented adjacency list types.
 //
 // This encapsulates the full matrix of conversion possibilities between
-// different graph edge types, for undirected graphs.
+// different graph edge types, for undirected and mixed graphs.
 func functorToAdjacencyList(from GraphSource, to al_graph) Graph {
 	vf := func(from GraphSource, to al_graph) {
 		if Order(to) != Order(from) {
@@ -73,7 +97,34 @@ func functorToAdjacencyList(from GraphSource, to al_graph) Graph {
 		}
 	}
 
-	if g, ok := to.(al_ea); ok {
+	// Mixed graphs also satisfy al_ea, so they must be checked first.
+	if g, ok := to.(al_mea); ok {
+		if mfrom, ok := from.(mixedSource); ok {
+			mfrom.Arcs(func(arc Arc) (terminate bool) {
+				g.addArcs(arc)
+				return
+			})
+			mfrom.UndirectedEdges(func(edge Edge) (terminate bool) {
+				g.addEdges(edge)
+				return
+			})
+		} else if dfrom, ok := from.(DigraphSource); ok {
+			dfrom.Arcs(func(arc Arc) (terminate bool) {
+				g.addArcs(arc)
+				return
+			})
+		} else {
+			from.Edges(func(edge Edge) (terminate bool) {
+				if arc, ok := edge.(Arc); ok {
+					g.addArcs(arc)
+				} else {
+					g.addEdges(edge)
+				}
+				return
+			})
+		}
+		vf(from, g)
+	} else if g, ok := to.(al_ea); ok {
 		from.Edges(func(edge Edge) (terminate bool) {
 			g.addEdges(edge)
 			return
@@ -112,6 +163,16 @@ func functorToAdjacencyList(from GraphSource, to al_graph) Graph {
 			return
 		})
 		vf(from, g)
+	} else if g, ok := to.(al_propea); ok {
+		from.Edges(func(edge Edge) (terminate bool) {
+			if e, ok := edge.(PropertyEdge); ok {
+				g.addEdges(e)
+			} else {
+				g.addEdges(propsOf(edge).edge(edge.Both()))
+			}
+			return
+		})
+		vf(from, g)
 	} else if g, ok := to.(al_ea); ok {
 		from.Edges(func(edge Edge) (terminate bool) {
 			g.addEdges(edge)
@@ -122,6 +183,7 @@ func functorToAdjacencyList(from GraphSource, to al_graph) Graph {
 		panic("Target graph did not implement a recognized adjacency list internal type")
 	}
 
+	copyVertexAttributes(from, to)
 	return to.(Graph)
 }
 
@@ -176,10 +238,21 @@ func functorToDirectedAdjacencyList(from DigraphSource, to al_digraph) Digraph {
 			return
 		})
 		vf(from, g)
+	} else if g, ok := to.(al_dpropea); ok {
+		from.Arcs(func(arc Arc) (terminate bool) {
+			if e, ok := arc.(PropertyArc); ok {
+				g.addArcs(e)
+			} else {
+				g.addArcs(propsOf(arc).arc(arc.Source(), arc.Target()))
+			}
+			return
+		})
+		vf(from, g)
 	} else {
 		panic("Target graph did not implement a recognized adjacency list internal type")
 	}
 
+	copyVertexAttributes(from, to)
 	return to.(Digraph)
 }
 
@@ -217,6 +290,14 @@ func eachVertexInAdjacencyList(list interface{}, vertex Vertex, vs VertexStep) {
 				}
 			}
 		}
+	case map[Vertex]map[Vertex]edgeProps:
+		if _, exists := l[vertex]; exists {
+			for adjacent := range l[vertex] {
+				if vs(adjacent) {
+					return
+				}
+			}
+		}
 	default:
 		panic("Unrecognized adjacency list map type.")
 	}
@@ -272,6 +353,18 @@ func eachPredecessorOf(list interface{}, vertex Vertex, vs VertexStep) {
 				}
 			}
 		}
+	case map[Vertex]map[Vertex]edgeProps:
+		if _, exists := l[vertex]; exists {
+			for candidate, adjacent := range l {
+				for target := range adjacent {
+					if target == vertex {
+						if vs(candidate) {
+							return
+						}
+					}
+				}
+			}
+		}
 	default:
 		panic("Unrecognized adjacency list map type.")
 	}
@@ -2,16 +2,485 @@ package al
 
 import (
 	"testing"
+	"time"
 
-	"github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
 	"github.com/sdboyer/gogl/spec"
 )
 
 // Hook gocheck into the go test runner
-func TestHookup(t *testing.T) { gocheck.TestingT(t) }
+func TestHookup(t *testing.T) { TestingT(t) }
 
 func init() {
 	for gp := range alCreators {
 		spec.SetUpTestsFromSpec(gp, G)
 	}
 }
+
+type ImmutabilitySuite struct{}
+
+var _ = Suite(&ImmutabilitySuite{})
+
+// Immutable specs must resolve to genuinely immutable implementations, never
+// falling back to a mutable (and lock-taking) type.
+func (s *ImmutabilitySuite) TestImmutableSpecsHaveNoMutators(c *C) {
+	for gp := range alCreators {
+		if gp&G_IMMUTABLE == 0 {
+			continue
+		}
+
+		g := G(GraphSpec{Props: gp, Source: spec.GraphFixtures["2e3v"]})
+		_, ok := g.(VertexSetMutator)
+		c.Assert(ok, Equals, false)
+		c.Assert(Order(g), Equals, 3)
+		c.Assert(Size(g), Equals, 2)
+	}
+}
+
+type ResolutionSuite struct{}
+
+var _ = Suite(&ResolutionSuite{})
+
+func (s *ResolutionSuite) TestTryG(c *C) {
+	g, err := Spec().Directed().Weighted().Immutable().Using(spec.GraphFixtures["w-2e3v"]).TryCreate(TryG)
+	c.Assert(err, IsNil)
+	c.Assert(g, FitsTypeOf, &immutableWeightedDirected{})
+
+	g, err = Spec().PseudoGraph().TryCreate(TryG)
+	c.Assert(g, IsNil)
+	c.Assert(err, FitsTypeOf, &UnsatisfiableSpecError{})
+
+	g, err = Spec().Directed().Using(EdgeList{NewEdge(1, 2)}).TryCreate(TryG)
+	c.Assert(g, IsNil)
+	c.Assert(err, ErrorMatches, "Cannot create a digraph from a graph.")
+}
+
+func (s *ResolutionSuite) TestGPanicsOnUnsatisfiable(c *C) {
+	c.Assert(func() { Spec().PseudoGraph().Create(G) }, PanicMatches, "No graph implementation satisfies the spec.*")
+}
+
+func (s *ResolutionSuite) TestRegisteredWithDefault(c *C) {
+	g, err := Spec().Labeled().TryCreate(DefaultRegistry.Create)
+	c.Assert(err, IsNil)
+	c.Assert(g, FitsTypeOf, &labeledUndirected{})
+}
+
+func (s *ResolutionSuite) TestProperties(c *C) {
+	for gp, gf := range alCreators {
+		g := gf().(interface {
+			Properties() GraphProperties
+		})
+		c.Assert(g.Properties(), Equals, gp)
+	}
+}
+
+func (s *ResolutionSuite) TestMixed(c *C) {
+	g, err := TryG(Spec().Mixed())
+	c.Assert(err, IsNil)
+	c.Assert(g, FitsTypeOf, &mutableMixed{})
+
+	// Sources that don't distinguish arcs from edges by type are imported as arcs
+	g = Spec().Mixed().Using(ArcList{NewArc(1, 2)}).Create(G)
+	c.Assert(g.(MixedGraph).HasArc(NewArc(1, 2)), Equals, true)
+}
+
+func (s *ResolutionSuite) TestPropertyEdges(c *C) {
+	g, err := TryG(Spec().Weighted().Labeled())
+	c.Assert(err, IsNil)
+	c.Assert(g, FitsTypeOf, &propertyUndirected{})
+
+	g, err = TryG(Spec().Directed().PropertyEdges().Immutable())
+	c.Assert(err, IsNil)
+	c.Assert(g, FitsTypeOf, &immutablePropertyDirected{})
+
+	// A single edge type still resolves to its dedicated implementation
+	g, err = TryG(Spec().Weighted())
+	c.Assert(err, IsNil)
+	c.Assert(g, FitsTypeOf, &weightedUndirected{})
+}
+
+type TransactionSuite struct{}
+
+var _ = Suite(&TransactionSuite{})
+
+func (s *TransactionSuite) TestCommit(c *C) {
+	g := Spec().Using(spec.GraphFixtures["2e3v"]).Create(G)
+
+	tx, err := Begin(g)
+	c.Assert(err, IsNil)
+
+	tx.EnsureVertex("isolate")
+	tx.AddEdges(NewEdge("baz", "qux"), NewEdge("qux", "quark"))
+	tx.RemoveVertex("foo")
+	tx.RemoveEdges(NewEdge("quark", "qux"))
+	c.Assert(tx.Len(), Equals, 4)
+
+	// nothing happens until commit
+	c.Assert(g.HasVertex("isolate"), Equals, false)
+	c.Assert(Size(g), Equals, 2)
+
+	c.Assert(tx.Commit(), IsNil)
+	c.Assert(g.HasVertex("isolate"), Equals, true)
+	c.Assert(g.HasVertex("foo"), Equals, false)
+	c.Assert(g.HasVertex("quark"), Equals, true)
+	c.Assert(g.HasEdge(NewEdge("qux", "baz")), Equals, true)
+	c.Assert(g.HasEdge(NewEdge("qux", "quark")), Equals, false)
+	c.Assert(Size(g), Equals, 2)
+
+	c.Assert(func() { tx.Commit() }, PanicMatches, "Transaction has already been committed or rolled back.")
+	c.Assert(func() { tx.EnsureVertex(1) }, PanicMatches, "Transaction has already been committed or rolled back.")
+}
+
+func (s *TransactionSuite) TestRollback(c *C) {
+	g := Spec().Directed().Using(spec.GraphFixtures["2e3v"]).Create(G)
+
+	tx, _ := Begin(g)
+	tx.AddArcs(NewArc("baz", "foo"))
+	tx.RemoveVertex("bar")
+	tx.Rollback()
+
+	c.Assert(Order(g), Equals, 3)
+	c.Assert(Size(g), Equals, 2)
+	c.Assert(func() { tx.Rollback() }, PanicMatches, "Transaction has already been committed or rolled back.")
+}
+
+func (s *TransactionSuite) TestTypedEdges(c *C) {
+	g := Spec().Directed().Weighted().Using(spec.GraphFixtures["w-2e3v"]).Create(G).(WeightedDigraph)
+
+	tx, _ := Begin(g)
+	tx.AddArcs(NewWeightedArc(3, 4, 1.5))
+	tx.RemoveArcs(NewWeightedArc(1, 2, 5.23))
+	c.Assert(tx.Commit(), IsNil)
+	c.Assert(g.HasWeightedArc(NewWeightedArc(3, 4, 1.5)), Equals, true)
+	c.Assert(g.HasArc(NewArc(1, 2)), Equals, false)
+
+	// a single bad edge spoils the whole transaction
+	tx, _ = Begin(g)
+	tx.EnsureVertex(10)
+	tx.AddArcs(NewWeightedArc(4, 5, 1), NewArc(5, 6))
+	c.Assert(tx.Commit(), ErrorMatches, "Edge of type .* cannot be held by a graph of .*WeightedArc edges.")
+	c.Assert(g.HasVertex(10), Equals, false)
+	c.Assert(g.HasVertex(5), Equals, false)
+
+	tx, _ = Begin(g)
+	tx.AddEdges(NewWeightedEdge(4, 5, 1))
+	c.Assert(tx.Commit(), ErrorMatches, "Graph does not hold undirected edges.")
+
+	tx, _ = Begin(Spec().Create(G))
+	tx.AddArcs(NewArc(4, 5))
+	c.Assert(tx.Commit(), ErrorMatches, "Graph does not hold arcs.")
+
+	// mixed graphs hold both
+	mg := Spec().Mixed().Create(G).(MixedGraph)
+	tx, _ = Begin(mg)
+	tx.AddArcs(NewArc(1, 2))
+	tx.AddEdges(NewEdge(2, 3))
+	c.Assert(tx.Commit(), IsNil)
+	c.Assert(mg.HasArc(NewArc(1, 2)), Equals, true)
+	c.Assert(mg.HasEdge(NewEdge(3, 2)), Equals, true)
+
+	_, err := Begin(Spec().Immutable().Create(G))
+	c.Assert(err, ErrorMatches, "Transactions are only supported on al's mutable graphs.")
+}
+
+func (s *TransactionSuite) TestSnapshot(c *C) {
+	for gp := range alCreators {
+		if gp&G_MUTABLE == 0 {
+			continue
+		}
+
+		g := G(GraphSpec{Props: gp, Source: spec.GraphFixtures["va-2e3v"]})
+		snap, err := Snapshot(g)
+		c.Assert(err, IsNil)
+		c.Assert(Order(snap), Equals, 3)
+		c.Assert(Size(snap), Equals, 2)
+
+		label, _ := snap.(VertexAttributeSource).VertexLabel("foo")
+		c.Assert(label, Equals, "start")
+
+		// the snapshot is unaffected by later mutations
+		g.(VertexSetMutator).RemoveVertex("bar")
+		c.Assert(Size(g), Equals, 0)
+		c.Assert(Size(snap), Equals, 2)
+
+		if gp&G_UNDIRECTED == 0 || gp&G_DIRECTED == 0 {
+			_, ok := snap.(VertexSetMutator)
+			c.Assert(ok, Equals, false)
+		}
+	}
+
+	_, err := Snapshot(Spec().Immutable().Create(G))
+	c.Assert(err, ErrorMatches, "Snapshots are only supported on al's mutable graphs.")
+}
+
+// Snapshots taken while transactions commit see each transaction whole, or
+// not at all.
+func (s *TransactionSuite) TestSnapshotConsistency(c *C) {
+	g := Spec().Create(G)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			tx, _ := Begin(g)
+			tx.AddEdges(NewEdge(i, -1), NewEdge(i, -2))
+			tx.Commit()
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		snap, _ := Snapshot(g)
+		c.Assert(Size(snap)%2, Equals, 0)
+	}
+	<-done
+	c.Assert(Size(g), Equals, 400)
+}
+
+type EventsSuite struct{}
+
+var _ = Suite(&EventsSuite{})
+
+// Records the events delivered to a listener.
+type recorder []Event
+
+func (r *recorder) listen(e Event) {
+	*r = append(*r, e)
+}
+
+func (r recorder) kinds() (kinds []EventKind) {
+	for _, e := range r {
+		kinds = append(kinds, e.Kind)
+	}
+	return
+}
+
+func (s *EventsSuite) TestMutations(c *C) {
+	g := Spec().Create(G).(MutableGraph)
+
+	var r recorder
+	cancel := g.(Observable).Listen(r.listen)
+
+	g.EnsureVertex(1)
+	g.EnsureVertex(1)
+	c.Assert(r, DeepEquals, recorder{{Kind: VertexAdded, Vertex: 1}})
+
+	r = nil
+	g.AddEdges(NewEdge(1, 2), NewEdge(2, 1))
+	c.Assert(r, DeepEquals, recorder{
+		{Kind: VertexAdded, Vertex: 2},
+		{Kind: EdgeAdded, Edge: NewEdge(1, 2)},
+	})
+
+	r = nil
+	g.AddEdges(NewEdge(2, 3))
+	g.RemoveVertex(2)
+	c.Assert(r.kinds(), DeepEquals, []EventKind{VertexAdded, EdgeAdded, EdgeRemoved, EdgeRemoved, VertexRemoved})
+	c.Assert(r[4].Vertex, Equals, 2)
+
+	r = nil
+	g.RemoveEdges(NewEdge(1, 3))
+	g.RemoveVertex(2)
+	c.Assert(r, IsNil)
+
+	cancel()
+	g.EnsureVertex(4)
+	c.Assert(r, IsNil)
+}
+
+func (s *EventsSuite) TestRemovalsCarryProperties(c *C) {
+	g := Spec().Directed().Weighted().Create(G).(*weightedDirected)
+	g.AddArcs(NewWeightedArc(1, 2, 5), NewWeightedArc(3, 1, 7))
+
+	var r recorder
+	g.Listen(r.listen)
+
+	g.RemoveArcs(NewWeightedArc(1, 2, 0))
+	c.Assert(r, DeepEquals, recorder{{Kind: EdgeRemoved, Edge: NewWeightedArc(1, 2, 5)}})
+
+	r = nil
+	g.RemoveVertex(1)
+	c.Assert(r, DeepEquals, recorder{
+		{Kind: EdgeRemoved, Edge: NewWeightedArc(3, 1, 7)},
+		{Kind: VertexRemoved, Vertex: 1},
+	})
+}
+
+// Every mutable graph announces the edges implicitly removed with a vertex.
+func (s *EventsSuite) TestRemoveVertexAllGraphs(c *C) {
+	for gp := range alCreators {
+		if gp&G_MUTABLE == 0 {
+			continue
+		}
+
+		g := G(GraphSpec{Props: gp, Source: spec.GraphFixtures["2e3v"]})
+
+		var r recorder
+		g.(Observable).Listen(r.listen)
+		g.(VertexSetMutator).RemoveVertex("bar")
+
+		c.Assert(r.kinds(), DeepEquals, []EventKind{EdgeRemoved, EdgeRemoved, VertexRemoved})
+		for _, e := range r[:2] {
+			u, v := e.Edge.Both()
+			c.Assert(u == "bar" || v == "bar", Equals, true)
+		}
+	}
+}
+
+func (s *EventsSuite) TestTransaction(c *C) {
+	g := Spec().Directed().Create(G)
+
+	var r recorder
+	g.(Observable).Listen(r.listen)
+
+	tx, _ := Begin(g)
+	tx.AddArcs(NewArc(1, 2))
+	tx.RemoveVertex(2)
+	c.Assert(r, IsNil)
+
+	c.Assert(tx.Commit(), IsNil)
+	c.Assert(r.kinds(), DeepEquals, []EventKind{VertexAdded, VertexAdded, EdgeAdded, EdgeRemoved, VertexRemoved})
+}
+
+func (s *EventsSuite) TestSubscribe(c *C) {
+	g := Spec().Mixed().Create(G).(MutableMixedGraph)
+
+	events, cancel := g.(Observable).Subscribe(4)
+	g.AddArcs(NewArc(1, 2))
+	g.AddEdges(NewEdge(2, 1))
+
+	c.Assert(<-events, DeepEquals, Event{Kind: VertexAdded, Vertex: 1})
+	c.Assert(<-events, DeepEquals, Event{Kind: VertexAdded, Vertex: 2})
+	c.Assert(<-events, DeepEquals, Event{Kind: EdgeAdded, Edge: NewArc(1, 2)})
+
+	// a full buffer blocks mutation until the subscriber reads, or cancels
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.EnsureVertex(3, 4, 5, 6, 7)
+	}()
+
+	<-events
+	cancel()
+	<-done
+
+	for range events {
+	}
+	c.Assert(Order(g), Equals, 7)
+}
+
+// Concurrent mutations are delivered in the order they were made, so
+// replaying events reproduces the graph.
+func (s *EventsSuite) TestConcurrentOrdering(c *C) {
+	g := Spec().Create(G).(MutableGraph)
+
+	var r recorder
+	g.(Observable).Listen(r.listen)
+
+	done := make(chan struct{})
+	for w := 0; w < 4; w++ {
+		go func(w int) {
+			defer func() { done <- struct{}{} }()
+			for i := 0; i < 100; i++ {
+				g.AddEdges(NewEdge(i%10, 10+i%7+w))
+				if i%5 == 0 {
+					g.RemoveVertex(i%10, 10+i%7)
+				}
+			}
+		}(w)
+	}
+	for w := 0; w < 4; w++ {
+		<-done
+	}
+
+	replay := Spec().Create(G).(MutableGraph)
+	for _, e := range r {
+		switch e.Kind {
+		case VertexAdded:
+			c.Assert(replay.HasVertex(e.Vertex), Equals, false)
+			replay.EnsureVertex(e.Vertex)
+		case VertexRemoved:
+			_, degree := replay.DegreeOf(e.Vertex)
+			c.Assert(degree, Equals, true)
+			replay.RemoveVertex(e.Vertex)
+		case EdgeAdded:
+			u, v := e.Edge.Both()
+			c.Assert(replay.HasVertex(u), Equals, true)
+			c.Assert(replay.HasVertex(v), Equals, true)
+			replay.AddEdges(e.Edge)
+		case EdgeRemoved:
+			c.Assert(replay.HasEdge(e.Edge), Equals, true)
+			replay.RemoveEdges(e.Edge)
+		}
+	}
+
+	c.Assert(Order(replay), Equals, Order(g))
+	c.Assert(Size(replay), Equals, Size(g))
+	g.Edges(func(e Edge) (terminate bool) {
+		c.Assert(replay.HasEdge(e), Equals, true)
+		return
+	})
+}
+
+// Listeners may read the graph while another goroutine is mutating it.
+func (s *EventsSuite) TestConcurrentReadingListener(c *C) {
+	g := Spec().Create(G).(MutableGraph)
+
+	var r recorder
+	done := make(chan struct{})
+	g.(Observable).Listen(func(e Event) {
+		if e.Vertex == 1 {
+			// Give the second mutation time to take the write lock and
+			// queue its event behind this one.
+			go func() {
+				g.EnsureVertex(2)
+				close(done)
+			}()
+			time.Sleep(20 * time.Millisecond)
+		}
+		g.HasVertex(e.Vertex)
+		r.listen(e)
+	})
+
+	returned := make(chan struct{})
+	go func() {
+		g.EnsureVertex(1)
+		close(returned)
+	}()
+
+	timeout := time.After(10 * time.Second)
+	for _, ch := range []chan struct{}{returned, done} {
+		select {
+		case <-ch:
+		case <-timeout:
+			c.Fatal("Mutation deadlocked with a listener reading the graph.")
+		}
+	}
+
+	c.Assert(r.kinds(), DeepEquals, []EventKind{VertexAdded, VertexAdded})
+	c.Assert(r[1].Vertex, Equals, 2)
+}
+
+type stubGraph struct {
+	Graph
+}
+
+// Other implementations may be registered with the DefaultRegistry for
+// properties al provides, and take precedence there.
+func (s *ResolutionSuite) TestDefaultRegistryPrecedence(c *C) {
+	r := &Registry{}
+	for gp, gf := range alCreators {
+		r.RegisterFallback(gp, populator(gp, gf))
+	}
+
+	gp := GraphProperties(G_UNDIRECTED | G_BASIC | G_SIMPLE | G_MUTABLE)
+	stub := &stubGraph{}
+	r.Register(gp, func(GraphSpec) (Graph, error) { return stub, nil })
+
+	g, err := Spec().TryCreate(r.Create)
+	c.Assert(err, IsNil)
+	c.Assert(g, Equals, Graph(stub))
+
+	// al's own resolution is unaffected
+	c.Assert(Spec().Create(G), FitsTypeOf, &mutableUndirected{})
+}
@@ -12,6 +12,8 @@ type baseData struct {
 	list map[Vertex]map[Vertex]interface{}
 	size int
 	mu   sync.RWMutex
+	observers
+	vertexAttrs
 }
 
 /* baseData shared methods */
@@ -65,7 +67,7 @@ func (g *baseData) EnsureVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.ensureVertex(vertices...)
 }
@@ -77,12 +79,73 @@ func (g *baseData) ensureVertex(vertices ...Vertex) {
 		if !g.hasVertex(vertex) {
 			// TODO experiment with different lengths...possibly by analyzing existing density?
 			g.list[vertex] = make(map[Vertex]interface{}, 10)
+			g.emit(VertexAdded, vertex, nil)
 		}
 	}
 
 	return
 }
 
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *baseData) VertexData(v Vertex) (data interface{}, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *baseData) VertexLabel(v Vertex) (label string, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *baseData) VertexWeight(v Vertex) (weight float64, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexWeight(v)
+}
+
+// Attaches arbitrary data to the provided vertex, replacing any existing data.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseData) SetVertexData(v Vertex, data interface{}) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexData(v, data)
+	}
+	return
+}
+
+// Attaches a label to the provided vertex, replacing any existing label.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseData) SetVertexLabel(v Vertex, label string) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexLabel(v, label)
+	}
+	return
+}
+
+// Attaches a weight to the provided vertex, replacing any existing weight.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseData) SetVertexWeight(v Vertex, weight float64) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexWeight(v, weight)
+	}
+	return
+}
+
 /* DirectedData implementation */
 
 type dataDirected struct {
@@ -294,22 +357,32 @@ func (g *dataDirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *dataDirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
+			for target, d := range g.list[vertex] {
+				g.emit(EdgeRemoved, nil, NewDataArc(vertex, target, d))
+			}
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
 
-			for _, adjacent := range g.list {
-				if _, has := adjacent[vertex]; has {
+			for source, adjacent := range g.list {
+				if d, has := adjacent[vertex]; has {
+					g.emit(EdgeRemoved, nil, NewDataArc(source, vertex, d))
 					delete(adjacent, vertex)
 					g.size--
 				}
 			}
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds arcs to the graph.
@@ -319,7 +392,7 @@ func (g *dataDirected) AddArcs(arcs ...DataArc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addArcs(arcs...)
 }
@@ -333,6 +406,7 @@ func (g *dataDirected) addArcs(arcs ...DataArc) {
 		if _, exists := g.list[u][v]; !exists {
 			g.list[u][v] = arc.Data()
 			g.size++
+			g.emit(EdgeAdded, nil, arc)
 		}
 	}
 }
@@ -345,11 +419,17 @@ func (g *dataDirected) RemoveArcs(arcs ...DataArc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeArcs(arcs...)
+}
+
+// Unlocked implementation of RemoveArcs.
+func (g *dataDirected) removeArcs(arcs ...DataArc) {
 	for _, arc := range arcs {
 		s, t := arc.Both()
-		if _, exists := g.list[s][t]; exists {
+		if d, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewDataArc(s, t, d))
 			delete(g.list[s], t)
 			g.size--
 		}
@@ -364,7 +444,10 @@ func (g *dataDirected) Transpose() Digraph {
 	g2.list = make(map[Vertex]map[Vertex]interface{})
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if o := len(g.list); o > 0 {
+		startcap = g.size / o
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -379,6 +462,9 @@ func (g *dataDirected) Transpose() Digraph {
 		}
 	}
 
+	g2.size = g.size
+	g2.vertexAttrs = g.vertexAttrs.clone()
+
 	return g2
 }
 
@@ -493,19 +579,26 @@ func (g *dataUndirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *dataUndirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
+				g.emit(EdgeRemoved, nil, NewDataEdge(vertex, adjacent, g.list[vertex][adjacent]))
 				delete(g.list[adjacent], vertex)
 				return
 			})
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds edges to the graph.
@@ -515,7 +608,7 @@ func (g *dataUndirected) AddEdges(edges ...DataEdge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addEdges(edges...)
 }
@@ -531,6 +624,7 @@ func (g *dataUndirected) addEdges(edges ...DataEdge) {
 			g.list[u][v] = d
 			g.list[v][u] = d
 			g.size++
+			g.emit(EdgeAdded, nil, edge)
 		}
 	}
 }
@@ -543,14 +637,393 @@ func (g *dataUndirected) RemoveEdges(edges ...DataEdge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
+
+	g.removeEdges(edges...)
+}
 
+// Unlocked implementation of RemoveEdges.
+func (g *dataUndirected) removeEdges(edges ...DataEdge) {
 	for _, edge := range edges {
 		s, t := edge.Both()
-		if _, exists := g.list[s][t]; exists {
+		if d, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewDataEdge(s, t, d))
 			delete(g.list[s], t)
 			delete(g.list[t], s)
 			g.size--
 		}
 	}
 }
+
+// Lock-free base for immutable data graphs. Immutable graphs can only be
+// populated at creation time, so there is no need to guard reads.
+type baseDataImmut struct {
+	list map[Vertex]map[Vertex]interface{}
+	size int
+	vertexAttrs
+}
+
+/* baseDataImmut shared methods */
+
+// Traverses the graph's vertices in random order, passing each vertex to the
+// provided closure.
+func (g *baseDataImmut) Vertices(f VertexStep) {
+	for v := range g.list {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseDataImmut) HasVertex(vertex Vertex) bool {
+	return g.hasVertex(vertex)
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseDataImmut) hasVertex(vertex Vertex) (exists bool) {
+	_, exists = g.list[vertex]
+	return
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *baseDataImmut) Order() int {
+	return len(g.list)
+}
+
+// Returns the size (number of edges) in the graph.
+func (g *baseDataImmut) Size() int {
+	return g.size
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *baseDataImmut) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			g.list[vertex] = make(map[Vertex]interface{}, 10)
+		}
+	}
+
+	return
+}
+
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *baseDataImmut) VertexData(v Vertex) (data interface{}, exists bool) {
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *baseDataImmut) VertexLabel(v Vertex) (label string, exists bool) {
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *baseDataImmut) VertexWeight(v Vertex) (weight float64, exists bool) {
+	return g.vertexWeight(v)
+}
+
+/* immutableDataDirected implementation */
+
+type immutableDataDirected struct {
+	baseDataImmut
+}
+
+// Returns the outdegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutableDataDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Returns the indegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+//
+// Note that getting indegree is inefficient for directed adjacency lists; it requires
+// a full scan of the graph's edge set.
+func (g *immutableDataDirected) InDegreeOf(vertex Vertex) (degree int, exists bool) {
+	return inDegreeOf(g, vertex)
+}
+
+// Returns the degree of the given vertex, counting both in and out-edges.
+func (g *immutableDataDirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	indegree, exists := g.InDegreeOf(vertex)
+	outdegree, exists := g.OutDegreeOf(vertex)
+	return indegree + outdegree, exists
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutableDataDirected) IncidentTo(v Vertex, f EdgeStep) {
+	eachEdgeIncidentToDirected(g, v, f)
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutableDataDirected) AdjacentTo(start Vertex, f VertexStep) {
+	g.IncidentTo(start, func(e Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		} else {
+			return f(u)
+		}
+	})
+}
+
+// Enumerates the set of out-edges for the provided vertex.
+func (g *immutableDataDirected) ArcsFrom(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, data := range g.list[v] {
+		if f(NewDataArc(v, adjacent, data)) {
+			return
+		}
+	}
+}
+
+func (g *immutableDataDirected) SuccessorsOf(v Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, v, f)
+}
+
+// Enumerates the set of in-edges for the provided vertex.
+func (g *immutableDataDirected) ArcsTo(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for candidate, adjacent := range g.list {
+		for target, data := range adjacent {
+			if target == v {
+				if f(NewDataArc(candidate, target, data)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *immutableDataDirected) PredecessorsOf(v Vertex, f VertexStep) {
+	eachPredecessorOf(g.list, v, f)
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutableDataDirected) Edges(f EdgeStep) {
+	for source, adjacent := range g.list {
+		for target, data := range adjacent {
+			if f(NewDataEdge(source, target, data)) {
+				return
+			}
+		}
+	}
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *immutableDataDirected) Arcs(f ArcStep) {
+	for source, adjacent := range g.list {
+		for target, data := range adjacent {
+			if f(NewDataArc(source, target, data)) {
+				return
+			}
+		}
+	}
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge data.
+func (g *immutableDataDirected) HasEdge(edge Edge) bool {
+	u, v := edge.Both()
+	_, exists := g.list[u][v]
+	if !exists {
+		_, exists = g.list[v][u]
+	}
+	return exists
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *immutableDataDirected) HasArc(arc Arc) bool {
+	_, exists := g.list[arc.Source()][arc.Target()]
+	return exists
+}
+
+// Indicates whether or not the given data edge is present in the graph.
+// It will only match if the provided DataEdge has the same data as
+// the edge contained in the graph.
+func (g *immutableDataDirected) HasDataEdge(edge DataEdge) bool {
+	u, v := edge.Both()
+	if data, exists := g.list[u][v]; exists {
+		return data == edge.Data()
+	} else if data, exists = g.list[v][u]; exists {
+		return data == edge.Data()
+	}
+	return false
+}
+
+// Indicates whether or not the given data arc is present in the graph.
+// It will only match if the provided DataArc has the same data as
+// the arc contained in the graph.
+func (g *immutableDataDirected) HasDataArc(arc DataArc) bool {
+	if data, exists := g.list[arc.Source()][arc.Target()]; exists {
+		return data == arc.Data()
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutableDataDirected) Density() float64 {
+	order := g.Order()
+	return float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new arc to the graph.
+func (g *immutableDataDirected) addArcs(arcs ...DataArc) {
+	for _, arc := range arcs {
+		g.ensureVertex(arc.Source(), arc.Target())
+
+		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
+			g.list[arc.Source()][arc.Target()] = arc.Data()
+			g.size++
+		}
+	}
+}
+
+// Returns a graph with the same vertex and edge set, but with the
+// directionality of all its edges reversed.
+//
+// This implementation returns a new graph object (doubling memory use),
+// but not all implementations do so.
+func (g *immutableDataDirected) Transpose() Digraph {
+	g2 := &immutableDataDirected{}
+	g2.list = make(map[Vertex]map[Vertex]interface{})
+	g2.size = g.size
+
+	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
+	var startcap int
+	if g.Order() > 0 {
+		startcap = g.Size() / g.Order()
+	}
+
+	for source, adjacent := range g.list {
+		if !g2.hasVertex(source) {
+			g2.list[source] = make(map[Vertex]interface{}, startcap+1)
+		}
+
+		for target, data := range adjacent {
+			if !g2.hasVertex(target) {
+				g2.list[target] = make(map[Vertex]interface{}, startcap+1)
+			}
+			g2.list[target][source] = data
+		}
+	}
+
+	// Immutable graphs never change their attributes, so they can be shared
+	g2.vertexAttrs = g.vertexAttrs
+
+	return g2
+}
+
+/* immutableDataUndirected implementation */
+
+type immutableDataUndirected struct {
+	baseDataImmut
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutableDataUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutableDataUndirected) Edges(f EdgeStep) {
+	visited := set.New(set.NonThreadSafe)
+
+	var e DataEdge
+	for source, adjacent := range g.list {
+		for target, data := range adjacent {
+			e = NewDataEdge(source, target, data)
+			if !visited.Has(NewEdge(e.Both())) {
+				visited.Add(NewEdge(target, source))
+				if f(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutableDataUndirected) IncidentTo(v Vertex, f EdgeStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, data := range g.list[v] {
+		if f(NewDataEdge(v, adjacent, data)) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutableDataUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, vertex, f)
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge data.
+func (g *immutableDataUndirected) HasEdge(edge Edge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if _, exists := g.list[u][v]; exists {
+		return true
+	} else if _, exists := g.list[v][u]; exists {
+		return true
+	}
+	return false
+}
+
+// Indicates whether or not the given data edge is present in the graph.
+// It will only match if the provided DataEdge has the same data as
+// the edge contained in the graph.
+func (g *immutableDataUndirected) HasDataEdge(edge DataEdge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if data, exists := g.list[u][v]; exists {
+		return edge.Data() == data
+	} else if data, exists := g.list[v][u]; exists {
+		return edge.Data() == data
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutableDataUndirected) Density() float64 {
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new edge to the graph.
+func (g *immutableDataUndirected) addEdges(edges ...DataEdge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		g.ensureVertex(u, v)
+
+		if _, exists := g.list[u][v]; !exists {
+			d := edge.Data()
+			g.list[u][v] = d
+			g.list[v][u] = d
+			g.size++
+		}
+	}
+}
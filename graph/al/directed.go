@@ -185,25 +185,35 @@ func (g *mutableDirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *mutableDirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
+			for target := range g.list[vertex] {
+				g.emit(EdgeRemoved, nil, NewArc(vertex, target))
+			}
 			// TODO Is the expensive search good to do here and now...
 			// while read-locked?
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
 
 			// TODO consider chunking the list and parallelizing into goroutines
-			for _, adjacent := range g.list {
+			for source, adjacent := range g.list {
 				if _, has := adjacent[vertex]; has {
+					g.emit(EdgeRemoved, nil, NewArc(source, vertex))
 					delete(adjacent, vertex)
 					g.size--
 				}
 			}
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds arcs to the graph.
@@ -213,7 +223,7 @@ func (g *mutableDirected) AddArcs(arcs ...Arc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addArcs(arcs...)
 }
@@ -226,6 +236,7 @@ func (g *mutableDirected) addArcs(arcs ...Arc) {
 		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
 			g.list[arc.Source()][arc.Target()] = keyExists
 			g.size++
+			g.emit(EdgeAdded, nil, arc)
 		}
 	}
 }
@@ -238,11 +249,17 @@ func (g *mutableDirected) RemoveArcs(arcs ...Arc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
+
+	g.removeArcs(arcs...)
+}
 
+// Unlocked implementation of RemoveArcs.
+func (g *mutableDirected) removeArcs(arcs ...Arc) {
 	for _, arc := range arcs {
 		s, t := arc.Both()
 		if _, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewArc(s, t))
 			delete(g.list[s], t)
 			g.size--
 		}
@@ -262,7 +279,10 @@ func (g *mutableDirected) Transpose() Digraph {
 	g2.list = make(map[Vertex]map[Vertex]struct{})
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if o := len(g.list); o > 0 {
+		startcap = g.size / o
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -276,6 +296,9 @@ func (g *mutableDirected) Transpose() Digraph {
 		}
 	}
 
+	g2.size = g.size
+	g2.vertexAttrs = g.vertexAttrs.clone()
+
 	return g2
 }
 
@@ -431,7 +454,10 @@ func (g *immutableDirected) Transpose() Digraph {
 	g2.list = make(map[Vertex]map[Vertex]struct{})
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if o := len(g.list); o > 0 {
+		startcap = g.size / o
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -445,6 +471,9 @@ func (g *immutableDirected) Transpose() Digraph {
 		}
 	}
 
+	// Immutable graphs never change their attributes, so they can be shared
+	g2.vertexAttrs = g.vertexAttrs
+
 	return g2
 }
 
@@ -0,0 +1,231 @@
+package al
+
+import (
+	"sync"
+	"sync/atomic"
+
+	. "github.com/sdboyer/gogl"
+)
+
+// The kind of change to a graph described by an Event.
+type EventKind uint8
+
+const (
+	VertexAdded EventKind = iota
+	VertexRemoved
+	EdgeAdded
+	EdgeRemoved
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case VertexAdded:
+		return "VertexAdded"
+	case VertexRemoved:
+		return "VertexRemoved"
+	case EdgeAdded:
+		return "EdgeAdded"
+	case EdgeRemoved:
+		return "EdgeRemoved"
+	}
+	return "EventKind(?)"
+}
+
+// An Event describes a single change made to an observable graph.
+//
+// Vertex events carry the added or removed vertex. Edge events carry the
+// added or removed edge, of the type the graph holds: arcs for digraphs, and
+// weighted, labeled, data or property edges for graphs of those kinds. A
+// removed edge carries the properties it had in the graph.
+type Event struct {
+	Kind   EventKind
+	Vertex Vertex
+	Edge   Edge
+}
+
+// A Listener is called with each event emitted by an observable graph.
+type Listener func(Event)
+
+// Observable is implemented by all of al's mutable graphs, allowing derived
+// structures to be kept up to date as the graph changes.
+//
+// An event is emitted for each change that is actually made: adding a vertex
+// or edge already present emits nothing, nor does removing one that is absent.
+// Vertices implicitly added as members of a new edge are announced before the
+// edge, and edges implicitly removed along with a vertex are announced before
+// the vertex.
+//
+// Events are delivered synchronously, by the goroutine that made the change,
+// before the mutating method (or Transaction.Commit) returns. Delivery is
+// serialized: every listener and subscriber sees every event in the order
+// the changes were made, even when the graph is mutated concurrently. The
+// graph's lock is released before delivery begins, and a mutation waiting
+// for the events of earlier ones to be delivered does not hold it, so
+// listeners may read from the graph - though they may see later changes
+// whose events have not yet been delivered. Listeners must not mutate the
+// graph, nor cancel a subscription, as doing so will deadlock.
+type Observable interface {
+	// Registers a listener, to be called with each subsequent event. The
+	// returned function unregisters it; events already being delivered
+	// may still reach it.
+	Listen(f Listener) (cancel func())
+
+	// Returns a channel on which each subsequent event will be sent. The
+	// channel has the given buffer; once that is full, mutations block
+	// until the receiver catches up. The returned function unsubscribes
+	// and closes the channel.
+	Subscribe(buffer int) (events <-chan Event, cancel func())
+}
+
+// A single registered listener or channel subscriber.
+type subscriber struct {
+	f    Listener
+	ch   chan Event
+	done chan struct{}
+}
+
+func (s *subscriber) deliver(e Event) {
+	if s.f != nil {
+		s.f(e)
+		return
+	}
+
+	select {
+	case s.ch <- e:
+	case <-s.done:
+	}
+}
+
+// Embedded in each mutable graph to implement Observable. Events are queued
+// by the unlocked mutators while the graph's write lock is held, and
+// delivered by release once the mutation is complete.
+//
+// Each batch of events is numbered while the write lock is still held, and
+// batches are delivered strictly in number order; a mutation whose turn has
+// not yet come waits for it after releasing the write lock. lmu is only ever
+// held briefly, and never while delivering.
+type observers struct {
+	n       int32 // number of subscribers, read without lmu by emit
+	lmu     sync.Mutex
+	subs    []*subscriber
+	pending []Event
+	next    uint64    // number of the next batch to be queued
+	turn    uint64    // number of the batch now allowed to be delivered
+	turns   sync.Cond // signalled on lmu as turn advances
+}
+
+func (o *observers) Listen(f Listener) (cancel func()) {
+	s := &subscriber{f: f}
+	o.add(s)
+
+	return func() { o.remove(s) }
+}
+
+func (o *observers) Subscribe(buffer int) (events <-chan Event, cancel func()) {
+	s := &subscriber{ch: make(chan Event, buffer), done: make(chan struct{})}
+	o.add(s)
+
+	return s.ch, func() {
+		if o.remove(s) {
+			// Unblock any delivery waiting on the channel, then wait for
+			// every batch that may still be delivered to it before closing
+			// it. Later batches cannot, having been queued after the removal.
+			close(s.done)
+
+			o.lmu.Lock()
+			for last := o.next; o.turn < last; {
+				o.turns.Wait()
+			}
+			o.lmu.Unlock()
+			close(s.ch)
+		}
+	}
+}
+
+func (o *observers) add(s *subscriber) {
+	o.lmu.Lock()
+	defer o.lmu.Unlock()
+
+	// Any batch to wait on was queued after an add, so this suffices to
+	// prepare turns before anyone waits on it.
+	if o.turns.L == nil {
+		o.turns.L = &o.lmu
+	}
+	o.subs = append(o.subs, s)
+	atomic.AddInt32(&o.n, 1)
+}
+
+func (o *observers) remove(s *subscriber) bool {
+	o.lmu.Lock()
+	defer o.lmu.Unlock()
+
+	for k, sub := range o.subs {
+		if sub == s {
+			subs := make([]*subscriber, 0, len(o.subs)-1)
+			o.subs = append(append(subs, o.subs[:k]...), o.subs[k+1:]...)
+			atomic.AddInt32(&o.n, -1)
+			return true
+		}
+	}
+	return false
+}
+
+// Queues an event for delivery, if anyone is listening. Must be called with
+// the graph's write lock held.
+func (o *observers) emit(kind EventKind, v Vertex, e Edge) {
+	if atomic.LoadInt32(&o.n) > 0 {
+		o.pending = append(o.pending, Event{Kind: kind, Vertex: v, Edge: e})
+	}
+}
+
+// Releases the provided write lock, then delivers any events queued while it
+// was held, once those of earlier mutations have been delivered.
+func (o *observers) release(mu *sync.RWMutex) {
+	if len(o.pending) == 0 {
+		mu.Unlock()
+		return
+	}
+
+	events := o.pending
+	o.pending = nil
+
+	o.lmu.Lock()
+	batch := o.next
+	o.next++
+	o.lmu.Unlock()
+	mu.Unlock()
+
+	o.lmu.Lock()
+	for o.turn != batch {
+		o.turns.Wait()
+	}
+	subs := o.subs
+	o.lmu.Unlock()
+
+	// Pass the turn on even if a listener panics, so later mutations are
+	// not left waiting forever.
+	defer func() {
+		o.lmu.Lock()
+		o.turn++
+		o.turns.Broadcast()
+		o.lmu.Unlock()
+	}()
+
+	for _, e := range events {
+		for _, s := range subs {
+			s.deliver(e)
+		}
+	}
+}
+
+var _ Observable = &mutableDirected{}
+var _ Observable = &mutableUndirected{}
+var _ Observable = &mutableMixed{}
+var _ Observable = &weightedDirected{}
+var _ Observable = &weightedUndirected{}
+var _ Observable = &labeledDirected{}
+var _ Observable = &labeledUndirected{}
+var _ Observable = &dataDirected{}
+var _ Observable = &dataUndirected{}
+var _ Observable = &propertyDirected{}
+var _ Observable = &propertyUndirected{}
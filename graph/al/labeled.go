@@ -12,6 +12,8 @@ type baseLabeled struct {
 	list map[Vertex]map[Vertex]string
 	size int
 	mu   sync.RWMutex
+	observers
+	vertexAttrs
 }
 
 /* baseLabeled shared methods */
@@ -65,7 +67,7 @@ func (g *baseLabeled) EnsureVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.ensureVertex(vertices...)
 }
@@ -77,12 +79,73 @@ func (g *baseLabeled) ensureVertex(vertices ...Vertex) {
 		if !g.hasVertex(vertex) {
 			// TODO experiment with different lengths...possibly by analyzing existing density?
 			g.list[vertex] = make(map[Vertex]string, 10)
+			g.emit(VertexAdded, vertex, nil)
 		}
 	}
 
 	return
 }
 
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *baseLabeled) VertexData(v Vertex) (data interface{}, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *baseLabeled) VertexLabel(v Vertex) (label string, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *baseLabeled) VertexWeight(v Vertex) (weight float64, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexWeight(v)
+}
+
+// Attaches arbitrary data to the provided vertex, replacing any existing data.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseLabeled) SetVertexData(v Vertex, data interface{}) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexData(v, data)
+	}
+	return
+}
+
+// Attaches a label to the provided vertex, replacing any existing label.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseLabeled) SetVertexLabel(v Vertex, label string) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexLabel(v, label)
+	}
+	return
+}
+
+// Attaches a weight to the provided vertex, replacing any existing weight.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseLabeled) SetVertexWeight(v Vertex, weight float64) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexWeight(v, weight)
+	}
+	return
+}
+
 /* DirectedLabeled implementation */
 
 type labeledDirected struct {
@@ -294,22 +357,32 @@ func (g *labeledDirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *labeledDirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
+			for target, l := range g.list[vertex] {
+				g.emit(EdgeRemoved, nil, NewLabeledArc(vertex, target, l))
+			}
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
 
-			for _, adjacent := range g.list {
-				if _, has := adjacent[vertex]; has {
+			for source, adjacent := range g.list {
+				if l, has := adjacent[vertex]; has {
+					g.emit(EdgeRemoved, nil, NewLabeledArc(source, vertex, l))
 					delete(adjacent, vertex)
 					g.size--
 				}
 			}
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds arcs to the graph.
@@ -319,7 +392,7 @@ func (g *labeledDirected) AddArcs(arcs ...LabeledArc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addArcs(arcs...)
 }
@@ -332,6 +405,7 @@ func (g *labeledDirected) addArcs(arcs ...LabeledArc) {
 		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
 			g.list[arc.Source()][arc.Target()] = arc.Label()
 			g.size++
+			g.emit(EdgeAdded, nil, arc)
 		}
 	}
 }
@@ -344,11 +418,17 @@ func (g *labeledDirected) RemoveArcs(arcs ...LabeledArc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
+
+	g.removeArcs(arcs...)
+}
 
+// Unlocked implementation of RemoveArcs.
+func (g *labeledDirected) removeArcs(arcs ...LabeledArc) {
 	for _, arc := range arcs {
 		s, t := arc.Both()
-		if _, exists := g.list[s][t]; exists {
+		if l, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewLabeledArc(s, t, l))
 			delete(g.list[s], t)
 			g.size--
 		}
@@ -363,7 +443,10 @@ func (g *labeledDirected) Transpose() Digraph {
 	g2.list = make(map[Vertex]map[Vertex]string)
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if o := len(g.list); o > 0 {
+		startcap = g.size / o
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -378,6 +461,9 @@ func (g *labeledDirected) Transpose() Digraph {
 		}
 	}
 
+	g2.size = g.size
+	g2.vertexAttrs = g.vertexAttrs.clone()
+
 	return g2
 }
 
@@ -496,19 +582,26 @@ func (g *labeledUndirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *labeledUndirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
+				g.emit(EdgeRemoved, nil, NewLabeledEdge(vertex, adjacent, g.list[vertex][adjacent]))
 				delete(g.list[adjacent], vertex)
 				return
 			})
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds edges to the graph.
@@ -518,7 +611,7 @@ func (g *labeledUndirected) AddEdges(edges ...LabeledEdge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addEdges(edges...)
 }
@@ -534,6 +627,7 @@ func (g *labeledUndirected) addEdges(edges ...LabeledEdge) {
 			g.list[u][v] = l
 			g.list[v][u] = l
 			g.size++
+			g.emit(EdgeAdded, nil, edge)
 		}
 	}
 }
@@ -546,14 +640,393 @@ func (g *labeledUndirected) RemoveEdges(edges ...LabeledEdge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
+
+	g.removeEdges(edges...)
+}
 
+// Unlocked implementation of RemoveEdges.
+func (g *labeledUndirected) removeEdges(edges ...LabeledEdge) {
 	for _, edge := range edges {
 		s, t := edge.Both()
-		if _, exists := g.list[s][t]; exists {
+		if l, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewLabeledEdge(s, t, l))
 			delete(g.list[s], t)
 			delete(g.list[t], s)
 			g.size--
 		}
 	}
 }
+
+// Lock-free base for immutable labeled graphs. Immutable graphs can only be
+// populated at creation time, so there is no need to guard reads.
+type baseLabeledImmut struct {
+	list map[Vertex]map[Vertex]string
+	size int
+	vertexAttrs
+}
+
+/* baseLabeledImmut shared methods */
+
+// Traverses the graph's vertices in random order, passing each vertex to the
+// provided closure.
+func (g *baseLabeledImmut) Vertices(f VertexStep) {
+	for v := range g.list {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseLabeledImmut) HasVertex(vertex Vertex) bool {
+	return g.hasVertex(vertex)
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseLabeledImmut) hasVertex(vertex Vertex) (exists bool) {
+	_, exists = g.list[vertex]
+	return
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *baseLabeledImmut) Order() int {
+	return len(g.list)
+}
+
+// Returns the size (number of edges) in the graph.
+func (g *baseLabeledImmut) Size() int {
+	return g.size
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *baseLabeledImmut) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			g.list[vertex] = make(map[Vertex]string, 10)
+		}
+	}
+
+	return
+}
+
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *baseLabeledImmut) VertexData(v Vertex) (data interface{}, exists bool) {
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *baseLabeledImmut) VertexLabel(v Vertex) (label string, exists bool) {
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *baseLabeledImmut) VertexWeight(v Vertex) (weight float64, exists bool) {
+	return g.vertexWeight(v)
+}
+
+/* immutableLabeledDirected implementation */
+
+type immutableLabeledDirected struct {
+	baseLabeledImmut
+}
+
+// Returns the outdegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutableLabeledDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Returns the indegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+//
+// Note that getting indegree is inefficient for directed adjacency lists; it requires
+// a full scan of the graph's edge set.
+func (g *immutableLabeledDirected) InDegreeOf(vertex Vertex) (degree int, exists bool) {
+	return inDegreeOf(g, vertex)
+}
+
+// Returns the degree of the given vertex, counting both in and out-edges.
+func (g *immutableLabeledDirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	indegree, exists := g.InDegreeOf(vertex)
+	outdegree, exists := g.OutDegreeOf(vertex)
+	return indegree + outdegree, exists
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutableLabeledDirected) IncidentTo(v Vertex, f EdgeStep) {
+	eachEdgeIncidentToDirected(g, v, f)
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutableLabeledDirected) AdjacentTo(start Vertex, f VertexStep) {
+	g.IncidentTo(start, func(e Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		} else {
+			return f(u)
+		}
+	})
+}
+
+// Enumerates the set of out-edges for the provided vertex.
+func (g *immutableLabeledDirected) ArcsFrom(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, label := range g.list[v] {
+		if f(NewLabeledArc(v, adjacent, label)) {
+			return
+		}
+	}
+}
+
+func (g *immutableLabeledDirected) SuccessorsOf(v Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, v, f)
+}
+
+// Enumerates the set of in-edges for the provided vertex.
+func (g *immutableLabeledDirected) ArcsTo(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for candidate, adjacent := range g.list {
+		for target, label := range adjacent {
+			if target == v {
+				if f(NewLabeledArc(candidate, target, label)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *immutableLabeledDirected) PredecessorsOf(v Vertex, f VertexStep) {
+	eachPredecessorOf(g.list, v, f)
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutableLabeledDirected) Edges(f EdgeStep) {
+	for source, adjacent := range g.list {
+		for target, label := range adjacent {
+			if f(NewLabeledEdge(source, target, label)) {
+				return
+			}
+		}
+	}
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *immutableLabeledDirected) Arcs(f ArcStep) {
+	for source, adjacent := range g.list {
+		for target, label := range adjacent {
+			if f(NewLabeledArc(source, target, label)) {
+				return
+			}
+		}
+	}
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge label.
+func (g *immutableLabeledDirected) HasEdge(edge Edge) bool {
+	u, v := edge.Both()
+	_, exists := g.list[u][v]
+	if !exists {
+		_, exists = g.list[v][u]
+	}
+	return exists
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *immutableLabeledDirected) HasArc(arc Arc) bool {
+	_, exists := g.list[arc.Source()][arc.Target()]
+	return exists
+}
+
+// Indicates whether or not the given labeled edge is present in the graph.
+// It will only match if the provided LabeledEdge has the same label as
+// the edge contained in the graph.
+func (g *immutableLabeledDirected) HasLabeledEdge(edge LabeledEdge) bool {
+	u, v := edge.Both()
+	if label, exists := g.list[u][v]; exists {
+		return label == edge.Label()
+	} else if label, exists = g.list[v][u]; exists {
+		return label == edge.Label()
+	}
+	return false
+}
+
+// Indicates whether or not the given labeled arc is present in the graph.
+// It will only match if the provided LabeledArc has the same label as
+// the arc contained in the graph.
+func (g *immutableLabeledDirected) HasLabeledArc(arc LabeledArc) bool {
+	if label, exists := g.list[arc.Source()][arc.Target()]; exists {
+		return label == arc.Label()
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutableLabeledDirected) Density() float64 {
+	order := g.Order()
+	return float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new arc to the graph.
+func (g *immutableLabeledDirected) addArcs(arcs ...LabeledArc) {
+	for _, arc := range arcs {
+		g.ensureVertex(arc.Source(), arc.Target())
+
+		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
+			g.list[arc.Source()][arc.Target()] = arc.Label()
+			g.size++
+		}
+	}
+}
+
+// Returns a graph with the same vertex and edge set, but with the
+// directionality of all its edges reversed.
+//
+// This implementation returns a new graph object (doubling memory use),
+// but not all implementations do so.
+func (g *immutableLabeledDirected) Transpose() Digraph {
+	g2 := &immutableLabeledDirected{}
+	g2.list = make(map[Vertex]map[Vertex]string)
+	g2.size = g.size
+
+	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
+	var startcap int
+	if g.Order() > 0 {
+		startcap = g.Size() / g.Order()
+	}
+
+	for source, adjacent := range g.list {
+		if !g2.hasVertex(source) {
+			g2.list[source] = make(map[Vertex]string, startcap+1)
+		}
+
+		for target, label := range adjacent {
+			if !g2.hasVertex(target) {
+				g2.list[target] = make(map[Vertex]string, startcap+1)
+			}
+			g2.list[target][source] = label
+		}
+	}
+
+	// Immutable graphs never change their attributes, so they can be shared
+	g2.vertexAttrs = g.vertexAttrs
+
+	return g2
+}
+
+/* immutableLabeledUndirected implementation */
+
+type immutableLabeledUndirected struct {
+	baseLabeledImmut
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutableLabeledUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutableLabeledUndirected) Edges(f EdgeStep) {
+	visited := set.New(set.NonThreadSafe)
+
+	var e LabeledEdge
+	for source, adjacent := range g.list {
+		for target, label := range adjacent {
+			e = NewLabeledEdge(source, target, label)
+			if !visited.Has(NewEdge(e.Both())) {
+				visited.Add(NewEdge(target, source))
+				if f(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutableLabeledUndirected) IncidentTo(v Vertex, f EdgeStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, label := range g.list[v] {
+		if f(NewLabeledEdge(v, adjacent, label)) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutableLabeledUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, vertex, f)
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge label.
+func (g *immutableLabeledUndirected) HasEdge(edge Edge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if _, exists := g.list[u][v]; exists {
+		return true
+	} else if _, exists := g.list[v][u]; exists {
+		return true
+	}
+	return false
+}
+
+// Indicates whether or not the given labeled edge is present in the graph.
+// It will only match if the provided LabeledEdge has the same label as
+// the edge contained in the graph.
+func (g *immutableLabeledUndirected) HasLabeledEdge(edge LabeledEdge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if label, exists := g.list[u][v]; exists {
+		return edge.Label() == label
+	} else if label, exists := g.list[v][u]; exists {
+		return edge.Label() == label
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutableLabeledUndirected) Density() float64 {
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new edge to the graph.
+func (g *immutableLabeledUndirected) addEdges(edges ...LabeledEdge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		g.ensureVertex(u, v)
+
+		if _, exists := g.list[u][v]; !exists {
+			l := edge.Label()
+			g.list[u][v] = l
+			g.list[v][u] = l
+			g.size++
+		}
+	}
+}
@@ -0,0 +1,531 @@
+package al
+
+import (
+	"sync"
+
+	. "github.com/sdboyer/gogl"
+	"gopkg.in/fatih/set.v0"
+)
+
+// A mixed graph, holding both undirected edges and arcs.
+//
+// Arcs are kept in a directed adjacency list, and undirected edges in a
+// separate, symmetric one. Every vertex is present as a key in both lists.
+//
+// At most one kind of member may connect any given pair of vertices: if an
+// undirected edge connects u and v, adding an arc between them is a no-op, and
+// vice versa. Arcs in both directions between a pair are permitted.
+type mutableMixed struct {
+	arcs  map[Vertex]map[Vertex]struct{}
+	edges map[Vertex]map[Vertex]struct{}
+	size  int
+	mu    sync.RWMutex
+	observers
+	vertexAttrs
+}
+
+/* Unexported, unlocked helpers */
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *mutableMixed) hasVertex(vertex Vertex) (exists bool) {
+	_, exists = g.arcs[vertex]
+	return
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *mutableMixed) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			g.arcs[vertex] = make(map[Vertex]struct{}, 10)
+			g.edges[vertex] = make(map[Vertex]struct{}, 10)
+			g.emit(VertexAdded, vertex, nil)
+		}
+	}
+}
+
+// Indicates whether any arc or undirected edge connects the two vertices.
+func (g *mutableMixed) connected(u, v Vertex) bool {
+	if _, exists := g.edges[u][v]; exists {
+		return true
+	}
+	if _, exists := g.arcs[u][v]; exists {
+		return true
+	}
+	_, exists := g.arcs[v][u]
+	return exists
+}
+
+func (g *mutableMixed) arcsFrom(v Vertex, f ArcStep) (terminate bool) {
+	for adjacent := range g.arcs[v] {
+		if f(NewArc(v, adjacent)) {
+			return true
+		}
+	}
+	return
+}
+
+func (g *mutableMixed) arcsTo(v Vertex, f ArcStep) (terminate bool) {
+	for candidate, adjacent := range g.arcs {
+		if _, exists := adjacent[v]; exists {
+			if f(NewArc(candidate, v)) {
+				return true
+			}
+		}
+	}
+	return
+}
+
+func (g *mutableMixed) undirectedIncidentTo(v Vertex, f EdgeStep) (terminate bool) {
+	for adjacent := range g.edges[v] {
+		if f(NewEdge(v, adjacent)) {
+			return true
+		}
+	}
+	return
+}
+
+// Adds the provided undirected edges to the graph.
+func (g *mutableMixed) addEdges(edges ...Edge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		g.ensureVertex(u, v)
+
+		if !g.connected(u, v) {
+			g.edges[u][v] = keyExists
+			g.edges[v][u] = keyExists
+			g.size++
+			g.emit(EdgeAdded, nil, edge)
+		}
+	}
+}
+
+// Adds the provided arcs to the graph.
+func (g *mutableMixed) addArcs(arcs ...Arc) {
+	for _, arc := range arcs {
+		s, t := arc.Both()
+		g.ensureVertex(s, t)
+
+		if _, exists := g.arcs[s][t]; exists {
+			continue
+		}
+		if _, exists := g.edges[s][t]; !exists {
+			g.arcs[s][t] = keyExists
+			g.size++
+			g.emit(EdgeAdded, nil, arc)
+		}
+	}
+}
+
+/* Exported, locked methods */
+
+// Traverses the graph's vertices in random order, passing each vertex to the
+// provided closure.
+func (g *mutableMixed) Vertices(f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for v := range g.arcs {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *mutableMixed) HasVertex(vertex Vertex) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.hasVertex(vertex)
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *mutableMixed) Order() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.arcs)
+}
+
+// Returns the size (number of arcs and undirected edges) in the graph.
+func (g *mutableMixed) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.size
+}
+
+// Returns the degree of the provided vertex, counting in-arcs, out-arcs and
+// undirected edges. If the vertex is not present in the graph, the second
+// return value will be false.
+func (g *mutableMixed) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.arcs[vertex]) + len(g.edges[vertex])
+		g.arcsTo(vertex, func(a Arc) (terminate bool) {
+			degree++
+			return
+		})
+	}
+	return
+}
+
+// Traverses the set of all members of the graph, passing each to the provided
+// closure. Arcs are passed as Arcs; undirected edges are not.
+func (g *mutableMixed) Edges(f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for source := range g.arcs {
+		if g.arcsFrom(source, func(a Arc) bool { return f(a) }) {
+			return
+		}
+	}
+
+	g.undirectedEdges(f)
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *mutableMixed) Arcs(f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for source := range g.arcs {
+		if g.arcsFrom(source, f) {
+			return
+		}
+	}
+}
+
+// Traverses the set of undirected edges in the graph, passing each edge to
+// the provided closure.
+func (g *mutableMixed) UndirectedEdges(f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.undirectedEdges(f)
+}
+
+func (g *mutableMixed) undirectedEdges(f EdgeStep) {
+	visited := set.New(set.NonThreadSafe)
+
+	for source, adjacent := range g.edges {
+		for target := range adjacent {
+			e := NewEdge(source, target)
+			if !visited.Has(NewEdge(target, source)) {
+				visited.Add(e)
+				if f(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of undirected edges incident to the provided vertex.
+func (g *mutableMixed) UndirectedIncidentTo(v Vertex, f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.undirectedIncidentTo(v, f)
+}
+
+// Enumerates the set of all arcs and undirected edges incident to the provided vertex.
+func (g *mutableMixed) IncidentTo(v Vertex, f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasVertex(v) {
+		return
+	}
+
+	af := func(a Arc) bool { return f(a) }
+	if g.arcsFrom(v, af) || g.arcsTo(v, af) {
+		return
+	}
+	g.undirectedIncidentTo(v, f)
+}
+
+// Enumerates the vertices adjacent to the provided vertex, by any arc or
+// undirected edge.
+func (g *mutableMixed) AdjacentTo(start Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.IncidentTo(start, func(e Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		} else {
+			return f(u)
+		}
+	})
+}
+
+// Enumerates the set of out-arcs for the provided vertex.
+func (g *mutableMixed) ArcsFrom(v Vertex, f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.arcsFrom(v, f)
+}
+
+// Enumerates the set of in-arcs for the provided vertex.
+func (g *mutableMixed) ArcsTo(v Vertex, f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasVertex(v) {
+		return
+	}
+
+	g.arcsTo(v, f)
+}
+
+// Enumerates the vertices reachable from the provided vertex by traversing
+// either an out-arc or an undirected edge.
+func (g *mutableMixed) SuccessorsOf(v Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent := range g.arcs[v] {
+		if f(adjacent) {
+			return
+		}
+	}
+	eachVertexInAdjacencyList(g.edges, v, f)
+}
+
+// Enumerates the vertices from which the provided vertex can be reached by
+// traversing either an arc or an undirected edge.
+func (g *mutableMixed) PredecessorsOf(v Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasVertex(v) {
+		return
+	}
+
+	if g.arcsTo(v, func(a Arc) bool { return f(a.Source()) }) {
+		return
+	}
+	eachVertexInAdjacencyList(g.edges, v, f)
+}
+
+// Indicates whether or not the given edge is present in the graph, as either
+// an undirected edge or an arc in either direction.
+func (g *mutableMixed) HasEdge(edge Edge) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.connected(edge.Both())
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *mutableMixed) HasArc(arc Arc) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, exists := g.arcs[arc.Source()][arc.Target()]
+	return exists
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *mutableMixed) EnsureVertex(vertices ...Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.ensureVertex(vertices...)
+}
+
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *mutableMixed) VertexData(v Vertex) (data interface{}, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *mutableMixed) VertexLabel(v Vertex) (label string, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *mutableMixed) VertexWeight(v Vertex) (weight float64, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexWeight(v)
+}
+
+// Attaches arbitrary data to the provided vertex, replacing any existing data.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *mutableMixed) SetVertexData(v Vertex, data interface{}) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexData(v, data)
+	}
+	return
+}
+
+// Attaches a label to the provided vertex, replacing any existing label.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *mutableMixed) SetVertexLabel(v Vertex, label string) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexLabel(v, label)
+	}
+	return
+}
+
+// Attaches a weight to the provided vertex, replacing any existing weight.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *mutableMixed) SetVertexWeight(v Vertex, weight float64) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexWeight(v, weight)
+	}
+	return
+}
+
+// Removes a vertex from the graph. Also removes any arcs or edges of which
+// that vertex is a member.
+func (g *mutableMixed) RemoveVertex(vertices ...Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *mutableMixed) removeVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			continue
+		}
+
+		for target := range g.arcs[vertex] {
+			g.emit(EdgeRemoved, nil, NewArc(vertex, target))
+		}
+		g.size -= len(g.arcs[vertex])
+		delete(g.arcs, vertex)
+		for source, adjacent := range g.arcs {
+			if _, has := adjacent[vertex]; has {
+				g.emit(EdgeRemoved, nil, NewArc(source, vertex))
+				delete(adjacent, vertex)
+				g.size--
+			}
+		}
+
+		for adjacent := range g.edges[vertex] {
+			g.emit(EdgeRemoved, nil, NewEdge(vertex, adjacent))
+			delete(g.edges[adjacent], vertex)
+		}
+		g.size -= len(g.edges[vertex])
+		delete(g.edges, vertex)
+		g.removeVertexAttrs(vertex)
+		g.emit(VertexRemoved, vertex, nil)
+	}
+}
+
+// Adds undirected edges to the graph.
+func (g *mutableMixed) AddEdges(edges ...Edge) {
+	if len(edges) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.addEdges(edges...)
+}
+
+// Removes undirected edges from the graph. Arcs are unaffected, and the
+// vertex members of the removed edges are not removed.
+func (g *mutableMixed) RemoveEdges(edges ...Edge) {
+	if len(edges) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.removeEdges(edges...)
+}
+
+// Unlocked implementation of RemoveEdges.
+func (g *mutableMixed) removeEdges(edges ...Edge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		if _, exists := g.edges[u][v]; exists {
+			g.emit(EdgeRemoved, nil, NewEdge(u, v))
+			delete(g.edges[u], v)
+			delete(g.edges[v], u)
+			g.size--
+		}
+	}
+}
+
+// Adds arcs to the graph.
+func (g *mutableMixed) AddArcs(arcs ...Arc) {
+	if len(arcs) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.addArcs(arcs...)
+}
+
+// Removes arcs from the graph. Undirected edges are unaffected, and the
+// vertex members of the removed arcs are not removed.
+func (g *mutableMixed) RemoveArcs(arcs ...Arc) {
+	if len(arcs) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.removeArcs(arcs...)
+}
+
+// Unlocked implementation of RemoveArcs.
+func (g *mutableMixed) removeArcs(arcs ...Arc) {
+	for _, arc := range arcs {
+		s, t := arc.Both()
+		if _, exists := g.arcs[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewArc(s, t))
+			delete(g.arcs[s], t)
+			g.size--
+		}
+	}
+}
@@ -0,0 +1,1221 @@
+package al
+
+import (
+	"sync"
+
+	. "github.com/sdboyer/gogl"
+	"gopkg.in/fatih/set.v0"
+)
+
+// The properties carried by a single edge in a property graph.
+type edgeProps struct {
+	w float64
+	l string
+	d interface{}
+}
+
+// Extracts whichever properties the provided edge carries. Those it does not
+// carry are left at their zero value.
+func propsOf(e Edge) (p edgeProps) {
+	if we, ok := e.(WeightedEdge); ok {
+		p.w = we.Weight()
+	}
+	if le, ok := e.(LabeledEdge); ok {
+		p.l = le.Label()
+	}
+	if de, ok := e.(DataEdge); ok {
+		p.d = de.Data()
+	}
+	return
+}
+
+// Indicates whether every property the provided edge carries matches these
+// properties. Properties the edge does not carry are disregarded.
+func (p edgeProps) matches(e Edge) bool {
+	if we, ok := e.(WeightedEdge); ok && we.Weight() != p.w {
+		return false
+	}
+	if le, ok := e.(LabeledEdge); ok && le.Label() != p.l {
+		return false
+	}
+	if de, ok := e.(DataEdge); ok && de.Data() != p.d {
+		return false
+	}
+	return true
+}
+
+func (p edgeProps) edge(u, v Vertex) PropertyEdge {
+	return NewPropertyEdge(u, v, p.w, p.l, p.d)
+}
+
+func (p edgeProps) arc(u, v Vertex) PropertyArc {
+	return NewPropertyArc(u, v, p.w, p.l, p.d)
+}
+
+// This is implemented as an adjacency list, because those are simple.
+type baseProperty struct {
+	list map[Vertex]map[Vertex]edgeProps
+	size int
+	mu   sync.RWMutex
+	observers
+	vertexAttrs
+}
+
+/* baseProperty shared methods */
+
+// Traverses the graph's vertices in random order, passing each vertex to the
+// provided closure.
+func (g *baseProperty) Vertices(f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for v := range g.list {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseProperty) HasVertex(vertex Vertex) (exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	exists = g.hasVertex(vertex)
+	return
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseProperty) hasVertex(vertex Vertex) (exists bool) {
+	_, exists = g.list[vertex]
+	return
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *baseProperty) Order() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list)
+}
+
+// Returns the size (number of edges) in the graph.
+func (g *baseProperty) Size() int {
+	return g.size
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *baseProperty) EnsureVertex(vertices ...Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.ensureVertex(vertices...)
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *baseProperty) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			// TODO experiment with different lengths...possibly by analyzing existing density?
+			g.list[vertex] = make(map[Vertex]edgeProps, 10)
+			g.emit(VertexAdded, vertex, nil)
+		}
+	}
+
+	return
+}
+
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *baseProperty) VertexData(v Vertex) (data interface{}, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *baseProperty) VertexLabel(v Vertex) (label string, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *baseProperty) VertexWeight(v Vertex) (weight float64, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexWeight(v)
+}
+
+// Attaches arbitrary data to the provided vertex, replacing any existing data.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseProperty) SetVertexData(v Vertex, data interface{}) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexData(v, data)
+	}
+	return
+}
+
+// Attaches a label to the provided vertex, replacing any existing label.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseProperty) SetVertexLabel(v Vertex, label string) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexLabel(v, label)
+	}
+	return
+}
+
+// Attaches a weight to the provided vertex, replacing any existing weight.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseProperty) SetVertexWeight(v Vertex, weight float64) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexWeight(v, weight)
+	}
+	return
+}
+
+/* propertyDirected implementation */
+
+type propertyDirected struct {
+	baseProperty
+}
+
+// Returns the outdegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *propertyDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Returns the indegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+//
+// Note that getting indegree is inefficient for directed adjacency lists; it requires
+// a full scan of the graph's edge set.
+func (g *propertyDirected) InDegreeOf(vertex Vertex) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return inDegreeOf(g, vertex)
+}
+
+// Returns the degree of the given vertex, counting both in and out-edges.
+func (g *propertyDirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	indegree, exists := inDegreeOf(g, vertex)
+	outdegree, exists := g.OutDegreeOf(vertex)
+	return indegree + outdegree, exists
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *propertyDirected) IncidentTo(v Vertex, f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	eachEdgeIncidentToDirected(g, v, f)
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *propertyDirected) AdjacentTo(start Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.IncidentTo(start, func(e Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		} else {
+			return f(u)
+		}
+	})
+}
+
+// Enumerates the set of out-edges for the provided vertex.
+func (g *propertyDirected) ArcsFrom(v Vertex, f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, props := range g.list[v] {
+		if f(props.arc(v, adjacent)) {
+			return
+		}
+	}
+}
+
+func (g *propertyDirected) SuccessorsOf(v Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	eachVertexInAdjacencyList(g.list, v, f)
+}
+
+// Enumerates the set of in-edges for the provided vertex.
+func (g *propertyDirected) ArcsTo(v Vertex, f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for candidate, adjacent := range g.list {
+		for target, props := range adjacent {
+			if target == v {
+				if f(props.arc(candidate, target)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *propertyDirected) PredecessorsOf(v Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	eachPredecessorOf(g.list, v, f)
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *propertyDirected) Edges(f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for source, adjacent := range g.list {
+		for target, props := range adjacent {
+			if f(props.edge(source, target)) {
+				return
+			}
+		}
+	}
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *propertyDirected) Arcs(f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for source, adjacent := range g.list {
+		for target, props := range adjacent {
+			if f(props.arc(source, target)) {
+				return
+			}
+		}
+	}
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge properties.
+func (g *propertyDirected) HasEdge(edge Edge) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	u, v := edge.Both()
+	_, exists := g.list[u][v]
+	if !exists {
+		_, exists = g.list[v][u]
+	}
+	return exists
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *propertyDirected) HasArc(arc Arc) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, exists := g.list[arc.Source()][arc.Target()]
+	return exists
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyDirected) HasWeightedEdge(edge WeightedEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given labeled edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyDirected) HasLabeledEdge(edge LabeledEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given data edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyDirected) HasDataEdge(edge DataEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given property edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyDirected) HasPropertyEdge(edge PropertyEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given weighted arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *propertyDirected) HasWeightedArc(arc WeightedArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+// Indicates whether or not the given labeled arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *propertyDirected) HasLabeledArc(arc LabeledArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+// Indicates whether or not the given data arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *propertyDirected) HasDataArc(arc DataArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+// Indicates whether or not the given property arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *propertyDirected) HasPropertyArc(arc PropertyArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+func (g *propertyDirected) hasMatchingEdge(edge Edge) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	u, v := edge.Both()
+	if props, exists := g.list[u][v]; exists && props.matches(edge) {
+		return true
+	}
+	props, exists := g.list[v][u]
+	return exists && props.matches(edge)
+}
+
+func (g *propertyDirected) hasMatchingArc(arc Arc) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	props, exists := g.list[arc.Source()][arc.Target()]
+	return exists && props.matches(arc)
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *propertyDirected) Density() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	order := g.Order()
+	return float64(g.Size()) / float64(order*(order-1))
+}
+
+// Removes a vertex from the graph. Also removes any edges of which that
+// vertex is a member.
+func (g *propertyDirected) RemoveVertex(vertices ...Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *propertyDirected) removeVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if g.hasVertex(vertex) {
+			for target, p := range g.list[vertex] {
+				g.emit(EdgeRemoved, nil, p.arc(vertex, target))
+			}
+			g.size -= len(g.list[vertex])
+			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
+
+			for source, adjacent := range g.list {
+				if p, has := adjacent[vertex]; has {
+					g.emit(EdgeRemoved, nil, p.arc(source, vertex))
+					delete(adjacent, vertex)
+					g.size--
+				}
+			}
+			g.emit(VertexRemoved, vertex, nil)
+		}
+	}
+}
+
+// Adds arcs to the graph.
+func (g *propertyDirected) AddArcs(arcs ...PropertyArc) {
+	if len(arcs) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.addArcs(arcs...)
+}
+
+// Adds a new arc to the graph.
+func (g *propertyDirected) addArcs(arcs ...PropertyArc) {
+	for _, arc := range arcs {
+		g.ensureVertex(arc.Source(), arc.Target())
+
+		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
+			g.list[arc.Source()][arc.Target()] = propsOf(arc)
+			g.size++
+			g.emit(EdgeAdded, nil, arc)
+		}
+	}
+}
+
+// Removes arcs from the graph. This does NOT remove vertex members of the
+// removed arcs.
+func (g *propertyDirected) RemoveArcs(arcs ...PropertyArc) {
+	if len(arcs) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.removeArcs(arcs...)
+}
+
+// Unlocked implementation of RemoveArcs.
+func (g *propertyDirected) removeArcs(arcs ...PropertyArc) {
+	for _, arc := range arcs {
+		s, t := arc.Both()
+		if p, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, p.arc(s, t))
+			delete(g.list[s], t)
+			g.size--
+		}
+	}
+}
+
+func (g *propertyDirected) Transpose() Digraph {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g2 := &propertyDirected{}
+	g2.list = make(map[Vertex]map[Vertex]edgeProps)
+
+	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
+	var startcap int
+	if o := len(g.list); o > 0 {
+		startcap = g.size / o
+	}
+
+	for source, adjacent := range g.list {
+		if !g2.hasVertex(source) {
+			g2.list[source] = make(map[Vertex]edgeProps, startcap+1)
+		}
+
+		for target, props := range adjacent {
+			if !g2.hasVertex(target) {
+				g2.list[target] = make(map[Vertex]edgeProps, startcap+1)
+			}
+			g2.list[target][source] = props
+		}
+	}
+
+	g2.size = g.size
+	g2.vertexAttrs = g.vertexAttrs.clone()
+
+	return g2
+}
+
+/* propertyUndirected implementation */
+
+type propertyUndirected struct {
+	baseProperty
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *propertyUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *propertyUndirected) Edges(f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	visited := set.New(set.NonThreadSafe)
+
+	var e PropertyEdge
+	for source, adjacent := range g.list {
+		for target, props := range adjacent {
+			e = props.edge(source, target)
+			if !visited.Has(NewEdge(e.Both())) {
+				visited.Add(NewEdge(target, source))
+				if f(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *propertyUndirected) IncidentTo(v Vertex, f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, props := range g.list[v] {
+		if f(props.edge(v, adjacent)) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *propertyUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	eachVertexInAdjacencyList(g.list, vertex, f)
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge properties.
+func (g *propertyUndirected) HasEdge(edge Edge) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if _, exists := g.list[u][v]; exists {
+		return true
+	} else if _, exists := g.list[v][u]; exists {
+		return true
+	}
+	return false
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyUndirected) HasWeightedEdge(edge WeightedEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given labeled edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyUndirected) HasLabeledEdge(edge LabeledEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given data edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyUndirected) HasDataEdge(edge DataEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given property edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *propertyUndirected) HasPropertyEdge(edge PropertyEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+func (g *propertyUndirected) hasMatchingEdge(edge Edge) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if props, exists := g.list[u][v]; exists {
+		return props.matches(edge)
+	} else if props, exists := g.list[v][u]; exists {
+		return props.matches(edge)
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *propertyUndirected) Density() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+// Removes a vertex from the graph. Also removes any edges of which that
+// vertex is a member.
+func (g *propertyUndirected) RemoveVertex(vertices ...Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *propertyUndirected) removeVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if g.hasVertex(vertex) {
+			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
+				g.emit(EdgeRemoved, nil, g.list[vertex][adjacent].edge(vertex, adjacent))
+				delete(g.list[adjacent], vertex)
+				return
+			})
+			g.size -= len(g.list[vertex])
+			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
+			g.emit(VertexRemoved, vertex, nil)
+		}
+	}
+}
+
+// Adds edges to the graph.
+func (g *propertyUndirected) AddEdges(edges ...PropertyEdge) {
+	if len(edges) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.addEdges(edges...)
+}
+
+// Adds a new edge to the graph.
+func (g *propertyUndirected) addEdges(edges ...PropertyEdge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		g.ensureVertex(u, v)
+
+		if _, exists := g.list[u][v]; !exists {
+			w := propsOf(edge)
+			g.list[u][v] = w
+			g.list[v][u] = w
+			g.size++
+			g.emit(EdgeAdded, nil, edge)
+		}
+	}
+}
+
+// Removes edges from the graph. This does NOT remove vertex members of the
+// removed edges.
+func (g *propertyUndirected) RemoveEdges(edges ...PropertyEdge) {
+	if len(edges) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.release(&g.mu)
+
+	g.removeEdges(edges...)
+}
+
+// Unlocked implementation of RemoveEdges.
+func (g *propertyUndirected) removeEdges(edges ...PropertyEdge) {
+	for _, edge := range edges {
+		s, t := edge.Both()
+		if p, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, p.edge(s, t))
+			delete(g.list[s], t)
+			delete(g.list[t], s)
+			g.size--
+		}
+	}
+}
+
+// Lock-free base for immutable property graphs. Immutable graphs can only be
+// populated at creation time, so there is no need to guard reads.
+type basePropertyImmut struct {
+	list map[Vertex]map[Vertex]edgeProps
+	size int
+	vertexAttrs
+}
+
+/* basePropertyImmut shared methods */
+
+// Traverses the graph's vertices in random order, passing each vertex to the
+// provided closure.
+func (g *basePropertyImmut) Vertices(f VertexStep) {
+	for v := range g.list {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *basePropertyImmut) HasVertex(vertex Vertex) bool {
+	return g.hasVertex(vertex)
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *basePropertyImmut) hasVertex(vertex Vertex) (exists bool) {
+	_, exists = g.list[vertex]
+	return
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *basePropertyImmut) Order() int {
+	return len(g.list)
+}
+
+// Returns the size (number of edges) in the graph.
+func (g *basePropertyImmut) Size() int {
+	return g.size
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *basePropertyImmut) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			g.list[vertex] = make(map[Vertex]edgeProps, 10)
+		}
+	}
+
+	return
+}
+
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *basePropertyImmut) VertexData(v Vertex) (data interface{}, exists bool) {
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *basePropertyImmut) VertexLabel(v Vertex) (label string, exists bool) {
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *basePropertyImmut) VertexWeight(v Vertex) (weight float64, exists bool) {
+	return g.vertexWeight(v)
+}
+
+/* immutablePropertyDirected implementation */
+
+type immutablePropertyDirected struct {
+	basePropertyImmut
+}
+
+// Returns the outdegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutablePropertyDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Returns the indegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+//
+// Note that getting indegree is inefficient for directed adjacency lists; it requires
+// a full scan of the graph's edge set.
+func (g *immutablePropertyDirected) InDegreeOf(vertex Vertex) (degree int, exists bool) {
+	return inDegreeOf(g, vertex)
+}
+
+// Returns the degree of the given vertex, counting both in and out-edges.
+func (g *immutablePropertyDirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	indegree, exists := g.InDegreeOf(vertex)
+	outdegree, exists := g.OutDegreeOf(vertex)
+	return indegree + outdegree, exists
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutablePropertyDirected) IncidentTo(v Vertex, f EdgeStep) {
+	eachEdgeIncidentToDirected(g, v, f)
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutablePropertyDirected) AdjacentTo(start Vertex, f VertexStep) {
+	g.IncidentTo(start, func(e Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		} else {
+			return f(u)
+		}
+	})
+}
+
+// Enumerates the set of out-edges for the provided vertex.
+func (g *immutablePropertyDirected) ArcsFrom(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, props := range g.list[v] {
+		if f(props.arc(v, adjacent)) {
+			return
+		}
+	}
+}
+
+func (g *immutablePropertyDirected) SuccessorsOf(v Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, v, f)
+}
+
+// Enumerates the set of in-edges for the provided vertex.
+func (g *immutablePropertyDirected) ArcsTo(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for candidate, adjacent := range g.list {
+		for target, props := range adjacent {
+			if target == v {
+				if f(props.arc(candidate, target)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *immutablePropertyDirected) PredecessorsOf(v Vertex, f VertexStep) {
+	eachPredecessorOf(g.list, v, f)
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutablePropertyDirected) Edges(f EdgeStep) {
+	for source, adjacent := range g.list {
+		for target, props := range adjacent {
+			if f(props.edge(source, target)) {
+				return
+			}
+		}
+	}
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *immutablePropertyDirected) Arcs(f ArcStep) {
+	for source, adjacent := range g.list {
+		for target, props := range adjacent {
+			if f(props.arc(source, target)) {
+				return
+			}
+		}
+	}
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge properties.
+func (g *immutablePropertyDirected) HasEdge(edge Edge) bool {
+	u, v := edge.Both()
+	_, exists := g.list[u][v]
+	if !exists {
+		_, exists = g.list[v][u]
+	}
+	return exists
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *immutablePropertyDirected) HasArc(arc Arc) bool {
+	_, exists := g.list[arc.Source()][arc.Target()]
+	return exists
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyDirected) HasWeightedEdge(edge WeightedEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given labeled edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyDirected) HasLabeledEdge(edge LabeledEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given data edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyDirected) HasDataEdge(edge DataEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given property edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyDirected) HasPropertyEdge(edge PropertyEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given weighted arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *immutablePropertyDirected) HasWeightedArc(arc WeightedArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+// Indicates whether or not the given labeled arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *immutablePropertyDirected) HasLabeledArc(arc LabeledArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+// Indicates whether or not the given data arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *immutablePropertyDirected) HasDataArc(arc DataArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+// Indicates whether or not the given property arc is present in the graph.
+// It will only match if every property the provided arc carries is the same
+// as on the arc contained in the graph.
+func (g *immutablePropertyDirected) HasPropertyArc(arc PropertyArc) bool {
+	return g.hasMatchingArc(arc)
+}
+
+func (g *immutablePropertyDirected) hasMatchingEdge(edge Edge) bool {
+	u, v := edge.Both()
+	if props, exists := g.list[u][v]; exists && props.matches(edge) {
+		return true
+	}
+	props, exists := g.list[v][u]
+	return exists && props.matches(edge)
+}
+
+func (g *immutablePropertyDirected) hasMatchingArc(arc Arc) bool {
+	props, exists := g.list[arc.Source()][arc.Target()]
+	return exists && props.matches(arc)
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutablePropertyDirected) Density() float64 {
+	order := g.Order()
+	return float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new arc to the graph.
+func (g *immutablePropertyDirected) addArcs(arcs ...PropertyArc) {
+	for _, arc := range arcs {
+		g.ensureVertex(arc.Source(), arc.Target())
+
+		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
+			g.list[arc.Source()][arc.Target()] = propsOf(arc)
+			g.size++
+		}
+	}
+}
+
+// Returns a graph with the same vertex and edge set, but with the
+// directionality of all its edges reversed.
+//
+// This implementation returns a new graph object (doubling memory use),
+// but not all implementations do so.
+func (g *immutablePropertyDirected) Transpose() Digraph {
+	g2 := &immutablePropertyDirected{}
+	g2.list = make(map[Vertex]map[Vertex]edgeProps)
+	g2.size = g.size
+
+	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
+	var startcap int
+	if g.Order() > 0 {
+		startcap = g.Size() / g.Order()
+	}
+
+	for source, adjacent := range g.list {
+		if !g2.hasVertex(source) {
+			g2.list[source] = make(map[Vertex]edgeProps, startcap+1)
+		}
+
+		for target, props := range adjacent {
+			if !g2.hasVertex(target) {
+				g2.list[target] = make(map[Vertex]edgeProps, startcap+1)
+			}
+			g2.list[target][source] = props
+		}
+	}
+
+	// Immutable graphs never change their attributes, so they can be shared
+	g2.vertexAttrs = g.vertexAttrs
+
+	return g2
+}
+
+/* immutablePropertyUndirected implementation */
+
+type immutablePropertyUndirected struct {
+	basePropertyImmut
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutablePropertyUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutablePropertyUndirected) Edges(f EdgeStep) {
+	visited := set.New(set.NonThreadSafe)
+
+	var e PropertyEdge
+	for source, adjacent := range g.list {
+		for target, props := range adjacent {
+			e = props.edge(source, target)
+			if !visited.Has(NewEdge(e.Both())) {
+				visited.Add(NewEdge(target, source))
+				if f(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutablePropertyUndirected) IncidentTo(v Vertex, f EdgeStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, props := range g.list[v] {
+		if f(props.edge(v, adjacent)) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutablePropertyUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, vertex, f)
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge properties.
+func (g *immutablePropertyUndirected) HasEdge(edge Edge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if _, exists := g.list[u][v]; exists {
+		return true
+	} else if _, exists := g.list[v][u]; exists {
+		return true
+	}
+	return false
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyUndirected) HasWeightedEdge(edge WeightedEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given labeled edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyUndirected) HasLabeledEdge(edge LabeledEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given data edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyUndirected) HasDataEdge(edge DataEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+// Indicates whether or not the given property edge is present in the graph.
+// It will only match if every property the provided edge carries is the same
+// as on the edge contained in the graph.
+func (g *immutablePropertyUndirected) HasPropertyEdge(edge PropertyEdge) bool {
+	return g.hasMatchingEdge(edge)
+}
+
+func (g *immutablePropertyUndirected) hasMatchingEdge(edge Edge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if props, exists := g.list[u][v]; exists {
+		return props.matches(edge)
+	} else if props, exists := g.list[v][u]; exists {
+		return props.matches(edge)
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutablePropertyUndirected) Density() float64 {
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new edge to the graph.
+func (g *immutablePropertyUndirected) addEdges(edges ...PropertyEdge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		g.ensureVertex(u, v)
+
+		if _, exists := g.list[u][v]; !exists {
+			w := propsOf(edge)
+			g.list[u][v] = w
+			g.list[v][u] = w
+			g.size++
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package al
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	. "github.com/sdboyer/gogl"
+)
+
+// Implemented by every mutable adjacency list, giving transactions and
+// snapshots access to its lock, and to an unlocked copy of its contents.
+type transactional interface {
+	Graph
+	locker() *sync.RWMutex
+	release(*sync.RWMutex)
+	ensureVertex(...Vertex)
+	removeVertex(...Vertex)
+	snapshot() Graph
+}
+
+func (g *al_basic_mut) locker() *sync.RWMutex { return &g.mu }
+func (g *baseWeighted) locker() *sync.RWMutex { return &g.mu }
+func (g *baseLabeled) locker() *sync.RWMutex  { return &g.mu }
+func (g *baseData) locker() *sync.RWMutex     { return &g.mu }
+func (g *baseProperty) locker() *sync.RWMutex { return &g.mu }
+func (g *mutableMixed) locker() *sync.RWMutex { return &g.mu }
+
+// A Transaction batches mutations to one of al's mutable graphs, so that they
+// can be made all together, under a single acquisition of the graph's write
+// lock. Readers of the graph see either none of a transaction's mutations, or
+// all of them.
+//
+// Mutations are queued, in order, by the Transaction's methods, and are not
+// made until Commit is called. Until then, they may be abandoned by calling
+// Rollback. Edges and arcs are checked against the graph only on Commit: if
+// any cannot be held by the graph - a basic edge added to a weighted graph,
+// say, or an arc to an undirected graph - Commit makes none of the
+// transaction's mutations and returns an error.
+//
+// A Transaction is not safe for concurrent use, and may only be committed or
+// rolled back once; its methods panic if called afterwards.
+type Transaction struct {
+	g    transactional
+	ops  []txop
+	done bool
+}
+
+type txopKind int
+
+const (
+	txEnsureVertex txopKind = iota
+	txRemoveVertex
+	txAddEdges
+	txRemoveEdges
+	txAddArcs
+	txRemoveArcs
+)
+
+// A single queued mutation.
+type txop struct {
+	kind     txopKind
+	vertices []Vertex
+	edges    []Edge
+	arcs     []Arc
+}
+
+// Begins a transaction on the provided graph, which must be one of al's
+// mutable graphs.
+func Begin(g Graph) (*Transaction, error) {
+	tg, ok := g.(transactional)
+	if !ok {
+		return nil, errors.New("Transactions are only supported on al's mutable graphs.")
+	}
+
+	return &Transaction{g: tg}, nil
+}
+
+func (tx *Transaction) queue(op txop) {
+	if tx.done {
+		panic("Transaction has already been committed or rolled back.")
+	}
+	tx.ops = append(tx.ops, op)
+}
+
+// Queues the addition of the provided vertices, as by EnsureVertex.
+func (tx *Transaction) EnsureVertex(vertices ...Vertex) {
+	tx.queue(txop{kind: txEnsureVertex, vertices: vertices})
+}
+
+// Queues the removal of the provided vertices, and any edges of which they are
+// members, as by RemoveVertex.
+func (tx *Transaction) RemoveVertex(vertices ...Vertex) {
+	tx.queue(txop{kind: txRemoveVertex, vertices: vertices})
+}
+
+// Queues the addition of the provided undirected edges. Each edge must be of
+// the type the graph holds; for example, a WeightedEdge for a weighted graph.
+func (tx *Transaction) AddEdges(edges ...Edge) {
+	tx.queue(txop{kind: txAddEdges, edges: edges})
+}
+
+// Queues the removal of the provided undirected edges.
+func (tx *Transaction) RemoveEdges(edges ...Edge) {
+	tx.queue(txop{kind: txRemoveEdges, edges: edges})
+}
+
+// Queues the addition of the provided arcs. Each arc must be of the type the
+// graph holds; for example, a WeightedArc for a weighted digraph.
+func (tx *Transaction) AddArcs(arcs ...Arc) {
+	tx.queue(txop{kind: txAddArcs, arcs: arcs})
+}
+
+// Queues the removal of the provided arcs.
+func (tx *Transaction) RemoveArcs(arcs ...Arc) {
+	tx.queue(txop{kind: txRemoveArcs, arcs: arcs})
+}
+
+// Returns the number of mutations queued so far.
+func (tx *Transaction) Len() int {
+	return len(tx.ops)
+}
+
+// Abandons all queued mutations, leaving the graph untouched.
+func (tx *Transaction) Rollback() {
+	if tx.done {
+		panic("Transaction has already been committed or rolled back.")
+	}
+	tx.done = true
+	tx.ops = nil
+}
+
+// Makes all queued mutations to the graph, in the order in which they were
+// queued, under a single acquisition of the graph's write lock.
+//
+// If any queued edge or arc cannot be held by the graph, no mutations are
+// made and an error is returned. Either way, the transaction is finished.
+//
+// If the graph is observed, events for all the changes made are delivered
+// together, once the write lock has been released.
+func (tx *Transaction) Commit() error {
+	if tx.done {
+		panic("Transaction has already been committed or rolled back.")
+	}
+	tx.done = true
+
+	// Check and convert everything before taking the lock, so that a failure
+	// leaves the graph untouched.
+	steps := make([]func(), 0, len(tx.ops))
+	for _, op := range tx.ops {
+		step, err := tx.bind(op)
+		if err != nil {
+			return err
+		}
+		steps = append(steps, step)
+	}
+	tx.ops = nil
+
+	mu := tx.g.locker()
+	mu.Lock()
+	defer tx.g.release(mu)
+
+	for _, step := range steps {
+		step()
+	}
+
+	return nil
+}
+
+// Produces the function that performs a queued mutation, without locking.
+func (tx *Transaction) bind(op txop) (func(), error) {
+	g := tx.g
+	switch op.kind {
+	case txEnsureVertex:
+		return func() { g.ensureVertex(op.vertices...) }, nil
+	case txRemoveVertex:
+		return func() { g.removeVertex(op.vertices...) }, nil
+	case txAddEdges:
+		switch t := g.(type) {
+		case interface{ addEdges(...Edge) }:
+			return bindEdges(t.addEdges, op.edges)
+		case interface{ addEdges(...WeightedEdge) }:
+			return bindEdges(t.addEdges, op.edges)
+		case interface{ addEdges(...LabeledEdge) }:
+			return bindEdges(t.addEdges, op.edges)
+		case interface{ addEdges(...DataEdge) }:
+			return bindEdges(t.addEdges, op.edges)
+		case interface{ addEdges(...PropertyEdge) }:
+			return bindEdges(t.addEdges, op.edges)
+		}
+	case txRemoveEdges:
+		switch t := g.(type) {
+		case interface{ removeEdges(...Edge) }:
+			return bindEdges(t.removeEdges, op.edges)
+		case interface{ removeEdges(...WeightedEdge) }:
+			return bindEdges(t.removeEdges, op.edges)
+		case interface{ removeEdges(...LabeledEdge) }:
+			return bindEdges(t.removeEdges, op.edges)
+		case interface{ removeEdges(...DataEdge) }:
+			return bindEdges(t.removeEdges, op.edges)
+		case interface{ removeEdges(...PropertyEdge) }:
+			return bindEdges(t.removeEdges, op.edges)
+		}
+	case txAddArcs:
+		switch t := g.(type) {
+		case interface{ addArcs(...Arc) }:
+			return bindEdges(t.addArcs, op.arcs)
+		case interface{ addArcs(...WeightedArc) }:
+			return bindEdges(t.addArcs, op.arcs)
+		case interface{ addArcs(...LabeledArc) }:
+			return bindEdges(t.addArcs, op.arcs)
+		case interface{ addArcs(...DataArc) }:
+			return bindEdges(t.addArcs, op.arcs)
+		case interface{ addArcs(...PropertyArc) }:
+			return bindEdges(t.addArcs, op.arcs)
+		}
+	case txRemoveArcs:
+		switch t := g.(type) {
+		case interface{ removeArcs(...Arc) }:
+			return bindEdges(t.removeArcs, op.arcs)
+		case interface{ removeArcs(...WeightedArc) }:
+			return bindEdges(t.removeArcs, op.arcs)
+		case interface{ removeArcs(...LabeledArc) }:
+			return bindEdges(t.removeArcs, op.arcs)
+		case interface{ removeArcs(...DataArc) }:
+			return bindEdges(t.removeArcs, op.arcs)
+		case interface{ removeArcs(...PropertyArc) }:
+			return bindEdges(t.removeArcs, op.arcs)
+		}
+	}
+
+	if op.kind == txAddArcs || op.kind == txRemoveArcs {
+		return nil, errors.New("Graph does not hold arcs.")
+	}
+	return nil, errors.New("Graph does not hold undirected edges.")
+}
+
+// Converts edges to the type taken by an unlocked mutator, returning a
+// function that calls the mutator with them.
+func bindEdges[In, Out any](f func(...Out), edges []In) (func(), error) {
+	converted := make([]Out, len(edges))
+	for i, e := range edges {
+		c, ok := any(e).(Out)
+		if !ok {
+			var want Out
+			return nil, fmt.Errorf("Edge of type %T cannot be held by a graph of %T edges.", e, any(&want))
+		}
+		converted[i] = c
+	}
+
+	return func() { f(converted...) }, nil
+}
+
+// Returns a copy of the provided graph, which must be one of al's mutable
+// graphs, taken under a single acquisition of its read lock. The copy is
+// consistent - it reflects either all or none of any batch of mutations made
+// under a single write lock, such as a Transaction - and is unaffected by any
+// later mutation of the original.
+//
+// Copies are immutable adjacency lists with the same edge type as the
+// original, where such an implementation exists; copies of mixed graphs are
+// themselves mutable mixed graphs.
+func Snapshot(g Graph) (Graph, error) {
+	tg, ok := g.(transactional)
+	if !ok {
+		return nil, errors.New("Snapshots are only supported on al's mutable graphs.")
+	}
+
+	mu := tg.locker()
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return tg.snapshot(), nil
+}
+
+// Deeply copies an adjacency list.
+func copyList[P any](list map[Vertex]map[Vertex]P) map[Vertex]map[Vertex]P {
+	c := make(map[Vertex]map[Vertex]P, len(list))
+	for v, adj := range list {
+		ca := make(map[Vertex]P, len(adj))
+		for u, p := range adj {
+			ca[u] = p
+		}
+		c[v] = ca
+	}
+	return c
+}
+
+func (g *al_basic) copyBasic() al_basic {
+	return al_basic{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}
+}
+
+func (g *mutableDirected) snapshot() Graph {
+	return &immutableDirected{al_basic_immut{g.copyBasic()}}
+}
+
+func (g *mutableUndirected) snapshot() Graph {
+	return &immutableUndirected{al_basic_immut{g.copyBasic()}}
+}
+
+func (g *mutableMixed) snapshot() Graph {
+	return &mutableMixed{
+		arcs:        copyList(g.arcs),
+		edges:       copyList(g.edges),
+		size:        g.size,
+		vertexAttrs: g.vertexAttrs.clone(),
+	}
+}
+
+func (g *weightedDirected) snapshot() Graph {
+	return &immutableWeightedDirected{baseWeightedImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+func (g *weightedUndirected) snapshot() Graph {
+	return &immutableWeightedUndirected{baseWeightedImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+func (g *labeledDirected) snapshot() Graph {
+	return &immutableLabeledDirected{baseLabeledImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+func (g *labeledUndirected) snapshot() Graph {
+	return &immutableLabeledUndirected{baseLabeledImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+func (g *dataDirected) snapshot() Graph {
+	return &immutableDataDirected{baseDataImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+func (g *dataUndirected) snapshot() Graph {
+	return &immutableDataUndirected{baseDataImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+func (g *propertyDirected) snapshot() Graph {
+	return &immutablePropertyDirected{basePropertyImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+func (g *propertyUndirected) snapshot() Graph {
+	return &immutablePropertyUndirected{basePropertyImmut{list: copyList(g.list), size: g.size, vertexAttrs: g.vertexAttrs.clone()}}
+}
+
+var _ transactional = &mutableDirected{}
+var _ transactional = &mutableUndirected{}
+var _ transactional = &mutableMixed{}
+var _ transactional = &weightedDirected{}
+var _ transactional = &weightedUndirected{}
+var _ transactional = &labeledDirected{}
+var _ transactional = &labeledUndirected{}
+var _ transactional = &dataDirected{}
+var _ transactional = &dataUndirected{}
+var _ transactional = &propertyDirected{}
+var _ transactional = &propertyUndirected{}
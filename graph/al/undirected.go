@@ -99,19 +99,26 @@ func (g *mutableUndirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *mutableUndirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
+				g.emit(EdgeRemoved, nil, NewEdge(vertex, adjacent))
 				delete(g.list[adjacent], vertex)
 				return
 			})
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds edges to the graph.
@@ -121,7 +128,7 @@ func (g *mutableUndirected) AddEdges(edges ...Edge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addEdges(edges...)
 }
@@ -136,6 +143,7 @@ func (g *mutableUndirected) addEdges(edges ...Edge) {
 			g.list[u][v] = keyExists
 			g.list[v][u] = keyExists
 			g.size++
+			g.emit(EdgeAdded, nil, edge)
 		}
 	}
 }
@@ -148,14 +156,104 @@ func (g *mutableUndirected) RemoveEdges(edges ...Edge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
+
+	g.removeEdges(edges...)
+}
 
+// Unlocked implementation of RemoveEdges.
+func (g *mutableUndirected) removeEdges(edges ...Edge) {
 	for _, edge := range edges {
 		s, t := edge.Both()
 		if _, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewEdge(s, t))
 			delete(g.list[s], t)
 			delete(g.list[t], s)
 			g.size--
 		}
 	}
 }
+
+/* immutableUndirected implementation */
+
+type immutableUndirected struct {
+	al_basic_immut
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutableUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutableUndirected) Edges(f EdgeStep) {
+	visited := set.New(set.NonThreadSafe)
+
+	for source, adjacent := range g.list {
+		for target := range adjacent {
+			e := NewEdge(source, target)
+			if !visited.Has(NewEdge(target, source)) {
+				visited.Add(e)
+				if f(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutableUndirected) IncidentTo(v Vertex, f EdgeStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent := range g.list[v] {
+		if f(NewEdge(v, adjacent)) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutableUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, vertex, f)
+}
+
+// Indicates whether or not the given edge is present in the graph.
+func (g *immutableUndirected) HasEdge(edge Edge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if _, exists := g.list[u][v]; exists {
+		return true
+	} else if _, exists := g.list[v][u]; exists {
+		return true
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutableUndirected) Density() float64 {
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new edge to the graph.
+func (g *immutableUndirected) addEdges(edges ...Edge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		g.ensureVertex(u, v)
+
+		if _, exists := g.list[u][v]; !exists {
+			g.list[u][v] = keyExists
+			g.list[v][u] = keyExists
+			g.size++
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package al
+
+import (
+	. "github.com/sdboyer/gogl"
+)
+
+// Storage for the attributes attached to a graph's vertices. It is embedded
+// in every adjacency list; each map is allocated only once it is first
+// written to, so graphs that never use vertex attributes pay nothing for them.
+//
+// None of these methods take locks; that is the embedding graph's job.
+type vertexAttrs struct {
+	vdata    map[Vertex]interface{}
+	vlabels  map[Vertex]string
+	vweights map[Vertex]float64
+}
+
+func (a *vertexAttrs) attrs() *vertexAttrs {
+	return a
+}
+
+func (a *vertexAttrs) vertexData(v Vertex) (data interface{}, exists bool) {
+	data, exists = a.vdata[v]
+	return
+}
+
+func (a *vertexAttrs) vertexLabel(v Vertex) (label string, exists bool) {
+	label, exists = a.vlabels[v]
+	return
+}
+
+func (a *vertexAttrs) vertexWeight(v Vertex) (weight float64, exists bool) {
+	weight, exists = a.vweights[v]
+	return
+}
+
+func (a *vertexAttrs) setVertexData(v Vertex, data interface{}) {
+	if a.vdata == nil {
+		a.vdata = make(map[Vertex]interface{})
+	}
+	a.vdata[v] = data
+}
+
+func (a *vertexAttrs) setVertexLabel(v Vertex, label string) {
+	if a.vlabels == nil {
+		a.vlabels = make(map[Vertex]string)
+	}
+	a.vlabels[v] = label
+}
+
+func (a *vertexAttrs) setVertexWeight(v Vertex, weight float64) {
+	if a.vweights == nil {
+		a.vweights = make(map[Vertex]float64)
+	}
+	a.vweights[v] = weight
+}
+
+// Removes all attributes attached to the provided vertex.
+func (a *vertexAttrs) removeVertexAttrs(v Vertex) {
+	delete(a.vdata, v)
+	delete(a.vlabels, v)
+	delete(a.vweights, v)
+}
+
+// Returns a deep copy of the attribute maps (though not of the data values
+// themselves).
+func (a *vertexAttrs) clone() vertexAttrs {
+	var c vertexAttrs
+
+	if a.vdata != nil {
+		c.vdata = make(map[Vertex]interface{}, len(a.vdata))
+		for v, data := range a.vdata {
+			c.vdata[v] = data
+		}
+	}
+	if a.vlabels != nil {
+		c.vlabels = make(map[Vertex]string, len(a.vlabels))
+		for v, label := range a.vlabels {
+			c.vlabels[v] = label
+		}
+	}
+	if a.vweights != nil {
+		c.vweights = make(map[Vertex]float64, len(a.vweights))
+		for v, weight := range a.vweights {
+			c.vweights[v] = weight
+		}
+	}
+
+	return c
+}
+
+// Copies any vertex attributes reported by the source into the target graph,
+// for those vertices present in the target.
+func copyVertexAttributes(from GraphSource, to al_graph) {
+	src, ok := from.(VertexAttributeSource)
+	if !ok {
+		return
+	}
+
+	// Collect first, rather than querying the source from within its own
+	// enumerator, in case the source guards both with the same lock.
+	var vertices []Vertex
+	from.Vertices(func(v Vertex) (terminate bool) {
+		vertices = append(vertices, v)
+		return
+	})
+
+	a := to.attrs()
+	for _, v := range vertices {
+		if !to.hasVertex(v) {
+			continue
+		}
+
+		if data, exists := src.VertexData(v); exists {
+			a.setVertexData(v, data)
+		}
+		if label, exists := src.VertexLabel(v); exists {
+			a.setVertexLabel(v, label)
+		}
+		if weight, exists := src.VertexWeight(v); exists {
+			a.setVertexWeight(v, weight)
+		}
+	}
+}
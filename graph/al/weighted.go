@@ -12,6 +12,8 @@ type baseWeighted struct {
 	list map[Vertex]map[Vertex]float64
 	size int
 	mu   sync.RWMutex
+	observers
+	vertexAttrs
 }
 
 /* baseWeighted shared methods */
@@ -65,7 +67,7 @@ func (g *baseWeighted) EnsureVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.ensureVertex(vertices...)
 }
@@ -77,12 +79,73 @@ func (g *baseWeighted) ensureVertex(vertices ...Vertex) {
 		if !g.hasVertex(vertex) {
 			// TODO experiment with different lengths...possibly by analyzing existing density?
 			g.list[vertex] = make(map[Vertex]float64, 10)
+			g.emit(VertexAdded, vertex, nil)
 		}
 	}
 
 	return
 }
 
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *baseWeighted) VertexData(v Vertex) (data interface{}, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *baseWeighted) VertexLabel(v Vertex) (label string, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *baseWeighted) VertexWeight(v Vertex) (weight float64, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.vertexWeight(v)
+}
+
+// Attaches arbitrary data to the provided vertex, replacing any existing data.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseWeighted) SetVertexData(v Vertex, data interface{}) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexData(v, data)
+	}
+	return
+}
+
+// Attaches a label to the provided vertex, replacing any existing label.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseWeighted) SetVertexLabel(v Vertex, label string) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexLabel(v, label)
+	}
+	return
+}
+
+// Attaches a weight to the provided vertex, replacing any existing weight.
+// If the vertex is not present in the graph, this is a no-op and false is returned.
+func (g *baseWeighted) SetVertexWeight(v Vertex, weight float64) (exists bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if exists = g.hasVertex(v); exists {
+		g.setVertexWeight(v, weight)
+	}
+	return
+}
+
 /* DirectedWeighted implementation */
 
 type weightedDirected struct {
@@ -279,22 +342,32 @@ func (g *weightedDirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *weightedDirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
+			for target, w := range g.list[vertex] {
+				g.emit(EdgeRemoved, nil, NewWeightedArc(vertex, target, w))
+			}
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
 
-			for _, adjacent := range g.list {
-				if _, has := adjacent[vertex]; has {
+			for source, adjacent := range g.list {
+				if w, has := adjacent[vertex]; has {
+					g.emit(EdgeRemoved, nil, NewWeightedArc(source, vertex, w))
 					delete(adjacent, vertex)
 					g.size--
 				}
 			}
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds arcs to the graph.
@@ -304,7 +377,7 @@ func (g *weightedDirected) AddArcs(arcs ...WeightedArc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addArcs(arcs...)
 }
@@ -317,6 +390,7 @@ func (g *weightedDirected) addArcs(arcs ...WeightedArc) {
 		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
 			g.list[arc.Source()][arc.Target()] = arc.Weight()
 			g.size++
+			g.emit(EdgeAdded, nil, arc)
 		}
 	}
 }
@@ -329,11 +403,17 @@ func (g *weightedDirected) RemoveArcs(arcs ...WeightedArc) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
+
+	g.removeArcs(arcs...)
+}
 
+// Unlocked implementation of RemoveArcs.
+func (g *weightedDirected) removeArcs(arcs ...WeightedArc) {
 	for _, arc := range arcs {
 		s, t := arc.Both()
-		if _, exists := g.list[s][t]; exists {
+		if w, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewWeightedArc(s, t, w))
 			delete(g.list[s], t)
 			g.size--
 		}
@@ -348,7 +428,10 @@ func (g *weightedDirected) Transpose() Digraph {
 	g2.list = make(map[Vertex]map[Vertex]float64)
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if o := len(g.list); o > 0 {
+		startcap = g.size / o
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -363,6 +446,9 @@ func (g *weightedDirected) Transpose() Digraph {
 		}
 	}
 
+	g2.size = g.size
+	g2.vertexAttrs = g.vertexAttrs.clone()
+
 	return g2
 }
 
@@ -496,19 +582,26 @@ func (g *weightedUndirected) RemoveVertex(vertices ...Vertex) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
+	g.removeVertex(vertices...)
+}
+
+// Unlocked implementation of RemoveVertex.
+func (g *weightedUndirected) removeVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
 		if g.hasVertex(vertex) {
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
+				g.emit(EdgeRemoved, nil, NewWeightedEdge(vertex, adjacent, g.list[vertex][adjacent]))
 				delete(g.list[adjacent], vertex)
 				return
 			})
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.removeVertexAttrs(vertex)
+			g.emit(VertexRemoved, vertex, nil)
 		}
 	}
-	return
 }
 
 // Adds edges to the graph.
@@ -518,7 +611,7 @@ func (g *weightedUndirected) AddEdges(edges ...WeightedEdge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
 
 	g.addEdges(edges...)
 }
@@ -534,6 +627,7 @@ func (g *weightedUndirected) addEdges(edges ...WeightedEdge) {
 			g.list[u][v] = w
 			g.list[v][u] = w
 			g.size++
+			g.emit(EdgeAdded, nil, edge)
 		}
 	}
 }
@@ -546,14 +640,393 @@ func (g *weightedUndirected) RemoveEdges(edges ...WeightedEdge) {
 	}
 
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.release(&g.mu)
+
+	g.removeEdges(edges...)
+}
 
+// Unlocked implementation of RemoveEdges.
+func (g *weightedUndirected) removeEdges(edges ...WeightedEdge) {
 	for _, edge := range edges {
 		s, t := edge.Both()
-		if _, exists := g.list[s][t]; exists {
+		if w, exists := g.list[s][t]; exists {
+			g.emit(EdgeRemoved, nil, NewWeightedEdge(s, t, w))
 			delete(g.list[s], t)
 			delete(g.list[t], s)
 			g.size--
 		}
 	}
 }
+
+// Lock-free base for immutable weighted graphs. Immutable graphs can only be
+// populated at creation time, so there is no need to guard reads.
+type baseWeightedImmut struct {
+	list map[Vertex]map[Vertex]float64
+	size int
+	vertexAttrs
+}
+
+/* baseWeightedImmut shared methods */
+
+// Traverses the graph's vertices in random order, passing each vertex to the
+// provided closure.
+func (g *baseWeightedImmut) Vertices(f VertexStep) {
+	for v := range g.list {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseWeightedImmut) HasVertex(vertex Vertex) bool {
+	return g.hasVertex(vertex)
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *baseWeightedImmut) hasVertex(vertex Vertex) (exists bool) {
+	_, exists = g.list[vertex]
+	return
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *baseWeightedImmut) Order() int {
+	return len(g.list)
+}
+
+// Returns the size (number of edges) in the graph.
+func (g *baseWeightedImmut) Size() int {
+	return g.size
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *baseWeightedImmut) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if !g.hasVertex(vertex) {
+			g.list[vertex] = make(map[Vertex]float64, 10)
+		}
+	}
+
+	return
+}
+
+// Returns the data attached to the provided vertex, if any has been set.
+func (g *baseWeightedImmut) VertexData(v Vertex) (data interface{}, exists bool) {
+	return g.vertexData(v)
+}
+
+// Returns the label attached to the provided vertex, if one has been set.
+func (g *baseWeightedImmut) VertexLabel(v Vertex) (label string, exists bool) {
+	return g.vertexLabel(v)
+}
+
+// Returns the weight attached to the provided vertex, if one has been set.
+func (g *baseWeightedImmut) VertexWeight(v Vertex) (weight float64, exists bool) {
+	return g.vertexWeight(v)
+}
+
+/* immutableWeightedDirected implementation */
+
+type immutableWeightedDirected struct {
+	baseWeightedImmut
+}
+
+// Returns the outdegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutableWeightedDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Returns the indegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+//
+// Note that getting indegree is inefficient for directed adjacency lists; it requires
+// a full scan of the graph's edge set.
+func (g *immutableWeightedDirected) InDegreeOf(vertex Vertex) (degree int, exists bool) {
+	return inDegreeOf(g, vertex)
+}
+
+// Returns the degree of the given vertex, counting both in and out-edges.
+func (g *immutableWeightedDirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	indegree, exists := g.InDegreeOf(vertex)
+	outdegree, exists := g.OutDegreeOf(vertex)
+	return indegree + outdegree, exists
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutableWeightedDirected) IncidentTo(v Vertex, f EdgeStep) {
+	eachEdgeIncidentToDirected(g, v, f)
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutableWeightedDirected) AdjacentTo(start Vertex, f VertexStep) {
+	g.IncidentTo(start, func(e Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		} else {
+			return f(u)
+		}
+	})
+}
+
+// Enumerates the set of out-edges for the provided vertex.
+func (g *immutableWeightedDirected) ArcsFrom(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, weight := range g.list[v] {
+		if f(NewWeightedArc(v, adjacent, weight)) {
+			return
+		}
+	}
+}
+
+func (g *immutableWeightedDirected) SuccessorsOf(v Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, v, f)
+}
+
+// Enumerates the set of in-edges for the provided vertex.
+func (g *immutableWeightedDirected) ArcsTo(v Vertex, f ArcStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for candidate, adjacent := range g.list {
+		for target, weight := range adjacent {
+			if target == v {
+				if f(NewWeightedArc(candidate, target, weight)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *immutableWeightedDirected) PredecessorsOf(v Vertex, f VertexStep) {
+	eachPredecessorOf(g.list, v, f)
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutableWeightedDirected) Edges(f EdgeStep) {
+	for source, adjacent := range g.list {
+		for target, weight := range adjacent {
+			if f(NewWeightedEdge(source, target, weight)) {
+				return
+			}
+		}
+	}
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *immutableWeightedDirected) Arcs(f ArcStep) {
+	for source, adjacent := range g.list {
+		for target, weight := range adjacent {
+			if f(NewWeightedArc(source, target, weight)) {
+				return
+			}
+		}
+	}
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge weight.
+func (g *immutableWeightedDirected) HasEdge(edge Edge) bool {
+	u, v := edge.Both()
+	_, exists := g.list[u][v]
+	if !exists {
+		_, exists = g.list[v][u]
+	}
+	return exists
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *immutableWeightedDirected) HasArc(arc Arc) bool {
+	_, exists := g.list[arc.Source()][arc.Target()]
+	return exists
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if the provided WeightedEdge has the same weight as
+// the edge contained in the graph.
+func (g *immutableWeightedDirected) HasWeightedEdge(edge WeightedEdge) bool {
+	u, v := edge.Both()
+	if weight, exists := g.list[u][v]; exists {
+		return weight == edge.Weight()
+	} else if weight, exists = g.list[v][u]; exists {
+		return weight == edge.Weight()
+	}
+	return false
+}
+
+// Indicates whether or not the given weighted arc is present in the graph.
+// It will only match if the provided WeightedArc has the same weight as
+// the arc contained in the graph.
+func (g *immutableWeightedDirected) HasWeightedArc(arc WeightedArc) bool {
+	if weight, exists := g.list[arc.Source()][arc.Target()]; exists {
+		return weight == arc.Weight()
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutableWeightedDirected) Density() float64 {
+	order := g.Order()
+	return float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new arc to the graph.
+func (g *immutableWeightedDirected) addArcs(arcs ...WeightedArc) {
+	for _, arc := range arcs {
+		g.ensureVertex(arc.Source(), arc.Target())
+
+		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
+			g.list[arc.Source()][arc.Target()] = arc.Weight()
+			g.size++
+		}
+	}
+}
+
+// Returns a graph with the same vertex and edge set, but with the
+// directionality of all its edges reversed.
+//
+// This implementation returns a new graph object (doubling memory use),
+// but not all implementations do so.
+func (g *immutableWeightedDirected) Transpose() Digraph {
+	g2 := &immutableWeightedDirected{}
+	g2.list = make(map[Vertex]map[Vertex]float64)
+	g2.size = g.size
+
+	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
+	var startcap int
+	if g.Order() > 0 {
+		startcap = g.Size() / g.Order()
+	}
+
+	for source, adjacent := range g.list {
+		if !g2.hasVertex(source) {
+			g2.list[source] = make(map[Vertex]float64, startcap+1)
+		}
+
+		for target, weight := range adjacent {
+			if !g2.hasVertex(target) {
+				g2.list[target] = make(map[Vertex]float64, startcap+1)
+			}
+			g2.list[target][source] = weight
+		}
+	}
+
+	// Immutable graphs never change their attributes, so they can be shared
+	g2.vertexAttrs = g.vertexAttrs
+
+	return g2
+}
+
+/* immutableWeightedUndirected implementation */
+
+type immutableWeightedUndirected struct {
+	baseWeightedImmut
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *immutableWeightedUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	if exists = g.hasVertex(vertex); exists {
+		degree = len(g.list[vertex])
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *immutableWeightedUndirected) Edges(f EdgeStep) {
+	visited := set.New(set.NonThreadSafe)
+
+	var e WeightedEdge
+	for source, adjacent := range g.list {
+		for target, weight := range adjacent {
+			e = NewWeightedEdge(source, target, weight)
+			if !visited.Has(NewEdge(e.Both())) {
+				visited.Add(NewEdge(target, source))
+				if f(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *immutableWeightedUndirected) IncidentTo(v Vertex, f EdgeStep) {
+	if !g.hasVertex(v) {
+		return
+	}
+
+	for adjacent, weight := range g.list[v] {
+		if f(NewWeightedEdge(v, adjacent, weight)) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *immutableWeightedUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
+	eachVertexInAdjacencyList(g.list, vertex, f)
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge weight.
+func (g *immutableWeightedUndirected) HasEdge(edge Edge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if _, exists := g.list[u][v]; exists {
+		return true
+	} else if _, exists := g.list[v][u]; exists {
+		return true
+	}
+	return false
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if the provided WeightedEdge has the same weight as
+// the edge contained in the graph.
+func (g *immutableWeightedUndirected) HasWeightedEdge(edge WeightedEdge) bool {
+	// Spread it into two expressions to avoid evaluating the second if possible
+	u, v := edge.Both()
+	if weight, exists := g.list[u][v]; exists {
+		return edge.Weight() == weight
+	} else if weight, exists := g.list[v][u]; exists {
+		return edge.Weight() == weight
+	}
+	return false
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *immutableWeightedUndirected) Density() float64 {
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+// Adds a new edge to the graph.
+func (g *immutableWeightedUndirected) addEdges(edges ...WeightedEdge) {
+	for _, edge := range edges {
+		u, v := edge.Both()
+		g.ensureVertex(u, v)
+
+		if _, exists := g.list[u][v]; !exists {
+			w := edge.Weight()
+			g.list[u][v] = w
+			g.list[v][u] = w
+			g.size++
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package journal
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// Entries are written as JSON, one object per line, so that a saved journal
+// can be appended to:
+//
+//	{"op":"EnsureVertex","vertices":[1,2]}
+//	{"op":"AddArcs","edges":[{"source":1,"target":2,"weight":5.23}]}
+//
+// Edge weights, labels and data are written as for node-link JSON links,
+// except that data is written even when nil - as "data":null - so that the
+// kind of each edge survives the round trip.
+type record struct {
+	Op       string               `json:"op"`
+	Vertices []stdjson.RawMessage `json:"vertices,omitempty"`
+	Edges    []link               `json:"edges,omitempty"`
+}
+
+type link struct {
+	Source stdjson.RawMessage `json:"source"`
+	Target stdjson.RawMessage `json:"target"`
+	Label  *string            `json:"label,omitempty"`
+	Weight *float64           `json:"weight,omitempty"`
+	Data   stdjson.RawMessage `json:"data,omitempty"`
+}
+
+// Returns the encoding of the provided entries. Vertices must be encodable by
+// encoding/json, and are expected to be strings, numbers or booleans.
+func Marshal(entries []Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := stdjson.NewEncoder(&buf)
+
+	for _, e := range entries {
+		r := record{Op: e.Op.String()}
+		for _, v := range e.Vertices {
+			id, err := stdjson.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			r.Vertices = append(r.Vertices, id)
+		}
+
+		for _, edge := range e.Edges {
+			u, v := edge.Both()
+			var l link
+			var err error
+			if l.Source, err = stdjson.Marshal(u); err != nil {
+				return nil, err
+			}
+			if l.Target, err = stdjson.Marshal(v); err != nil {
+				return nil, err
+			}
+
+			a := encoding.AttrsOf(edge)
+			if a.Props&gogl.G_LABELED != 0 {
+				l.Label = &a.Label
+			}
+			if a.Props&gogl.G_WEIGHTED != 0 {
+				l.Weight = &a.Weight
+			}
+			if a.Props&gogl.G_DATA != 0 {
+				if l.Data, err = stdjson.Marshal(a.Data); err != nil {
+					return nil, err
+				}
+			}
+			r.Edges = append(r.Edges, l)
+		}
+
+		if err := enc.Encode(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decodes entries encoded by Marshal. Numeric vertices are decoded as ints
+// where possible, and float64s otherwise.
+func Unmarshal(data []byte) ([]Entry, error) {
+	var entries []Entry
+
+	d := stdjson.NewDecoder(bytes.NewReader(data))
+	for {
+		var r record
+		if err := d.Decode(&r); err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		e, err := r.entry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+}
+
+func (r record) entry() (e Entry, err error) {
+	var known bool
+	for op, name := range opNames {
+		if name == r.Op {
+			e.Op, known = Op(op), true
+		}
+	}
+	if !known {
+		return e, fmt.Errorf("Unknown journal operation %q.", r.Op)
+	}
+
+	for _, id := range r.Vertices {
+		v, err := decodeVertex(id)
+		if err != nil {
+			return e, err
+		}
+		e.Vertices = append(e.Vertices, v)
+	}
+
+	for _, l := range r.Edges {
+		u, err := decodeVertex(l.Source)
+		if err != nil {
+			return e, err
+		}
+		v, err := decodeVertex(l.Target)
+		if err != nil {
+			return e, err
+		}
+
+		var a encoding.Attrs
+		if l.Label != nil {
+			a.SetLabel(*l.Label)
+		}
+		if l.Weight != nil {
+			a.SetWeight(*l.Weight)
+		}
+		if len(l.Data) != 0 {
+			var data interface{}
+			if err := stdjson.Unmarshal(l.Data, &data); err != nil {
+				return e, err
+			}
+			a.SetData(data)
+		}
+
+		if e.Op == AddArcs || e.Op == RemoveArcs {
+			e.Edges = append(e.Edges, a.Arc(u, v))
+		} else {
+			e.Edges = append(e.Edges, a.Edge(u, v))
+		}
+	}
+
+	return e, nil
+}
+
+func decodeVertex(id stdjson.RawMessage) (gogl.Vertex, error) {
+	if len(id) == 0 {
+		return nil, errors.New("Missing vertex.")
+	}
+
+	var v interface{}
+	d := stdjson.NewDecoder(bytes.NewReader(id))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	switch v := v.(type) {
+	case string, bool:
+		return v, nil
+	case stdjson.Number:
+		if i, err := strconv.Atoi(v.String()); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	}
+
+	return nil, fmt.Errorf("Cannot use %s as a vertex; vertices must be strings, numbers or booleans.", id)
+}
@@ -0,0 +1,427 @@
+// Records the mutations made to a graph, so that they can be undone and redone,
+// saved, and replayed onto a fresh graph.
+//
+// A Journal wraps a graph implementing VertexSetMutator and one or both of the
+// edge or arc mutator interfaces - basic, weighted, labeled, data or property.
+// Its mutating methods have the signatures of the basic Mutable*Graph
+// methods, and pass each edge or arc on to the wrapped graph's own mutator;
+// a WeightedArc to a weighted digraph, for example.
+//
+// For each call, the Journal records both the call itself, as an Entry, and
+// the changes it actually made to the graph: which vertices and edges were
+// new, and which were removed - including the edges removed along with a
+// vertex, and the properties and vertex attributes they carried. Undoing a
+// step reverses exactly those changes, so the graph returns to the state it
+// was in before the call. Entries, on the other hand, are what is saved and
+// replayed.
+package journal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sdboyer/gogl"
+)
+
+// The mutating method recorded by an Entry.
+type Op uint8
+
+const (
+	EnsureVertex Op = iota
+	RemoveVertex
+	AddEdges
+	RemoveEdges
+	AddArcs
+	RemoveArcs
+)
+
+var opNames = [...]string{"EnsureVertex", "RemoveVertex", "AddEdges", "RemoveEdges", "AddArcs", "RemoveArcs"}
+
+func (o Op) String() string {
+	if int(o) < len(opNames) {
+		return opNames[o]
+	}
+	return fmt.Sprintf("Op(%d)", o)
+}
+
+// An Entry records a single call to one of a Journal's mutating methods.
+type Entry struct {
+	Op       Op
+	Vertices []gogl.Vertex // The arguments to EnsureVertex or RemoveVertex
+	Edges    []gogl.Edge   // The arguments to the others; arcs, for AddArcs and RemoveArcs
+}
+
+// A Journal records the mutations made through it to a graph, and can undo
+// and redo them.
+//
+// The graph must only be mutated through the Journal while it is in use;
+// other mutations will not be undone, and may leave recorded changes
+// impossible to reverse faithfully. A Journal is not safe for concurrent use.
+type Journal struct {
+	g       gogl.Graph
+	vs      gogl.VertexSetMutator
+	edges   *mutator // nil if the graph holds no undirected edges
+	arcs    *mutator // nil if the graph holds no arcs
+	steps   []step
+	applied int // steps[applied:] have been undone, and may be redone
+}
+
+// A recorded call, and the changes it made.
+type step struct {
+	entry   Entry
+	changes []change
+}
+
+// A single vertex or edge added to, or removed from, the graph.
+type change struct {
+	added bool
+	v     gogl.Vertex  // Set for vertex changes
+	attrs *vertexAttrs // Set for removed vertices that had attributes
+	e     gogl.Edge    // Set for edge changes, with the properties the graph held
+	arc   bool
+}
+
+// The attributes of a removed vertex.
+type vertexAttrs struct {
+	data                         interface{}
+	label                        string
+	weight                       float64
+	hasData, hasLabel, hasWeight bool
+}
+
+// Adds and removes single edges of the type held by a graph.
+type mutator struct {
+	holds       func(gogl.Edge) bool
+	want        string
+	add, remove func(gogl.Edge)
+}
+
+func bind[E gogl.Edge](add, remove func(...E)) *mutator {
+	return &mutator{
+		holds:  func(e gogl.Edge) bool { _, ok := e.(E); return ok },
+		want:   fmt.Sprintf("%T", (*E)(nil))[1:],
+		add:    func(e gogl.Edge) { add(e.(E)) },
+		remove: func(e gogl.Edge) { remove(e.(E)) },
+	}
+}
+
+func edgeMutator(g gogl.Graph) *mutator {
+	switch m := g.(type) {
+	case gogl.EdgeSetMutator:
+		return bind(m.AddEdges, m.RemoveEdges)
+	case gogl.WeightedEdgeSetMutator:
+		return bind(m.AddEdges, m.RemoveEdges)
+	case gogl.LabeledEdgeSetMutator:
+		return bind(m.AddEdges, m.RemoveEdges)
+	case gogl.DataEdgeSetMutator:
+		return bind(m.AddEdges, m.RemoveEdges)
+	case gogl.PropertyEdgeSetMutator:
+		return bind(m.AddEdges, m.RemoveEdges)
+	}
+	return nil
+}
+
+func arcMutator(g gogl.Graph) *mutator {
+	switch m := g.(type) {
+	case gogl.ArcSetMutator:
+		return bind(m.AddArcs, m.RemoveArcs)
+	case gogl.WeightedArcSetMutator:
+		return bind(m.AddArcs, m.RemoveArcs)
+	case gogl.LabeledArcSetMutator:
+		return bind(m.AddArcs, m.RemoveArcs)
+	case gogl.DataArcSetMutator:
+		return bind(m.AddArcs, m.RemoveArcs)
+	case gogl.PropertyArcSetMutator:
+		return bind(m.AddArcs, m.RemoveArcs)
+	}
+	return nil
+}
+
+// Creates a Journal recording mutations to the provided graph, which must be
+// a VertexSetMutator.
+func New(g gogl.Graph) (*Journal, error) {
+	vs, ok := g.(gogl.VertexSetMutator)
+	if !ok {
+		return nil, errors.New("Journals can only be kept for mutable graphs.")
+	}
+
+	return &Journal{g: g, vs: vs, edges: edgeMutator(g), arcs: arcMutator(g)}, nil
+}
+
+// Creates a graph from the provided spec, and replays the provided entries
+// onto it, returning a Journal holding them as its history.
+//
+// An error is returned if the graph is not mutable, or cannot hold the edges
+// or arcs in one of the entries.
+func Replay(spec gogl.GraphSpec, f func(gogl.GraphSpec) gogl.Graph, entries []Entry) (*Journal, error) {
+	j, err := New(spec.Create(f))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if err := j.do(e); err != nil {
+			return nil, err
+		}
+	}
+
+	return j, nil
+}
+
+// Returns the graph the Journal is recording.
+func (j *Journal) Graph() gogl.Graph {
+	return j.g
+}
+
+// Returns the entries recorded for the calls that have been made, and not
+// undone, in the order they were made.
+func (j *Journal) Entries() []Entry {
+	entries := make([]Entry, j.applied)
+	for k, s := range j.steps[:j.applied] {
+		entries[k] = s.entry
+	}
+	return entries
+}
+
+// Returns the number of calls that may be undone.
+func (j *Journal) Len() int {
+	return j.applied
+}
+
+// Ensures the provided vertices are present in the graph.
+func (j *Journal) EnsureVertex(vertices ...gogl.Vertex) {
+	j.must(Entry{Op: EnsureVertex, Vertices: vertices})
+}
+
+// Removes the provided vertices, and any edges of which they are members,
+// from the graph.
+func (j *Journal) RemoveVertex(vertices ...gogl.Vertex) {
+	j.must(Entry{Op: RemoveVertex, Vertices: vertices})
+}
+
+// Adds the provided undirected edges to the graph. Each must be of the type
+// the graph holds; panics if not.
+func (j *Journal) AddEdges(edges ...gogl.Edge) {
+	j.must(Entry{Op: AddEdges, Edges: edges})
+}
+
+// Removes the provided undirected edges from the graph. Each must be of the
+// type the graph holds; panics if not.
+func (j *Journal) RemoveEdges(edges ...gogl.Edge) {
+	j.must(Entry{Op: RemoveEdges, Edges: edges})
+}
+
+// Adds the provided arcs to the graph. Each must be of the type the graph
+// holds; panics if not.
+func (j *Journal) AddArcs(arcs ...gogl.Arc) {
+	j.must(Entry{Op: AddArcs, Edges: edgesOf(arcs)})
+}
+
+// Removes the provided arcs from the graph. Each must be of the type the graph
+// holds; panics if not.
+func (j *Journal) RemoveArcs(arcs ...gogl.Arc) {
+	j.must(Entry{Op: RemoveArcs, Edges: edgesOf(arcs)})
+}
+
+func edgesOf(arcs []gogl.Arc) []gogl.Edge {
+	edges := make([]gogl.Edge, len(arcs))
+	for k, a := range arcs {
+		edges[k] = a
+	}
+	return edges
+}
+
+// Reverses the changes made by the most recent call not yet undone. Returns
+// false if there is none.
+func (j *Journal) Undo() bool {
+	if j.applied == 0 {
+		return false
+	}
+
+	j.applied--
+	changes := j.steps[j.applied].changes
+	for k := len(changes) - 1; k >= 0; k-- {
+		j.apply(changes[k], true)
+	}
+	return true
+}
+
+// Remakes the changes reversed by the most recent Undo. Returns false if there
+// is nothing to redo; any call to a mutating method discards whatever has
+// been undone.
+func (j *Journal) Redo() bool {
+	if j.applied == len(j.steps) {
+		return false
+	}
+
+	for _, c := range j.steps[j.applied].changes {
+		j.apply(c, false)
+	}
+	j.applied++
+	return true
+}
+
+func (j *Journal) must(e Entry) {
+	if err := j.do(e); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Makes the call recorded in the entry, and records it. If any edge in the
+// entry cannot be held by the graph, nothing is changed and an error is
+// returned.
+func (j *Journal) do(e Entry) error {
+	var m *mutator
+	switch e.Op {
+	case EnsureVertex, RemoveVertex:
+	case AddEdges, RemoveEdges:
+		if m = j.edges; m == nil {
+			return errors.New("Graph does not hold undirected edges.")
+		}
+	case AddArcs, RemoveArcs:
+		if m = j.arcs; m == nil {
+			return errors.New("Graph does not hold arcs.")
+		}
+	default:
+		return fmt.Errorf("Unknown journal operation %v.", e.Op)
+	}
+
+	for _, edge := range e.Edges {
+		if !m.holds(edge) {
+			return fmt.Errorf("Edge of type %T cannot be held by a graph of %s edges.", edge, m.want)
+		}
+	}
+
+	s := step{entry: e}
+	arc := e.Op == AddArcs || e.Op == RemoveArcs
+	switch e.Op {
+	case EnsureVertex:
+		for _, v := range e.Vertices {
+			if !j.g.HasVertex(v) {
+				j.vs.EnsureVertex(v)
+				s.changes = append(s.changes, change{added: true, v: v})
+			}
+		}
+	case RemoveVertex:
+		for _, v := range e.Vertices {
+			if j.g.HasVertex(v) {
+				s.changes = append(s.changes, j.removals(v)...)
+				j.vs.RemoveVertex(v)
+			}
+		}
+	case AddEdges, AddArcs:
+		for _, edge := range e.Edges {
+			u, v := edge.Both()
+			newU, newV := !j.g.HasVertex(u), !j.g.HasVertex(v) && u != v
+			_, existed := j.find(edge, arc)
+
+			m.add(edge)
+			if newU {
+				s.changes = append(s.changes, change{added: true, v: u})
+			}
+			if newV {
+				s.changes = append(s.changes, change{added: true, v: v})
+			}
+			if held, exists := j.find(edge, arc); exists && !existed {
+				s.changes = append(s.changes, change{added: true, e: held, arc: arc})
+			}
+		}
+	case RemoveEdges, RemoveArcs:
+		for _, edge := range e.Edges {
+			if held, exists := j.find(edge, arc); exists {
+				m.remove(edge)
+				s.changes = append(s.changes, change{e: held, arc: arc})
+			}
+		}
+	}
+
+	j.steps = append(j.steps[:j.applied], s)
+	j.applied++
+	return nil
+}
+
+// Returns the changes made by removing a vertex: the removal of each of its
+// incident edges, then of the vertex itself.
+func (j *Journal) removals(v gogl.Vertex) (changes []change) {
+	j.g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+		_, arc := e.(gogl.Arc)
+		changes = append(changes, change{e: e, arc: arc})
+		return
+	})
+
+	var attrs *vertexAttrs
+	if vas, ok := j.g.(gogl.VertexAttributeSource); ok {
+		var a vertexAttrs
+		a.data, a.hasData = vas.VertexData(v)
+		a.label, a.hasLabel = vas.VertexLabel(v)
+		a.weight, a.hasWeight = vas.VertexWeight(v)
+		if a.hasData || a.hasLabel || a.hasWeight {
+			attrs = &a
+		}
+	}
+
+	return append(changes, change{v: v, attrs: attrs})
+}
+
+// Finds the edge or arc the graph holds between the provided edge's members,
+// with whatever properties the graph holds for it.
+func (j *Journal) find(edge gogl.Edge, arc bool) (held gogl.Edge, exists bool) {
+	u, v := edge.Both()
+	j.g.IncidentTo(u, func(e gogl.Edge) (terminate bool) {
+		a, isArc := e.(gogl.Arc)
+		if isArc != arc {
+			return
+		}
+
+		if isArc {
+			exists = a.Source() == u && a.Target() == v
+		} else {
+			s, t := e.Both()
+			exists = (s == u && t == v) || (s == v && t == u)
+		}
+
+		if exists {
+			held = e
+		}
+		return exists
+	})
+
+	return
+}
+
+// Makes a recorded change, or reverses it.
+func (j *Journal) apply(c change, reverse bool) {
+	add := c.added != reverse
+
+	if c.e == nil {
+		if !add {
+			j.vs.RemoveVertex(c.v)
+			return
+		}
+
+		j.vs.EnsureVertex(c.v)
+		if va, ok := j.g.(gogl.VertexAttributes); ok && c.attrs != nil {
+			if c.attrs.hasData {
+				va.SetVertexData(c.v, c.attrs.data)
+			}
+			if c.attrs.hasLabel {
+				va.SetVertexLabel(c.v, c.attrs.label)
+			}
+			if c.attrs.hasWeight {
+				va.SetVertexWeight(c.v, c.attrs.weight)
+			}
+		}
+		return
+	}
+
+	m := j.edges
+	if c.arc {
+		m = j.arcs
+	}
+
+	if add {
+		m.add(c.e)
+	} else {
+		m.remove(c.e)
+	}
+}
@@ -0,0 +1,203 @@
+package journal
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/spec"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type JournalSuite struct{}
+
+var _ = Suite(&JournalSuite{})
+
+func newJournal(c *C, gs gogl.GraphSpec) *Journal {
+	j, err := New(gs.Create(al.G))
+	c.Assert(err, IsNil)
+	return j
+}
+
+func (s *JournalSuite) TestUndoRedo(c *C) {
+	j := newJournal(c, gogl.Spec())
+	g := j.Graph()
+
+	j.EnsureVertex(1, 2)
+	j.AddEdges(gogl.NewEdge(2, 3), gogl.NewEdge(3, 4))
+	j.RemoveVertex(3)
+	c.Assert(j.Len(), Equals, 3)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 0)
+
+	c.Assert(j.Undo(), Equals, true)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(g.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(3, 4)), Equals, true)
+
+	c.Assert(j.Undo(), Equals, true)
+	c.Assert(gogl.Order(g), Equals, 2)
+	c.Assert(gogl.Size(g), Equals, 0)
+
+	c.Assert(j.Undo(), Equals, true)
+	c.Assert(j.Undo(), Equals, false)
+	c.Assert(gogl.Order(g), Equals, 0)
+
+	c.Assert(j.Redo(), Equals, true)
+	c.Assert(j.Redo(), Equals, true)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 2)
+
+	// a new call discards what is left to redo
+	j.RemoveEdges(gogl.NewEdge(4, 3))
+	c.Assert(j.Redo(), Equals, false)
+	c.Assert(j.Len(), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 1)
+}
+
+// Undo reverses only the changes a call actually made.
+func (s *JournalSuite) TestUndoPreservesExisting(c *C) {
+	j := newJournal(c, gogl.Spec().Using(spec.GraphFixtures["2e3v"]))
+	g := j.Graph()
+
+	j.EnsureVertex("foo", "qux")
+	j.AddEdges(gogl.NewEdge("bar", "foo"), gogl.NewEdge("foo", "qux"))
+	j.RemoveEdges(gogl.NewEdge("quark", "foo"))
+
+	j.Undo()
+	j.Undo()
+	c.Assert(g.HasEdge(gogl.NewEdge("foo", "bar")), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge("foo", "qux")), Equals, false)
+	c.Assert(g.HasVertex("qux"), Equals, true)
+
+	j.Undo()
+	c.Assert(g.HasVertex("foo"), Equals, true)
+	c.Assert(g.HasVertex("qux"), Equals, false)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 2)
+}
+
+// Undoing a vertex removal restores the weights of the removed arcs, and the
+// vertex's attributes.
+func (s *JournalSuite) TestRemoveVertexProperties(c *C) {
+	j := newJournal(c, gogl.Spec().Directed().Weighted().Using(spec.GraphFixtures["w-2e3v"]))
+	g := j.Graph().(gogl.WeightedDigraph)
+	g.(gogl.VertexAttributes).SetVertexLabel(2, "middle")
+
+	j.RemoveVertex(2)
+	c.Assert(gogl.Size(g), Equals, 0)
+
+	j.Undo()
+	c.Assert(g.HasWeightedArc(gogl.NewWeightedArc(1, 2, 5.23)), Equals, true)
+	c.Assert(g.HasWeightedArc(gogl.NewWeightedArc(2, 3, 5.821)), Equals, true)
+	label, _ := g.(gogl.VertexAttributeSource).VertexLabel(2)
+	c.Assert(label, Equals, "middle")
+
+	// removals record the weight held by the graph, not the one passed
+	j.RemoveArcs(gogl.NewWeightedArc(1, 2, 0))
+	j.Undo()
+	c.Assert(g.HasWeightedArc(gogl.NewWeightedArc(1, 2, 5.23)), Equals, true)
+}
+
+func (s *JournalSuite) TestMixed(c *C) {
+	j := newJournal(c, gogl.Spec().Mixed())
+	g := j.Graph().(gogl.MixedGraph)
+
+	j.AddArcs(gogl.NewArc(1, 2))
+	j.AddEdges(gogl.NewEdge(2, 3), gogl.NewEdge(2, 1))
+	j.RemoveVertex(2)
+	c.Assert(gogl.Size(g), Equals, 0)
+
+	j.Undo()
+	c.Assert(g.HasArc(gogl.NewArc(1, 2)), Equals, true)
+	c.Assert(g.HasArc(gogl.NewArc(2, 3)), Equals, false)
+	c.Assert(g.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+	c.Assert(gogl.Size(g), Equals, 2)
+
+	j.Undo()
+	j.Undo()
+	c.Assert(gogl.Order(g), Equals, 0)
+}
+
+func (s *JournalSuite) TestErrors(c *C) {
+	_, err := New(gogl.Spec().Immutable().Create(al.G))
+	c.Assert(err, ErrorMatches, "Journals can only be kept for mutable graphs.")
+
+	j := newJournal(c, gogl.Spec().Directed().Weighted())
+	c.Assert(func() { j.AddEdges(gogl.NewEdge(1, 2)) }, PanicMatches, "Graph does not hold undirected edges.")
+	c.Assert(func() { j.AddArcs(gogl.NewWeightedArc(1, 2, 1), gogl.NewArc(2, 3)) }, PanicMatches,
+		"Edge of type gogl.baseArc cannot be held by a graph of gogl.WeightedArc edges.")
+	c.Assert(gogl.Order(j.Graph()), Equals, 0)
+	c.Assert(j.Len(), Equals, 0)
+
+	j = newJournal(c, gogl.Spec())
+	c.Assert(func() { j.RemoveArcs(gogl.NewArc(1, 2)) }, PanicMatches, "Graph does not hold arcs.")
+}
+
+func (s *JournalSuite) TestMarshalReplay(c *C) {
+	j := newJournal(c, gogl.Spec().Directed().PropertyEdges())
+	j.EnsureVertex("a", 1.5, true)
+	j.AddArcs(gogl.NewPropertyArc("a", 2, 3.5, "x", "payload"), gogl.NewPropertyArc(2, true, 0, "", nil))
+	j.RemoveArcs(gogl.NewPropertyArc(2, true, 0, "", nil))
+	j.RemoveVertex(1.5)
+	j.EnsureVertex("undone")
+	j.Undo()
+
+	data, err := Marshal(j.Entries())
+	c.Assert(err, IsNil)
+
+	entries, err := Unmarshal(data)
+	c.Assert(err, IsNil)
+	c.Assert(entries, DeepEquals, j.Entries())
+
+	r, err := Replay(gogl.Spec().Directed().PropertyEdges(), al.G, entries)
+	c.Assert(err, IsNil)
+	c.Assert(r.Len(), Equals, 4)
+
+	g := r.Graph().(gogl.PropertyDigraph)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(g.HasPropertyArc(gogl.NewPropertyArc("a", 2, 3.5, "x", "payload")), Equals, true)
+	c.Assert(gogl.Size(g), Equals, 1)
+
+	// the replayed history can itself be undone
+	r.Undo()
+	c.Assert(g.HasVertex(1.5), Equals, true)
+
+	_, err = Replay(gogl.Spec(), al.G, entries)
+	c.Assert(err, ErrorMatches, "Graph does not hold arcs.")
+}
+
+// Edges with nil data are still replayed as data edges.
+func (s *JournalSuite) TestMarshalNilData(c *C) {
+	j := newJournal(c, gogl.Spec().DataEdges())
+	j.AddEdges(gogl.NewDataEdge(1, 2, nil))
+
+	data, err := Marshal(j.Entries())
+	c.Assert(err, IsNil)
+
+	entries, err := Unmarshal(data)
+	c.Assert(err, IsNil)
+	c.Assert(entries, DeepEquals, j.Entries())
+
+	r, err := Replay(gogl.Spec().DataEdges(), al.G, entries)
+	c.Assert(err, IsNil)
+	c.Assert(r.Graph().(gogl.DataGraph).HasDataEdge(gogl.NewDataEdge(1, 2, nil)), Equals, true)
+}
+
+func (s *JournalSuite) TestUnmarshalErrors(c *C) {
+	_, err := Unmarshal([]byte(`{"op":"Frobnicate"}`))
+	c.Assert(err, ErrorMatches, `Unknown journal operation "Frobnicate".`)
+
+	_, err = Unmarshal([]byte(`{"op":"EnsureVertex","vertices":[[1]]}`))
+	c.Assert(err, ErrorMatches, "Cannot use .* as a vertex.*")
+
+	_, err = Unmarshal([]byte(`{"op":"AddEdges","edges":[{"source":1}]}`))
+	c.Assert(err, ErrorMatches, "Missing vertex.")
+
+	entries, err := Unmarshal(nil)
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 0)
+}
@@ -17,15 +17,20 @@ var _ SimpleGraph = nullGraph(false)
 var _ WeightedGraph = nullGraph(false)
 var _ LabeledGraph = nullGraph(false)
 var _ DataGraph = nullGraph(false)
-
-func (g nullGraph) Vertices(f VertexStep)                   {}
-func (g nullGraph) Edges(f EdgeStep)                       {}
-func (g nullGraph) Arcs(f ArcStep)                         {}
-func (g nullGraph) IncidentTo(Vertex, EdgeStep)       {}
-func (g nullGraph) ArcsFrom(Vertex, ArcStep)               {}
-func (g nullGraph) PredecessorsOf(Vertex, VertexStep)      {}
-func (g nullGraph) ArcsTo(Vertex, ArcStep)                 {}
-func (g nullGraph) SuccessorsOf(Vertex, VertexStep)        {}
+var _ PropertyGraph = nullGraph(false)
+var _ MixedGraph = nullGraph(false)
+var _ VertexAttributeSource = nullGraph(false)
+
+func (g nullGraph) Vertices(f VertexStep)                 {}
+func (g nullGraph) Edges(f EdgeStep)                      {}
+func (g nullGraph) Arcs(f ArcStep)                        {}
+func (g nullGraph) UndirectedEdges(f EdgeStep)            {}
+func (g nullGraph) UndirectedIncidentTo(Vertex, EdgeStep) {}
+func (g nullGraph) IncidentTo(Vertex, EdgeStep)           {}
+func (g nullGraph) ArcsFrom(Vertex, ArcStep)              {}
+func (g nullGraph) PredecessorsOf(Vertex, VertexStep)     {}
+func (g nullGraph) ArcsTo(Vertex, ArcStep)                {}
+func (g nullGraph) SuccessorsOf(Vertex, VertexStep)       {}
 func (g nullGraph) AdjacentTo(start Vertex, f VertexStep) {}
 
 func (g nullGraph) HasVertex(v Vertex) bool {
@@ -64,6 +69,22 @@ func (g nullGraph) HasDataEdge(e DataEdge) bool {
 	return false
 }
 
+func (g nullGraph) VertexData(Vertex) (data interface{}, exists bool) {
+	return nil, false
+}
+
+func (g nullGraph) VertexLabel(Vertex) (label string, exists bool) {
+	return "", false
+}
+
+func (g nullGraph) VertexWeight(Vertex) (weight float64, exists bool) {
+	return 0, false
+}
+
+func (g nullGraph) HasPropertyEdge(e PropertyEdge) bool {
+	return false
+}
+
 func (g nullGraph) Density() float64 {
 	return math.NaN()
 }
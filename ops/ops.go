@@ -0,0 +1,254 @@
+// Combines graphs with the standard set operations and graph products.
+//
+// The functors in this package rely only on the enumerators of the graphs they
+// are given, so graphs of any implementation can be combined - an EdgeList with
+// an adjacency list, say. Each returns a GraphSpec, populated from an in-memory
+// source holding the result and with properties inferred from it, so creating
+// the resulting graph is one more call away:
+//
+//	g := ops.Union(nil, a, b).Create(al.G)
+//
+// Vertices are identified by equality, as they are everywhere in gogl. Edges
+// are identified by their endpoints and directedness: an arc is identified by
+// its source and target, and an undirected edge by its endpoints in either
+// order. An arc and an undirected edge between the same vertices are thus
+// distinct, and combining them produces a mixed graph.
+//
+// Operations treat their inputs' edge sets as sets. When the same edge appears
+// more than once - in several inputs, or as parallel edges within one - the
+// copies are merged into one, and a Resolver decides the weight, label and
+// data of the result.
+//
+// A merged undirected edge keeps the orientation - the order of the endpoints
+// returned by Both() - of the copy enumerated first: the one from the earliest
+// input, or the earliest within it. Results are therefore deterministic for
+// inputs that enumerate deterministically, such as edge lists. Many graph
+// implementations, al's among them, enumerate undirected edges in an
+// unspecified order and orientation, and so leave the orientation of the
+// result unspecified too.
+package ops
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// A Resolver merges the properties of two copies of the same edge. a belongs to
+// the copy seen first: the one from the earlier input graph or, within a graph,
+// the one enumerated earlier.
+type Resolver func(a, b encoding.Attrs) encoding.Attrs
+
+// Resolves conflicts in favor of the first copy of an edge. Properties that
+// only the second copy carries are kept. This is the Resolver used when nil is
+// passed.
+func First(a, b encoding.Attrs) encoding.Attrs {
+	return merge(a, b)
+}
+
+// Resolves conflicts in favor of the last copy of an edge. Properties that only
+// the first copy carries are kept.
+func Last(a, b encoding.Attrs) encoding.Attrs {
+	return merge(b, a)
+}
+
+// Resolves conflicts as First does, except that the weights of the copies are
+// summed.
+func SumWeights(a, b encoding.Attrs) encoding.Attrs {
+	r := merge(a, b)
+	if a.Props&b.Props&gogl.G_WEIGHTED != 0 {
+		r.Weight = a.Weight + b.Weight
+	}
+	return r
+}
+
+// Resolves conflicts as First does, except that the lesser of the copies'
+// weights is kept.
+func MinWeight(a, b encoding.Attrs) encoding.Attrs {
+	r := merge(a, b)
+	if a.Props&b.Props&gogl.G_WEIGHTED != 0 && b.Weight < a.Weight {
+		r.Weight = b.Weight
+	}
+	return r
+}
+
+// Resolves conflicts as First does, except that the greater of the copies'
+// weights is kept.
+func MaxWeight(a, b encoding.Attrs) encoding.Attrs {
+	r := merge(a, b)
+	if a.Props&b.Props&gogl.G_WEIGHTED != 0 && b.Weight > a.Weight {
+		r.Weight = b.Weight
+	}
+	return r
+}
+
+// Returns a's properties, plus any that only b carries.
+func merge(a, b encoding.Attrs) encoding.Attrs {
+	missing := b.Props &^ a.Props
+	if missing&gogl.G_WEIGHTED != 0 {
+		a.SetWeight(b.Weight)
+	}
+	if missing&gogl.G_LABELED != 0 {
+		a.SetLabel(b.Label)
+	}
+	if missing&gogl.G_DATA != 0 {
+		a.SetData(b.Data)
+	}
+	return a
+}
+
+// A Pair is a vertex made from two others. The vertices of a graph product are
+// Pairs of a vertex from each factor; DisjointUnion makes a Pair of each input
+// graph's position in the argument list and each of its vertices.
+type Pair struct {
+	U, V gogl.Vertex
+}
+
+type key struct {
+	u, v     gogl.Vertex
+	directed bool
+}
+
+type edge struct {
+	u, v     gogl.Vertex
+	directed bool
+	attrs    encoding.Attrs
+}
+
+// An index holds a graph's vertices and deduplicated edges, in enumeration
+// order, with fast lookup of edges by their endpoints.
+type index struct {
+	dir      gogl.GraphProperties
+	vertices []gogl.Vertex
+	vset     map[gogl.Vertex]struct{}
+	edges    []*edge
+	lookup   map[key]*edge
+	resolve  Resolver
+}
+
+func newIndex(dir gogl.GraphProperties, r Resolver) *index {
+	if r == nil {
+		r = First
+	}
+
+	return &index{
+		dir:     dir,
+		vset:    make(map[gogl.Vertex]struct{}),
+		lookup:  make(map[key]*edge),
+		resolve: r,
+	}
+}
+
+// Indexes the provided graph, merging any parallel edges with the provided
+// Resolver.
+func indexOf(g gogl.GraphSource, r Resolver) *index {
+	x := newIndex(directedness(g), r)
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		x.addVertex(v)
+		return
+	})
+	eachEdge(g, func(u, v gogl.Vertex, directed bool, attrs encoding.Attrs) {
+		x.addEdge(u, v, directed, attrs)
+	})
+
+	return x
+}
+
+func (x *index) hasVertex(v gogl.Vertex) bool {
+	_, exists := x.vset[v]
+	return exists
+}
+
+func (x *index) addVertex(v gogl.Vertex) {
+	if !x.hasVertex(v) {
+		x.vset[v] = struct{}{}
+		x.vertices = append(x.vertices, v)
+	}
+}
+
+// Returns the edge connecting the provided vertices, or nil if there is none.
+// Undirected edges match with their endpoints in either order.
+func (x *index) find(u, v gogl.Vertex, directed bool) *edge {
+	if e, exists := x.lookup[key{u, v, directed}]; exists {
+		return e
+	}
+	if !directed {
+		return x.lookup[key{v, u, false}]
+	}
+	return nil
+}
+
+// Adds an edge and its endpoints, resolving its properties against those of
+// any copy already present.
+func (x *index) addEdge(u, v gogl.Vertex, directed bool, attrs encoding.Attrs) {
+	x.addVertex(u)
+	x.addVertex(v)
+
+	if e := x.find(u, v, directed); e != nil {
+		e.attrs = x.resolve(e.attrs, attrs)
+		return
+	}
+
+	e := &edge{u: u, v: v, directed: directed, attrs: attrs}
+	x.edges = append(x.edges, e)
+	x.lookup[key{u, v, directed}] = e
+}
+
+// Converts the index into a GraphSpec populated with its contents.
+func (x *index) spec() gogl.GraphSpec {
+	src := encoding.NewSource(x.dir&gogl.G_DIRECTED != 0)
+	for _, v := range x.vertices {
+		src.AddVertex(v)
+	}
+	for _, e := range x.edges {
+		if e.directed {
+			src.AddEdge(e.attrs.Arc(e.u, e.v))
+		} else {
+			src.AddEdge(e.attrs.Edge(e.u, e.v))
+		}
+	}
+
+	return encoding.Spec(src.GraphSource())
+}
+
+// Reports whether a graph is undirected, directed or mixed, based on the
+// interfaces it implements.
+func directedness(g gogl.GraphSource) gogl.GraphProperties {
+	if _, ok := g.(gogl.DigraphSource); !ok {
+		return gogl.G_UNDIRECTED
+	}
+	if _, ok := g.(gogl.UndirectedEdgeEnumerator); ok {
+		return gogl.G_DIRECTED | gogl.G_UNDIRECTED
+	}
+	return gogl.G_DIRECTED
+}
+
+// Passes each of a graph's edges to the provided function, along with whether
+// it is directed.
+//
+// Digraphs do not all yield Arcs from Edges(), so their arcs, and the
+// undirected edges of mixed graphs, are read from the dedicated enumerators
+// instead. For other graphs, any Arcs among the edges are still treated as
+// directed.
+func eachEdge(g gogl.GraphSource, f func(u, v gogl.Vertex, directed bool, attrs encoding.Attrs)) {
+	undirected := func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		_, directed := e.(gogl.Arc)
+		f(u, v, directed, encoding.AttrsOf(e))
+		return
+	}
+
+	dg, ok := g.(gogl.DigraphSource)
+	if !ok {
+		g.Edges(undirected)
+		return
+	}
+
+	dg.Arcs(func(a gogl.Arc) (terminate bool) {
+		f(a.Source(), a.Target(), true, encoding.AttrsOf(a))
+		return
+	})
+	if ug, ok := g.(gogl.UndirectedEdgeEnumerator); ok {
+		ug.UndirectedEdges(undirected)
+	}
+}
@@ -0,0 +1,174 @@
+package ops
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+	"github.com/sdboyer/gogl/gen"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+func TestOps(t *testing.T) { TestingT(t) }
+
+type OpsSuite struct{}
+
+var _ = Suite(&OpsSuite{})
+
+// Collects the weight of each edge in the spec's source, keyed by a basic edge.
+func weights(c *C, spec gogl.GraphSpec) map[gogl.Edge]float64 {
+	w := make(map[gogl.Edge]float64)
+	spec.Source.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e, Implements, new(gogl.WeightedEdge))
+		u, v := e.Both()
+		w[gogl.NewEdge(u, v)] = e.(gogl.WeightedEdge).Weight()
+		return
+	})
+	return w
+}
+
+func (s *OpsSuite) TestUnion(c *C) {
+	a := gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("foo", "bar", 1),
+		gogl.NewWeightedEdge("bar", "baz", 2),
+	}
+	// An al graph, to show implementations mix
+	b := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("baz", "bar", 5),
+		gogl.NewWeightedEdge("baz", "qux", 3),
+	}).Create(al.G)
+
+	g := Union(nil, a, b).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 3)
+
+	c.Assert(weights(c, Union(nil, a, b))[gogl.NewEdge("bar", "baz")], Equals, float64(2))
+	c.Assert(weights(c, Union(Last, a, b))[gogl.NewEdge("bar", "baz")], Equals, float64(5))
+	c.Assert(weights(c, Union(SumWeights, a, b))[gogl.NewEdge("bar", "baz")], Equals, float64(7))
+	c.Assert(weights(c, Union(MaxWeight, a, b))[gogl.NewEdge("bar", "baz")], Equals, float64(5))
+
+	// With the al graph first, the edge takes whichever orientation it enumerates
+	w := weights(c, Union(MinWeight, b, a))
+	c.Assert(w[gogl.NewEdge("baz", "bar")]+w[gogl.NewEdge("bar", "baz")], Equals, float64(2))
+
+	// Otherwise, the first input's orientation is kept
+	spec := Union(nil, gogl.EdgeList{gogl.NewEdge(2, 1)}, gogl.EdgeList{gogl.NewEdge(1, 2), gogl.NewEdge(3, 2)})
+	c.Assert(gogl.CollectEdges(spec.Source), DeepEquals, []gogl.Edge{gogl.NewEdge(2, 1), gogl.NewEdge(3, 2)})
+}
+
+func (s *OpsSuite) TestUnionMixed(c *C) {
+	spec := Union(nil, gogl.EdgeList{gogl.NewEdge(1, 2)}, gogl.ArcList{gogl.NewArc(1, 2)})
+	c.Assert(spec.Props&(gogl.G_DIRECTED|gogl.G_UNDIRECTED), Equals, gogl.GraphProperties(gogl.G_DIRECTED|gogl.G_UNDIRECTED))
+	c.Assert(gogl.Size(spec.Source), Equals, 2)
+}
+
+func (s *OpsSuite) TestResolvers(c *C) {
+	var a, b encoding.Attrs
+	a.SetWeight(1)
+	b.SetWeight(2)
+	b.SetLabel("foo")
+
+	r := First(a, b)
+	c.Assert(r.Weight, Equals, float64(1))
+	c.Assert(r.Label, Equals, "foo")
+	c.Assert(r.Props, Equals, gogl.GraphProperties(gogl.G_WEIGHTED|gogl.G_LABELED))
+
+	r = Last(a, b)
+	c.Assert(r.Weight, Equals, float64(2))
+	c.Assert(r.Props, Equals, gogl.GraphProperties(gogl.G_WEIGHTED|gogl.G_LABELED))
+
+	// Resolvers also merge parallel edges within a single graph
+	spec := Union(SumWeights, gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 1),
+		gogl.NewWeightedEdge(2, 1, 2),
+	})
+	c.Assert(weights(c, spec), DeepEquals, map[gogl.Edge]float64{gogl.NewEdge(1, 2): 3})
+}
+
+func (s *OpsSuite) TestDisjointUnion(c *C) {
+	g := DisjointUnion(gen.Path(3), gen.Path(3)).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 6)
+	c.Assert(gogl.Size(g), Equals, 4)
+	c.Assert(g.HasEdge(gogl.NewEdge(Pair{1, 0}, Pair{1, 1})), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(Pair{0, 2}, Pair{1, 2})), Equals, false)
+}
+
+func (s *OpsSuite) TestIntersection(c *C) {
+	g := Intersection(nil, gen.Complete(5), gen.Cycle(4), gen.Path(5)).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(g.HasEdge(gogl.NewEdge(3, 0)), Equals, false)
+
+	c.Assert(gogl.Size(Intersection(nil).Source), Equals, 0)
+}
+
+func (s *OpsSuite) TestDifference(c *C) {
+	g := Difference(gen.Complete(4), gen.Cycle(4)).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 2)
+	c.Assert(g.HasEdge(gogl.NewEdge(0, 2)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(1, 3)), Equals, true)
+
+	g = SymmetricDifference(gen.Path(4), gen.Star(5)).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 5)
+	c.Assert(gogl.Size(g), Equals, 5)
+	c.Assert(g.HasEdge(gogl.NewEdge(0, 1)), Equals, false)
+}
+
+func (s *OpsSuite) TestComplement(c *C) {
+	c.Assert(gogl.Size(Complement(gen.Petersen()).Source), Equals, 30)
+	c.Assert(gogl.Size(Complement(gen.Complete(5)).Source), Equals, 0)
+
+	// The complement of C_5 is another C_5
+	g := Complement(gen.Cycle(5)).Create(al.G)
+	c.Assert(gogl.Size(g), Equals, 5)
+	for i := 0; i < 5; i++ {
+		d, _ := g.DegreeOf(i)
+		c.Assert(d, Equals, 2)
+	}
+
+	dg := Complement(gen.Path(3).Directed()).Create(al.G).(gogl.Digraph)
+	c.Assert(gogl.Size(dg), Equals, 4)
+	c.Assert(dg.HasArc(gogl.NewArc(1, 0)), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc(0, 1)), Equals, false)
+}
+
+func (s *OpsSuite) TestProducts(c *C) {
+	// P_2 x P_3 is the 2x3 grid
+	g := CartesianProduct(gen.Path(2), gen.Path(3)).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 6)
+	c.Assert(gogl.Size(g), Equals, 7)
+	c.Assert(g.HasEdge(gogl.NewEdge(Pair{0, 1}, Pair{1, 1})), Equals, true)
+
+	// K_2 x K_3 (tensor) is the 6-cycle
+	g = TensorProduct(gen.Complete(2), gen.Complete(3), nil).Create(al.G)
+	c.Assert(gogl.Size(g), Equals, 6)
+	for _, v := range gogl.CollectVertices(g) {
+		d, _ := g.DegreeOf(v)
+		c.Assert(d, Equals, 2)
+	}
+
+	// K_2 x K_2 (strong) is K_4
+	c.Assert(gogl.Size(StrongProduct(gen.Complete(2), gen.Complete(2), nil).Source), Equals, 6)
+
+	dg := CartesianProduct(gen.Path(2).Directed(), gen.Path(2).Directed()).Create(al.G).(gogl.Digraph)
+	c.Assert(gogl.Size(dg), Equals, 4)
+	c.Assert(dg.HasArc(gogl.NewArc(Pair{0, 0}, Pair{0, 1})), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc(Pair{0, 1}, Pair{0, 0})), Equals, false)
+
+	c.Assert(func() { CartesianProduct(gen.Path(2), gen.Path(2).Directed()) },
+		PanicMatches, "Products require both graphs to be directed, or both undirected.")
+}
+
+func (s *OpsSuite) TestProductWeights(c *C) {
+	a := gen.Path(2).Weighted(func(u, v int) float64 { return 2 })
+	b := gen.Path(2).Weighted(func(u, v int) float64 { return 3 })
+
+	for _, w := range weights(c, CartesianProduct(a, b)) {
+		c.Assert(w == 2 || w == 3, Equals, true)
+	}
+	for _, w := range weights(c, TensorProduct(a, b, SumWeights)) {
+		c.Assert(w, Equals, float64(5))
+	}
+}
@@ -0,0 +1,88 @@
+package ops
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Returns the Cartesian product of two graphs. Its vertices are the Pairs
+// {u, v} of a vertex u from a and v from b; {u, v} and {u', v'} are adjacent
+// when u = u' and v is adjacent to v' in b, or v = v' and u is adjacent to u'
+// in a. Each edge carries the properties of the factor edge it came from.
+//
+// a and b must both be directed, or both undirected, else panic.
+func CartesianProduct(a, b gogl.GraphSource) gogl.GraphSpec {
+	x, y, z := factors(a, b, nil)
+	cartesian(x, y, z)
+	return z.spec()
+}
+
+// Returns the tensor (also called categorical, or direct) product of two
+// graphs. Its vertices are the Pairs {u, v} of a vertex u from a and v from
+// b; {u, v} and {u', v'} are adjacent when u is adjacent to u' in a, and v is
+// adjacent to v' in b. The properties of each edge are decided by the Resolver
+// from those of its two factor edges, a's first; if it is nil, First is used.
+//
+// a and b must both be directed, or both undirected, else panic.
+func TensorProduct(a, b gogl.GraphSource, r Resolver) gogl.GraphSpec {
+	x, y, z := factors(a, b, r)
+	tensor(x, y, z)
+	return z.spec()
+}
+
+// Returns the strong product of two graphs: the union of their Cartesian and
+// tensor products. Properties are decided as they are for those products; the
+// Resolver is used only for the tensor product's edges.
+//
+// a and b must both be directed, or both undirected, else panic.
+func StrongProduct(a, b gogl.GraphSource, r Resolver) gogl.GraphSpec {
+	x, y, z := factors(a, b, r)
+	cartesian(x, y, z)
+	tensor(x, y, z)
+	return z.spec()
+}
+
+// Indexes the factors of a product, and creates an index for the product with
+// all its vertices in place.
+func factors(a, b gogl.GraphSource, r Resolver) (x, y, z *index) {
+	x, y = indexOf(a, nil), indexOf(b, nil)
+	if x.dir != y.dir || x.dir == gogl.G_DIRECTED|gogl.G_UNDIRECTED {
+		panic("Products require both graphs to be directed, or both undirected.")
+	}
+
+	z = newIndex(x.dir, r)
+	for _, u := range x.vertices {
+		for _, v := range y.vertices {
+			z.addVertex(Pair{u, v})
+		}
+	}
+
+	return
+}
+
+func cartesian(x, y, z *index) {
+	for _, u := range x.vertices {
+		for _, e := range y.edges {
+			z.addEdge(Pair{u, e.u}, Pair{u, e.v}, e.directed, e.attrs)
+		}
+	}
+	for _, e := range x.edges {
+		for _, v := range y.vertices {
+			z.addEdge(Pair{e.u, v}, Pair{e.v, v}, e.directed, e.attrs)
+		}
+	}
+}
+
+func tensor(x, y, z *index) {
+	for _, e := range x.edges {
+		for _, f := range y.edges {
+			attrs := z.resolve(e.attrs, f.attrs)
+			z.addEdge(Pair{e.u, f.u}, Pair{e.v, f.v}, e.directed, attrs)
+
+			// An undirected edge in each factor yields two edges in the product,
+			// unless one of them is a loop, in which case both are the same.
+			if !e.directed && e.u != e.v && f.u != f.v {
+				z.addEdge(Pair{e.u, f.v}, Pair{e.v, f.u}, false, attrs)
+			}
+		}
+	}
+}
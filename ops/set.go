@@ -0,0 +1,159 @@
+package ops
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// Returns the union of the provided graphs: every vertex and every edge that
+// appears in any of them. The properties of an edge appearing more than once
+// are decided by the Resolver; if it is nil, First is used.
+// Such an edge, if undirected, is oriented as its first copy was enumerated.
+func Union(r Resolver, gs ...gogl.GraphSource) gogl.GraphSpec {
+	x := newIndex(0, r)
+	for _, g := range gs {
+		x.dir |= directedness(g)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			x.addVertex(v)
+			return
+		})
+		eachEdge(g, x.addEdge)
+	}
+
+	if x.dir == 0 {
+		x.dir = gogl.G_UNDIRECTED
+	}
+	return x.spec()
+}
+
+// Returns the disjoint union of the provided graphs: a copy of each, side by
+// side, with no vertices in common. The vertex v of the i-th graph becomes the
+// vertex Pair{i, v}.
+func DisjointUnion(gs ...gogl.GraphSource) gogl.GraphSpec {
+	x := newIndex(0, nil)
+	for i, g := range gs {
+		x.dir |= directedness(g)
+
+		i := i
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			x.addVertex(Pair{i, v})
+			return
+		})
+		eachEdge(g, func(u, v gogl.Vertex, directed bool, attrs encoding.Attrs) {
+			x.addEdge(Pair{i, u}, Pair{i, v}, directed, attrs)
+		})
+	}
+
+	if x.dir == 0 {
+		x.dir = gogl.G_UNDIRECTED
+	}
+	return x.spec()
+}
+
+// Returns the intersection of the provided graphs: the vertices, and the
+// edges, that appear in all of them. The properties of each edge are decided
+// by folding the Resolver over its copies, in argument order; if it is nil,
+// First is used.
+func Intersection(r Resolver, gs ...gogl.GraphSource) gogl.GraphSpec {
+	if len(gs) == 0 {
+		return newIndex(gogl.G_UNDIRECTED, r).spec()
+	}
+
+	x := indexOf(gs[0], r)
+	for _, g := range gs[1:] {
+		y := indexOf(g, r)
+		z := newIndex(x.dir|y.dir, r)
+
+		for _, v := range x.vertices {
+			if y.hasVertex(v) {
+				z.addVertex(v)
+			}
+		}
+		for _, e := range x.edges {
+			if o := y.find(e.u, e.v, e.directed); o != nil {
+				z.addEdge(e.u, e.v, e.directed, x.resolve(e.attrs, o.attrs))
+			}
+		}
+
+		x = z
+	}
+
+	return x.spec()
+}
+
+// Returns the difference of two graphs: all the vertices of a, and those of
+// its edges that do not appear in b.
+func Difference(a, b gogl.GraphSource) gogl.GraphSpec {
+	x, y := indexOf(a, nil), indexOf(b, nil)
+	z := newIndex(x.dir, nil)
+
+	for _, v := range x.vertices {
+		z.addVertex(v)
+	}
+	for _, e := range x.edges {
+		if y.find(e.u, e.v, e.directed) == nil {
+			z.addEdge(e.u, e.v, e.directed, e.attrs)
+		}
+	}
+
+	return z.spec()
+}
+
+// Returns the symmetric difference of two graphs: the vertices of both, and
+// the edges that appear in exactly one of them.
+func SymmetricDifference(a, b gogl.GraphSource) gogl.GraphSpec {
+	x, y := indexOf(a, nil), indexOf(b, nil)
+	z := newIndex(x.dir|y.dir, nil)
+
+	for _, v := range x.vertices {
+		z.addVertex(v)
+	}
+	for _, v := range y.vertices {
+		z.addVertex(v)
+	}
+	for _, e := range x.edges {
+		if y.find(e.u, e.v, e.directed) == nil {
+			z.addEdge(e.u, e.v, e.directed, e.attrs)
+		}
+	}
+	for _, e := range y.edges {
+		if x.find(e.u, e.v, e.directed) == nil {
+			z.addEdge(e.u, e.v, e.directed, e.attrs)
+		}
+	}
+
+	return z.spec()
+}
+
+// Returns the complement of a graph: the same vertices, connected by an edge
+// wherever the original had none. Loops are never added.
+//
+// The complement of a digraph contains each arc missing from the original. In
+// a mixed graph, an undirected edge counts as an arc in each direction, and the
+// complement is a digraph.
+func Complement(g gogl.GraphSource) gogl.GraphSpec {
+	x := indexOf(g, nil)
+	directed := x.dir&gogl.G_DIRECTED != 0
+
+	z := newIndex(gogl.G_UNDIRECTED, nil)
+	if directed {
+		z.dir = gogl.G_DIRECTED
+	}
+
+	for _, v := range x.vertices {
+		z.addVertex(v)
+	}
+	for i, u := range x.vertices {
+		for j, v := range x.vertices {
+			if i == j || (!directed && j < i) {
+				continue
+			}
+			if x.find(u, v, false) != nil || (directed && x.find(u, v, true) != nil) {
+				continue
+			}
+			z.addEdge(u, v, directed, encoding.Attrs{})
+		}
+	}
+
+	return z.spec()
+}
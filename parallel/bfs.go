@@ -0,0 +1,64 @@
+package parallel
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Performs a level-synchronous breadth-first traversal of the given graph from
+// the given start vertex, returning the depth - the distance, in edges - of
+// each vertex reachable from it.
+//
+// Each level of the traversal is expanded in parallel, and the next level is
+// not begun until every vertex in the current one has been expanded. As with
+// the bfs package, arcs are only followed in their direction; undirected
+// edges are followed either way.
+func BFS(g gogl.Graph, start gogl.Vertex, workers int) (map[gogl.Vertex]int, error) {
+	if !g.HasVertex(start) {
+		return nil, errors.New("Start vertex is not present in graph.")
+	}
+
+	workers = poolSize(workers)
+	ix := newIndex(g)
+	next := successors(g)
+
+	depths := make([]int32, len(ix.vertices))
+	for i := range depths {
+		depths[i] = -1
+	}
+
+	s := ix.ids[start]
+	depths[s] = 0
+
+	frontiers := make([][]int, workers)
+	frontier := []int{s}
+	for depth := int32(1); len(frontier) > 0; depth++ {
+		each(len(frontier), workers, func(w, i int) {
+			next(ix.vertices[frontier[i]], func(v gogl.Vertex) (terminate bool) {
+				j := ix.ids[v]
+				// Only the worker that claims a vertex adds it to the next level
+				if atomic.CompareAndSwapInt32(&depths[j], -1, depth) {
+					frontiers[w] = append(frontiers[w], j)
+				}
+				return
+			})
+		})
+
+		frontier = frontier[:0]
+		for w, f := range frontiers {
+			frontier = append(frontier, f...)
+			frontiers[w] = f[:0]
+		}
+	}
+
+	reached := make(map[gogl.Vertex]int)
+	for i, d := range depths {
+		if d >= 0 {
+			reached[ix.vertices[i]] = int(d)
+		}
+	}
+
+	return reached, nil
+}
@@ -0,0 +1,65 @@
+package parallel
+
+import (
+	"sync/atomic"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Finds the connected components of the given graph by label propagation,
+// returning the number of components and a map from each vertex to the
+// component containing it. Components are numbered from zero, in the order in
+// which the graph enumerates their first vertex.
+//
+// Edge direction is ignored, so in digraphs and mixed graphs the components
+// found are the weakly connected components.
+//
+// Every vertex begins labeled with its own number. In each round, all vertices
+// adopt, in parallel, the least label among their own and their neighbors';
+// rounds continue until no label changes. The number of rounds is therefore
+// bounded by the diameter of the largest component.
+func ConnectedComponents(g gogl.Graph, workers int) (count int, components map[gogl.Vertex]int) {
+	workers = poolSize(workers)
+	ix := newIndex(g)
+	adj := ix.adjacency(g.AdjacentTo, workers)
+
+	labels := make([]int64, len(ix.vertices))
+	for i := range labels {
+		labels[i] = int64(i)
+	}
+
+	for changed := true; changed; {
+		var changes int64
+		each(len(labels), workers, func(_, i int) {
+			// Only this call writes vertex i's label, but its neighbors'
+			// labels may be written concurrently.
+			least := atomic.LoadInt64(&labels[i])
+			for _, j := range adj[i] {
+				if l := atomic.LoadInt64(&labels[j]); l < least {
+					least = l
+				}
+			}
+
+			if least < atomic.LoadInt64(&labels[i]) {
+				atomic.StoreInt64(&labels[i], least)
+				atomic.AddInt64(&changes, 1)
+			}
+		})
+		changed = changes > 0
+	}
+
+	// Each component is labeled with its least vertex number, which is also
+	// the first of its vertices to be enumerated.
+	numbers := make(map[int64]int)
+	components = make(map[gogl.Vertex]int, len(labels))
+	for i, l := range labels {
+		n, exists := numbers[l]
+		if !exists {
+			n = len(numbers)
+			numbers[l] = n
+		}
+		components[ix.vertices[i]] = n
+	}
+
+	return len(numbers), components
+}
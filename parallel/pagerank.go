@@ -0,0 +1,95 @@
+package parallel
+
+import (
+	"errors"
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// The most iterations PageRank will perform before returning, should the
+// ranks not yet have converged.
+const MaxPageRankIterations = 100
+
+// Computes the PageRank of each vertex in the given graph by power iteration,
+// returning a map from each vertex to its rank. The ranks sum to one.
+//
+// The damping factor is the probability that the random surfer follows an
+// edge, rather than jumping to a vertex chosen uniformly at random; 0.85 is
+// customary. Iteration stops once the ranks change by less than the given
+// tolerance, summed across all vertices, or after MaxPageRankIterations.
+//
+// Arcs carry rank only in their direction; undirected edges carry it both
+// ways. The rank of vertices with no out-edges is spread evenly across all
+// vertices.
+func PageRank(g gogl.Graph, damping, tolerance float64, workers int) (map[gogl.Vertex]float64, error) {
+	if damping < 0 || damping > 1 {
+		return nil, errors.New("Damping factor must be in the range [0.0,1.0].")
+	}
+	if tolerance <= 0 {
+		return nil, errors.New("Tolerance must be positive.")
+	}
+
+	workers = poolSize(workers)
+	ix := newIndex(g)
+	n := len(ix.vertices)
+	if n == 0 {
+		return map[gogl.Vertex]float64{}, nil
+	}
+
+	out := ix.adjacency(successors(g), workers)
+
+	// Ranks are pulled along in-edges, so invert the adjacency.
+	in := make([][]int, n)
+	for i, succ := range out {
+		for _, j := range succ {
+			in[j] = append(in[j], i)
+		}
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	prev := make([]float64, n)
+	deltas := make([]float64, workers)
+
+	for iter := 0; iter < MaxPageRankIterations; iter++ {
+		rank, prev = prev, rank
+
+		var dangling float64
+		for i, succ := range out {
+			if len(succ) == 0 {
+				dangling += prev[i]
+			}
+		}
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+
+		for w := range deltas {
+			deltas[w] = 0
+		}
+		each(n, workers, func(w, i int) {
+			r := base
+			for _, j := range in[i] {
+				r += damping * prev[j] / float64(len(out[j]))
+			}
+			rank[i] = r
+			deltas[w] += math.Abs(r - prev[i])
+		})
+
+		var delta float64
+		for _, d := range deltas {
+			delta += d
+		}
+		if delta < tolerance {
+			break
+		}
+	}
+
+	ranks := make(map[gogl.Vertex]float64, n)
+	for i, r := range rank {
+		ranks[ix.vertices[i]] = r
+	}
+
+	return ranks, nil
+}
@@ -0,0 +1,127 @@
+// Contains parallel implementations of graph algorithms, which spread their
+// work across a pool of goroutines.
+//
+// Each algorithm accepts a worker count; if it is less than one, GOMAXPROCS is
+// used. The algorithms only read from the graphs they are given, but do so
+// from several goroutines at once, so the graph's enumerators must be safe
+// for concurrent use. The graphs in the al package are: they guard their
+// reads with an RWMutex, so any number of readers may proceed together. The
+// graph must not be mutated while an algorithm is running.
+//
+// Before doing their work, the algorithms number the graph's vertices, so
+// that per-vertex state can be held in slices rather than shared maps. This
+// costs one enumeration of the graph's vertices, and memory proportional to
+// its order.
+package parallel
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Resolves a requested worker count to the number to actually use.
+func poolSize(workers int) int {
+	if workers < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// Calls the provided function once for each i in [0, n), spread across the
+// given number of workers. Indices are handed out in chunks, so that workers
+// that finish early take on more of the remaining work. Each call is also
+// passed the number of the worker making it, in [0, workers), so that callers
+// may keep per-worker state without locking.
+func each(n, workers int, f func(worker, i int)) {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			f(0, i)
+		}
+		return
+	}
+
+	chunk := n / (workers * 8)
+	if chunk < 1 {
+		chunk = 1
+	}
+
+	var next int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for {
+				lo := int(atomic.AddInt64(&next, int64(chunk))) - chunk
+				if lo >= n {
+					return
+				}
+				hi := lo + chunk
+				if hi > n {
+					hi = n
+				}
+				for i := lo; i < hi; i++ {
+					f(w, i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// A numbering of a graph's vertices.
+type index struct {
+	vertices []gogl.Vertex
+	ids      map[gogl.Vertex]int
+}
+
+func newIndex(g gogl.VertexEnumerator) *index {
+	ix := &index{
+		vertices: gogl.CollectVertices(g),
+	}
+
+	ix.ids = make(map[gogl.Vertex]int, len(ix.vertices))
+	for i, v := range ix.vertices {
+		ix.ids[v] = i
+	}
+
+	return ix
+}
+
+// Collects, in parallel, the numbers of the vertices passed by the provided
+// enumerator for each vertex in the index. Loops are discarded, as are
+// repeats of a neighbor reached along parallel edges.
+func (ix *index) adjacency(next func(gogl.Vertex, gogl.VertexStep), workers int) [][]int {
+	adj := make([][]int, len(ix.vertices))
+
+	each(len(ix.vertices), workers, func(_, i int) {
+		seen := make(map[int]struct{})
+		next(ix.vertices[i], func(v gogl.Vertex) (terminate bool) {
+			j := ix.ids[v]
+			if _, dup := seen[j]; !dup && j != i {
+				seen[j] = struct{}{}
+				adj[i] = append(adj[i], j)
+			}
+			return
+		})
+	})
+
+	return adj
+}
+
+// Picks the enumerator of vertices reachable in one step for the given graph.
+func successors(g gogl.Graph) func(gogl.Vertex, gogl.VertexStep) {
+	if dg, ok := g.(gogl.Digraph); ok {
+		return dg.SuccessorsOf
+	}
+	if mg, ok := g.(gogl.MixedGraph); ok {
+		return mg.SuccessorsOf
+	}
+	return g.AdjacentTo
+}
@@ -0,0 +1,160 @@
+package parallel
+
+import (
+	"math"
+	stdrand "math/rand"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/bfs"
+	"github.com/sdboyer/gogl/gen"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type ParallelSuite struct{}
+
+var _ = Suite(&ParallelSuite{})
+
+func build(src gogl.GraphSource) gogl.Graph {
+	spec := gogl.Spec().Using(src)
+	if _, ok := src.(gogl.DigraphSource); ok {
+		spec = spec.Directed()
+	}
+	return spec.Create(al.G)
+}
+
+func random(directed bool) gogl.Graph {
+	return build(rand.GNM(300, 600, directed, true, stdrand.NewSource(42)))
+}
+
+func (s *ParallelSuite) TestEach(c *C) {
+	for _, workers := range []int{1, 3, 64} {
+		hits := make([]int, 1000)
+		each(len(hits), workers, func(w, i int) {
+			c.Check(w < workers, Equals, true)
+			hits[i]++
+		})
+		for _, h := range hits {
+			c.Assert(h, Equals, 1)
+		}
+	}
+
+	each(0, 4, func(w, i int) {
+		c.Error("Called on an empty range.")
+	})
+}
+
+func (s *ParallelSuite) TestBFS(c *C) {
+	for _, directed := range []bool{false, true} {
+		g := random(directed)
+
+		expected := make(map[gogl.Vertex]int)
+		bfs.Traverse(g, func(v gogl.Vertex, depth int) (terminate bool) {
+			expected[v] = depth
+			return
+		}, 0)
+
+		for _, workers := range []int{0, 1, 4} {
+			depths, err := BFS(g, 0, workers)
+			c.Assert(err, IsNil)
+			c.Assert(depths, DeepEquals, expected)
+		}
+	}
+
+	_, err := BFS(random(false), "nope", 4)
+	c.Assert(err, ErrorMatches, "Start vertex.*")
+}
+
+func (s *ParallelSuite) TestConnectedComponents(c *C) {
+	el := gogl.EdgeList{
+		gogl.NewEdge("foo", "bar"),
+		gogl.NewEdge("bar", "baz"),
+		gogl.NewEdge("qux", "quark"),
+	}
+	g := gogl.Spec().Mutable().Using(el).Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex("isolate")
+
+	count, components := ConnectedComponents(g, 4)
+	c.Assert(count, Equals, 3)
+	c.Assert(components["foo"], Equals, components["baz"])
+	c.Assert(components["qux"], Equals, components["quark"])
+	c.Assert(components["foo"], Not(Equals), components["qux"])
+	c.Assert(components["isolate"], Not(Equals), components["qux"])
+
+	// direction is ignored
+	count, _ = ConnectedComponents(build(gen.Path(50).Directed()), 4)
+	c.Assert(count, Equals, 1)
+
+	count, components = ConnectedComponents(build(gen.Empty(10)), 4)
+	c.Assert(count, Equals, 10)
+	seen := make(map[int]bool)
+	for _, n := range components {
+		c.Assert(n >= 0 && n < 10 && !seen[n], Equals, true)
+		seen[n] = true
+	}
+}
+
+func (s *ParallelSuite) TestPageRank(c *C) {
+	// all vertices of a cycle are alike
+	ranks, err := PageRank(build(gen.Cycle(10).Directed()), 0.85, 1e-9, 4)
+	c.Assert(err, IsNil)
+	for _, r := range ranks {
+		c.Assert(math.Abs(r-0.1) < 1e-9, Equals, true)
+	}
+
+	// the hub of a star collects the most rank, including from dangling leaves
+	g := build(gen.Star(6))
+	ranks, err = PageRank(g, 0.85, 1e-9, 4)
+	c.Assert(err, IsNil)
+
+	var total float64
+	for v, r := range ranks {
+		total += r
+		if v != 0 {
+			c.Assert(r < ranks[0], Equals, true)
+		}
+	}
+	c.Assert(math.Abs(total-1) < 1e-9, Equals, true)
+
+	ranks, err = PageRank(build(gen.Star(6).Directed()), 0.85, 1e-9, 4)
+	c.Assert(err, IsNil)
+	total = 0
+	for _, r := range ranks {
+		total += r
+	}
+	c.Assert(math.Abs(total-1) < 1e-9, Equals, true)
+
+	_, err = PageRank(g, 1.5, 1e-9, 4)
+	c.Assert(err, ErrorMatches, "Damping factor.*")
+	_, err = PageRank(g, 0.85, 0, 4)
+	c.Assert(err, ErrorMatches, "Tolerance.*")
+}
+
+func (s *ParallelSuite) TestTriangles(c *C) {
+	c.Assert(Triangles(build(gen.Complete(6)), 4), Equals, 20)
+	c.Assert(Triangles(build(gen.Wheel(8)), 4), Equals, 7)
+	c.Assert(Triangles(build(gen.Petersen()), 4), Equals, 0)
+	c.Assert(Triangles(build(gen.Complete(5).Directed()), 4), Equals, 10)
+
+	// agrees with a brute force count
+	g := random(false)
+	var expected int
+	for u := 0; u < 300; u++ {
+		for v := u + 1; v < 300; v++ {
+			if !g.HasEdge(gogl.NewEdge(u, v)) {
+				continue
+			}
+			for w := v + 1; w < 300; w++ {
+				if g.HasEdge(gogl.NewEdge(u, w)) && g.HasEdge(gogl.NewEdge(v, w)) {
+					expected++
+				}
+			}
+		}
+	}
+	c.Assert(Triangles(g, 4), Equals, expected)
+}
@@ -0,0 +1,63 @@
+package parallel
+
+import (
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Counts the triangles in the given graph: the sets of three vertices that are
+// all adjacent to one another.
+//
+// Edge direction is ignored, as are loops and parallel edges; each triangle
+// is counted once.
+//
+// Each vertex's neighbors are narrowed to those numbered above it, so that
+// each triangle is found only from its least vertex; the vertices are then
+// examined in parallel, intersecting their narrowed neighbor lists.
+func Triangles(g gogl.Graph, workers int) int {
+	workers = poolSize(workers)
+	ix := newIndex(g)
+	adj := ix.adjacency(g.AdjacentTo, workers)
+
+	each(len(adj), workers, func(_, i int) {
+		higher := adj[i][:0]
+		for _, j := range adj[i] {
+			if j > i {
+				higher = append(higher, j)
+			}
+		}
+		sort.Ints(higher)
+		adj[i] = higher
+	})
+
+	counts := make([]int, workers)
+	each(len(adj), workers, func(w, u int) {
+		for _, v := range adj[u] {
+			counts[w] += intersection(adj[u], adj[v])
+		}
+	})
+
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
+
+// Returns the number of elements common to two sorted slices.
+func intersection(a, b []int) (n int) {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			n++
+			i++
+			j++
+		}
+	}
+	return
+}
@@ -0,0 +1,36 @@
+package rand
+
+import (
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Generates a random directed acyclic graph of vertex count n, with probability ρ of an arc existing from any
+// vertex to any higher-numbered one.
+//
+// Every arc points from a lower-numbered vertex to a higher-numbered one, so the vertices in ascending order are
+// always a valid topological sort of the result. The returned graph is a gogl.DigraphSource.
+//
+// ρ must be a float64 in the range [0.0,1.0], else panic.
+//
+// Stability behaves as it does for BernoulliDistribution. If no rand source is provided, the stdlib math's global
+// rand source is used.
+func RandomDAG(n uint, ρ float64, stable bool, src stdrand.Source) gogl.GraphSource {
+	if ρ < 0.0 || ρ > 1.0 {
+		panic("ρ must be in the range [0.0,1.0].")
+	}
+
+	order := int(n)
+	gen := func(r rnd, f func(u, v int) bool) {
+		for u := 0; u < order; u++ {
+			for v := u + 1; v < order; v++ {
+				if r.Float64() < ρ && f(u, v) {
+					return
+				}
+			}
+		}
+	}
+
+	return generated(order, gen, true, stable, src)
+}
@@ -0,0 +1,168 @@
+package rand
+
+import (
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// The subset of *math/rand.Rand's methods used by the generators in this
+// package. It is satisfied by *math/rand.Rand, and by globalRand, which defers
+// to math/rand's global source.
+type rnd interface {
+	Float64() float64
+	Intn(n int) int
+	Perm(n int) []int
+}
+
+type globalRand struct{}
+
+func (globalRand) Float64() float64 {
+	return stdrand.Float64()
+}
+
+func (globalRand) Intn(n int) int {
+	return stdrand.Intn(n)
+}
+
+func (globalRand) Perm(n int) []int {
+	return stdrand.Perm(n)
+}
+
+// Returns a rnd drawing from the provided source, or from math/rand's global
+// source if src is nil.
+func newRnd(src stdrand.Source) rnd {
+	if src == nil {
+		return globalRand{}
+	}
+	return stdrand.New(src)
+}
+
+// A generator runs a random graph model, passing the vertex pair of each edge it
+// produces to the step function until the model is exhausted or the step
+// function returns true. Pairs are ordered (tail, head) for directed models.
+type generator func(r rnd, f func(u, v int) (terminate bool))
+
+// Wraps a generator in the GraphSource types shared by all the models in this
+// package other than BernoulliDistribution.
+//
+// Stable graphs run the generator once, in full, on first enumeration, and
+// record the pairs it produces; unstable graphs rerun it on every enumeration.
+func generated(order int, gen generator, directed, stable bool, src stdrand.Source) gogl.GraphSource {
+	g := unstableGenerated{order: order, gen: gen, r: newRnd(src)}
+
+	if stable {
+		if directed {
+			return &stableGeneratedDigraph{stableGenerated{unstableGenerated: g}}
+		}
+		return &stableGenerated{unstableGenerated: g}
+	}
+
+	if directed {
+		return unstableGeneratedDigraph{g}
+	}
+	return g
+}
+
+type unstableGenerated struct {
+	order int
+	gen   generator
+	r     rnd
+}
+
+func (g unstableGenerated) Vertices(f gogl.VertexStep) {
+	for i := 0; i < g.order; i++ {
+		if f(i) {
+			return
+		}
+	}
+}
+
+func (g unstableGenerated) Edges(f gogl.EdgeStep) {
+	g.gen(g.r, func(u, v int) bool {
+		return f(gogl.NewEdge(u, v))
+	})
+}
+
+func (g unstableGenerated) Order() int {
+	return g.order
+}
+
+type unstableGeneratedDigraph struct {
+	unstableGenerated
+}
+
+func (g unstableGeneratedDigraph) Edges(f gogl.EdgeStep) {
+	g.gen(g.r, func(u, v int) bool {
+		return f(gogl.NewArc(u, v))
+	})
+}
+
+func (g unstableGeneratedDigraph) Arcs(f gogl.ArcStep) {
+	g.gen(g.r, func(u, v int) bool {
+		return f(gogl.NewArc(u, v))
+	})
+}
+
+type stableGenerated struct {
+	unstableGenerated
+	done  bool
+	pairs [][2]int
+}
+
+// Runs the generator to completion the first time it is called, then passes
+// each recorded pair to the step function.
+func (g *stableGenerated) each(f func(u, v int) bool) {
+	if !g.done {
+		g.gen(g.r, func(u, v int) bool {
+			g.pairs = append(g.pairs, [2]int{u, v})
+			return false
+		})
+		g.done = true
+	}
+
+	for _, p := range g.pairs {
+		if f(p[0], p[1]) {
+			return
+		}
+	}
+}
+
+func (g *stableGenerated) Edges(f gogl.EdgeStep) {
+	g.each(func(u, v int) bool {
+		return f(gogl.NewEdge(u, v))
+	})
+}
+
+func (g *stableGenerated) Size() int {
+	g.each(func(u, v int) bool {
+		return true
+	})
+	return len(g.pairs)
+}
+
+type stableGeneratedDigraph struct {
+	stableGenerated
+}
+
+func (g *stableGeneratedDigraph) Edges(f gogl.EdgeStep) {
+	g.each(func(u, v int) bool {
+		return f(gogl.NewArc(u, v))
+	})
+}
+
+func (g *stableGeneratedDigraph) Arcs(f gogl.ArcStep) {
+	g.each(func(u, v int) bool {
+		return f(gogl.NewArc(u, v))
+	})
+}
+
+// Reports whether the provided graph is one of this package's stable random
+// graphs.
+func isStable(g gogl.GraphSource) bool {
+	switch g.(type) {
+	case *stableGenerated, *stableGeneratedDigraph, *stableBernoulliGraph, *stableBernoulliDigraph:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package rand
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+)
+
+type GeneratorSuite struct{}
+
+var _ = Suite(&GeneratorSuite{})
+
+// Collects a graph's edges as int pairs, checking along the way that the graph
+// is simple.
+func pairsOf(c *C, g gogl.GraphSource) [][2]int {
+	var pairs [][2]int
+	seen := make(map[[2]int]bool)
+
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		uv, vv := e.Both()
+		u, v := uv.(int), vv.(int)
+		c.Assert(u, Not(Equals), v)
+
+		_, directed := e.(gogl.Arc)
+		if !directed && u > v {
+			u, v = v, u
+		}
+		c.Assert(seen[[2]int{u, v}], Equals, false)
+		seen[[2]int{u, v}] = true
+
+		pairs = append(pairs, [2]int{u, v})
+		return
+	})
+
+	return pairs
+}
+
+func degrees(pairs [][2]int, n int) []int {
+	deg := make([]int, n)
+	for _, p := range pairs {
+		deg[p[0]]++
+		deg[p[1]]++
+	}
+	return deg
+}
+
+func (s *GeneratorSuite) TestPairIndexing(c *C) {
+	k := 0
+	for u := 0; u < 7; u++ {
+		for v := u + 1; v < 7; v++ {
+			pu, pv := trianglePair(k, 7)
+			c.Assert([]int{pu, pv}, DeepEquals, []int{u, v})
+			k++
+		}
+	}
+
+	k = 0
+	for u := 0; u < 5; u++ {
+		for v := 0; v < 5; v++ {
+			if u != v {
+				pu, pv := squarePair(k, 5)
+				c.Assert([]int{pu, pv}, DeepEquals, []int{u, v})
+				k++
+			}
+		}
+	}
+}
+
+func (s *GeneratorSuite) TestGNM(c *C) {
+	src := stdrand.NewSource(1)
+
+	c.Assert(len(pairsOf(c, GNM(20, 30, false, false, src))), Equals, 30)
+	c.Assert(len(pairsOf(c, GNM(20, 30, true, false, src))), Equals, 30)
+	c.Assert(len(pairsOf(c, GNM(8, 28, false, false, src))), Equals, 28)
+	c.Assert(len(pairsOf(c, GNM(8, 56, true, false, nil))), Equals, 56)
+
+	c.Assert(func() { GNM(8, 29, false, false, nil) }, PanicMatches, "m must not exceed the number of possible edges.")
+}
+
+func (s *GeneratorSuite) TestBarabasiAlbert(c *C) {
+	pairs := pairsOf(c, BarabasiAlbert(50, 3, false, stdrand.NewSource(1)))
+	c.Assert(len(pairs), Equals, 3*47)
+
+	deg := degrees(pairs, 50)
+	for v := 3; v < 50; v++ {
+		c.Assert(deg[v] >= 3, Equals, true)
+	}
+
+	c.Assert(func() { BarabasiAlbert(5, 0, false, nil) }, PanicMatches, "m must be at least 1 and less than n.")
+	c.Assert(func() { BarabasiAlbert(5, 5, false, nil) }, PanicMatches, "m must be at least 1 and less than n.")
+}
+
+func (s *GeneratorSuite) TestWattsStrogatz(c *C) {
+	pairs := pairsOf(c, WattsStrogatz(20, 4, 0, false, nil))
+	c.Assert(len(pairs), Equals, 40)
+	for _, d := range degrees(pairs, 20) {
+		c.Assert(d, Equals, 4)
+	}
+	for _, p := range pairs {
+		diff := p[1] - p[0]
+		c.Assert(diff <= 2 || diff >= 18, Equals, true)
+	}
+
+	// Rewiring moves edges around, but never adds or removes them
+	c.Assert(len(pairsOf(c, WattsStrogatz(20, 4, 1, false, stdrand.NewSource(1)))), Equals, 40)
+
+	c.Assert(func() { WattsStrogatz(20, 3, 0.5, false, nil) }, PanicMatches, "k must be even and less than n.")
+	c.Assert(func() { WattsStrogatz(4, 4, 0.5, false, nil) }, PanicMatches, "k must be even and less than n.")
+	c.Assert(func() { WattsStrogatz(20, 4, 1.5, false, nil) }, PanicMatches, "β must be in the range \\[0\\.0,1\\.0\\].")
+}
+
+func (s *GeneratorSuite) TestRandomRegular(c *C) {
+	for _, d := range []int{0, 1, 3, 4} {
+		pairs := pairsOf(c, RandomRegular(20, uint(d), false, stdrand.NewSource(1)))
+		c.Assert(len(pairs), Equals, 20*d/2)
+		for _, deg := range degrees(pairs, 20) {
+			c.Assert(deg, Equals, d)
+		}
+	}
+
+	c.Assert(func() { RandomRegular(5, 3, false, nil) }, PanicMatches, "n\\*d must be even.")
+	c.Assert(func() { RandomRegular(4, 4, false, nil) }, PanicMatches, "d must be less than n.")
+}
+
+func (s *GeneratorSuite) TestStochasticBlockModel(c *C) {
+	p := [][]float64{{1, 0}, {0, 1}}
+
+	// Two disjoint cliques, of 3 and 4 vertices
+	pairs := pairsOf(c, StochasticBlockModel([]uint{3, 4}, p, false, false, nil))
+	c.Assert(len(pairs), Equals, 3+6)
+	for _, pair := range pairs {
+		c.Assert(pair[0] < 3, Equals, pair[1] < 3)
+	}
+
+	arcs := pairsOf(c, StochasticBlockModel([]uint{3, 4}, [][]float64{{0, 1}, {0, 0}}, true, false, nil))
+	c.Assert(len(arcs), Equals, 12)
+	for _, arc := range arcs {
+		c.Assert(arc[0] < 3 && arc[1] >= 3, Equals, true)
+	}
+
+	c.Assert(func() { StochasticBlockModel([]uint{3, 4}, [][]float64{{0, 1}, {0, 0}}, false, false, nil) },
+		PanicMatches, "p must be symmetric for an undirected graph.")
+	c.Assert(func() { StochasticBlockModel([]uint{3}, p, false, false, nil) }, PanicMatches, "p must have one row per block.")
+}
+
+func (s *GeneratorSuite) TestRandomDAG(c *C) {
+	g := RandomDAG(10, 1, false, nil)
+	c.Assert(g, Implements, new(gogl.DigraphSource))
+
+	arcs := pairsOf(c, g)
+	c.Assert(len(arcs), Equals, 45)
+
+	hit := 0
+	RandomDAG(30, 0.3, false, stdrand.NewSource(1)).(gogl.DigraphSource).Arcs(func(a gogl.Arc) (terminate bool) {
+		c.Assert(a.Source().(int) < a.Target().(int), Equals, true)
+		hit++
+		return
+	})
+	c.Assert(hit > 0, Equals, true)
+}
+
+func (s *GeneratorSuite) TestStability(c *C) {
+	graphs := map[string]gogl.GraphSource{
+		"gnm":     GNM(20, 40, true, true, nil),
+		"ba":      BarabasiAlbert(20, 2, true, nil),
+		"ws":      WattsStrogatz(20, 4, 0.5, true, nil),
+		"regular": RandomRegular(20, 3, true, nil),
+		"sbm":     StochasticBlockModel([]uint{10, 10}, [][]float64{{0.5, 0.1}, {0.1, 0.5}}, false, true, nil),
+		"dag":     RandomDAG(20, 0.3, true, nil),
+	}
+
+	for name, g := range graphs {
+		first := pairsOf(c, g)
+		c.Assert(pairsOf(c, g), DeepEquals, first, Commentf("%s", name))
+		c.Assert(g.(gogl.EdgeCounter).Size(), Equals, len(first), Commentf("%s", name))
+		c.Assert(gogl.Order(g), Equals, 20, Commentf("%s", name))
+	}
+
+	// Early termination must not truncate what a stable graph records
+	g := GNM(20, 40, false, true, nil)
+	g.Edges(func(e gogl.Edge) bool { return true })
+	c.Assert(len(pairsOf(c, g)), Equals, 40)
+}
+
+func (s *GeneratorSuite) TestSeeded(c *C) {
+	a := WattsStrogatz(30, 4, 0.3, false, stdrand.NewSource(42))
+	b := WattsStrogatz(30, 4, 0.3, false, stdrand.NewSource(42))
+	c.Assert(pairsOf(c, a), DeepEquals, pairsOf(c, b))
+
+	a = RandomRegular(30, 3, false, stdrand.NewSource(42))
+	b = RandomRegular(30, 3, false, stdrand.NewSource(42))
+	c.Assert(pairsOf(c, a), DeepEquals, pairsOf(c, b))
+}
+
+func (s *GeneratorSuite) TestTermination(c *C) {
+	hit := 0
+	BarabasiAlbert(20, 2, false, nil).Edges(func(e gogl.Edge) bool {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+
+	RandomDAG(10, 1, true, nil).(gogl.DigraphSource).Arcs(func(a gogl.Arc) bool {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 2)
+}
+
+func (s *GeneratorSuite) TestWeighted(c *C) {
+	g := Weighted(GNM(10, 20, false, true, nil), Uniform(2, 3, nil))
+	weights := make(map[gogl.Edge]float64)
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e, Implements, new(gogl.WeightedEdge))
+		w := e.(gogl.WeightedEdge).Weight()
+		c.Assert(w >= 2 && w < 3, Equals, true)
+
+		u, v := e.Both()
+		weights[gogl.NewEdge(u, v)] = w
+		return
+	})
+	c.Assert(len(weights), Equals, 20)
+
+	// Stable graphs get stable weights
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		c.Assert(e.(gogl.WeightedEdge).Weight(), Equals, weights[gogl.NewEdge(u, v)])
+		return
+	})
+
+	dg := Weighted(RandomDAG(10, 0.5, false, stdrand.NewSource(1)), Exponential(1, stdrand.NewSource(1)))
+	c.Assert(dg, Implements, new(gogl.DigraphSource))
+	dg.(gogl.DigraphSource).Arcs(func(a gogl.Arc) (terminate bool) {
+		c.Assert(a, Implements, new(gogl.WeightedArc))
+		c.Assert(a.(gogl.WeightedArc).Weight() >= 0, Equals, true)
+		return
+	})
+}
@@ -0,0 +1,83 @@
+package rand
+
+import (
+	"math"
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Generates a random graph of vertex count n with exactly m edges, chosen uniformly from among all possible
+// edges - the Erdős–Rényi G(n,m) model.
+//
+// This produces simple graphs only - no loops, no multiple edges. Graphs can be either directed or undirected,
+// governed by the appropriately named parameter. m may not exceed the number of possible edges, n(n-1)/2 for
+// undirected graphs and n(n-1) for directed graphs, else panic.
+//
+// Stability behaves as it does for BernoulliDistribution; a stable graph records its m edges the first time they
+// are enumerated. Either way, each generation requires O(m) memory to guarantee the edges are distinct.
+//
+// If no rand source is provided, the stdlib math's global rand source is used.
+func GNM(n, m uint, directed bool, stable bool, src stdrand.Source) gogl.GraphSource {
+	order := int(n)
+	max := order * (order - 1)
+	if !directed {
+		max /= 2
+	}
+	if int(m) > max {
+		panic("m must not exceed the number of possible edges.")
+	}
+
+	gen := func(r rnd, f func(u, v int) bool) {
+		// Floyd's algorithm: sample m distinct indices from [0,max) without
+		// materializing the whole range.
+		chosen := make(map[int]struct{}, m)
+		for j := max - int(m); j < max; j++ {
+			k := r.Intn(j + 1)
+			if _, exists := chosen[k]; exists {
+				k = j
+			}
+			chosen[k] = struct{}{}
+
+			var u, v int
+			if directed {
+				u, v = squarePair(k, order)
+			} else {
+				u, v = trianglePair(k, order)
+			}
+			if f(u, v) {
+				return
+			}
+		}
+	}
+
+	return generated(order, gen, directed, stable, src)
+}
+
+// Maps an index in [0,n(n-1)) to the ordered pair of distinct vertices it
+// represents, in row-major order.
+func squarePair(k, n int) (u, v int) {
+	u, v = k/(n-1), k%(n-1)
+	if v >= u {
+		v++
+	}
+	return
+}
+
+// Maps an index in [0,n(n-1)/2) to the pair of vertices u < v it represents,
+// in row-major order over the upper triangle.
+func trianglePair(k, n int) (u, v int) {
+	// Row u begins at index u(2n-u-1)/2; estimate u with the quadratic formula,
+	// then correct for any floating point error.
+	rowStart := func(u int) int { return u * (2*n - u - 1) / 2 }
+	fn := float64(2*n - 1)
+	u = int((fn - math.Sqrt(fn*fn-8*float64(k))) / 2)
+	for u > 0 && rowStart(u) > k {
+		u--
+	}
+	for rowStart(u+1) <= k {
+		u++
+	}
+
+	return u, u + 1 + k - rowStart(u)
+}
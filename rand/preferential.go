@@ -0,0 +1,56 @@
+package rand
+
+import (
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Generates a random undirected graph of vertex count n by Barabási–Albert preferential attachment.
+//
+// Vertices 0 through m-1 form the initial, edgeless graph. Each subsequent vertex is then attached, in order, to m
+// distinct existing vertices, chosen with probability proportional to their current degree (the first such vertex
+// attaches to all the initial ones). The result has m(n-m) edges and a scale-free degree distribution.
+//
+// This produces simple graphs only - no loops, no multiple edges. m must be at least 1 and less than n, else panic.
+//
+// Stability behaves as it does for BernoulliDistribution. If no rand source is provided, the stdlib math's global
+// rand source is used.
+func BarabasiAlbert(n, m uint, stable bool, src stdrand.Source) gogl.GraphSource {
+	if m < 1 || m >= n {
+		panic("m must be at least 1 and less than n.")
+	}
+
+	order, attach := int(n), int(m)
+	gen := func(r rnd, f func(u, v int) bool) {
+		targets := make([]int, attach)
+		for i := range targets {
+			targets[i] = i
+		}
+
+		// Each vertex appears here once per incident edge, so a uniform choice
+		// from it is a choice proportional to degree.
+		repeated := make([]int, 0, 2*attach*(order-attach))
+
+		for source := attach; source < order; source++ {
+			for _, t := range targets {
+				if f(t, source) {
+					return
+				}
+				repeated = append(repeated, t, source)
+			}
+
+			chosen := make(map[int]struct{}, attach)
+			targets = targets[:0]
+			for len(targets) < attach {
+				t := repeated[r.Intn(len(repeated))]
+				if _, exists := chosen[t]; !exists {
+					chosen[t] = struct{}{}
+					targets = append(targets, t)
+				}
+			}
+		}
+	}
+
+	return generated(order, gen, false, stable, src)
+}
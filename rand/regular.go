@@ -0,0 +1,126 @@
+package rand
+
+import (
+	stdrand "math/rand"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Generates a random undirected d-regular graph of vertex count n - that is, one in which every vertex has
+// exactly d neighbors.
+//
+// This produces simple graphs only - no loops, no multiple edges. n*d must be even and d must be less than n,
+// else panic.
+//
+// Graphs are generated with the algorithm of Steger and Wormald, which repeatedly pairs up the remaining free
+// "stubs" at random, restarting whenever it reaches a state that cannot be completed. Generation is fast for small
+// d, but the expected number of restarts grows quickly with it.
+//
+// Stability behaves as it does for BernoulliDistribution. If no rand source is provided, the stdlib math's global
+// rand source is used.
+func RandomRegular(n, d uint, stable bool, src stdrand.Source) gogl.GraphSource {
+	if (n*d)%2 != 0 {
+		panic("n*d must be even.")
+	}
+	if d >= n && d > 0 {
+		panic("d must be less than n.")
+	}
+
+	order, degree := int(n), int(d)
+	gen := func(r rnd, f func(u, v int) bool) {
+		var edges map[[2]int]struct{}
+		for edges == nil {
+			edges = tryRegular(r, order, degree)
+		}
+
+		// Emit in vertex order, rather than map order, so that a seeded source
+		// reproduces the same sequence of edges.
+		keys := make([][2]int, 0, len(edges))
+		for e := range edges {
+			keys = append(keys, e)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i][0] != keys[j][0] {
+				return keys[i][0] < keys[j][0]
+			}
+			return keys[i][1] < keys[j][1]
+		})
+
+		for _, e := range keys {
+			if f(e[0], e[1]) {
+				return
+			}
+		}
+	}
+
+	return generated(order, gen, false, stable, src)
+}
+
+// Makes a single attempt at pairing up stubs into a d-regular graph, returning
+// nil if it gets stuck.
+func tryRegular(r rnd, n, d int) map[[2]int]struct{} {
+	edges := make(map[[2]int]struct{}, n*d/2)
+
+	stubs := make([]int, 0, n*d)
+	for u := 0; u < n; u++ {
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, u)
+		}
+	}
+
+	for len(stubs) > 0 {
+		// Pair the stubs off at random, keeping the pairs that make valid new
+		// edges and returning the endpoints of the rest to the pool.
+		potential := make(map[int]int)
+		perm := r.Perm(len(stubs))
+		for i := 0; i+1 < len(perm); i += 2 {
+			u, v := stubs[perm[i]], stubs[perm[i+1]]
+			if u > v {
+				u, v = v, u
+			}
+
+			if _, exists := edges[[2]int{u, v}]; u != v && !exists {
+				edges[[2]int{u, v}] = struct{}{}
+			} else {
+				potential[u]++
+				potential[v]++
+			}
+		}
+
+		if !regularSuitable(edges, potential) {
+			return nil
+		}
+
+		// Rebuild in vertex order, rather than map order, so that a seeded source
+		// reproduces the same graph.
+		stubs = stubs[:0]
+		for u := 0; u < n; u++ {
+			for i := 0; i < potential[u]; i++ {
+				stubs = append(stubs, u)
+			}
+		}
+	}
+
+	return edges
+}
+
+// Reports whether at least one valid edge can still be made between the
+// vertices with free stubs, or there are no free stubs left.
+func regularSuitable(edges map[[2]int]struct{}, potential map[int]int) bool {
+	if len(potential) == 0 {
+		return true
+	}
+
+	for u := range potential {
+		for v := range potential {
+			if u < v {
+				if _, exists := edges[[2]int{u, v}]; !exists {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package rand
+
+import (
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Generates a random graph from a stochastic block model.
+//
+// The graph's vertices are partitioned into consecutive blocks, block i containing sizes[i] vertices; block 0 holds
+// vertices 0 through sizes[0]-1, and so on. An edge between a vertex in block i and one in block j then exists with
+// probability p[i][j]. With a single block, this is equivalent to BernoulliDistribution.
+//
+// This produces simple graphs only - no loops, no multiple edges. Graphs can be either directed or undirected,
+// governed by the appropriately named parameter. p must be a square matrix with one row per block and entries in the
+// range [0.0,1.0], and symmetric if the graph is undirected, else panic.
+//
+// Stability behaves as it does for BernoulliDistribution. If no rand source is provided, the stdlib math's global
+// rand source is used.
+func StochasticBlockModel(sizes []uint, p [][]float64, directed bool, stable bool, src stdrand.Source) gogl.GraphSource {
+	if len(p) != len(sizes) {
+		panic("p must have one row per block.")
+	}
+	for i, row := range p {
+		if len(row) != len(sizes) {
+			panic("p must have one column per block.")
+		}
+		for j, ρ := range row {
+			if ρ < 0.0 || ρ > 1.0 {
+				panic("Probabilities in p must be in the range [0.0,1.0].")
+			}
+			if !directed && ρ != p[j][i] {
+				panic("p must be symmetric for an undirected graph.")
+			}
+		}
+	}
+
+	var blocks []int
+	for i, size := range sizes {
+		for k := uint(0); k < size; k++ {
+			blocks = append(blocks, i)
+		}
+	}
+
+	order := len(blocks)
+	gen := func(r rnd, f func(u, v int) bool) {
+		for u := 0; u < order; u++ {
+			v := u + 1
+			if directed {
+				v = 0
+			}
+
+			for ; v < order; v++ {
+				if u != v && r.Float64() < p[blocks[u]][blocks[v]] && f(u, v) {
+					return
+				}
+			}
+		}
+	}
+
+	return generated(order, gen, directed, stable, src)
+}
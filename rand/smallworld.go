@@ -0,0 +1,89 @@
+package rand
+
+import (
+	stdrand "math/rand"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Generates a random undirected graph of vertex count n by the Watts–Strogatz small-world model.
+//
+// The vertices are first arranged in a ring, each connected to its k nearest neighbors (k/2 to either side). Each
+// of those edges is then, with probability β, rewired: its far endpoint is replaced by a vertex chosen uniformly at
+// random, avoiding loops and duplicate edges. β = 0 leaves the ring lattice intact; β = 1 approaches a random graph.
+//
+// This produces simple graphs only - no loops, no multiple edges. k must be even and less than n, and β must be in
+// the range [0.0,1.0], else panic.
+//
+// Stability behaves as it does for BernoulliDistribution; generating requires O(nk) memory in either case. If no
+// rand source is provided, the stdlib math's global rand source is used.
+func WattsStrogatz(n, k uint, β float64, stable bool, src stdrand.Source) gogl.GraphSource {
+	if k%2 != 0 || (k >= n && k > 0) {
+		panic("k must be even and less than n.")
+	}
+	if β < 0.0 || β > 1.0 {
+		panic("β must be in the range [0.0,1.0].")
+	}
+
+	order, half := int(n), int(k/2)
+	gen := func(r rnd, f func(u, v int) bool) {
+		adj := make([]map[int]struct{}, order)
+		for u := range adj {
+			adj[u] = make(map[int]struct{}, 2*half)
+		}
+		linked := func(u, v int) bool {
+			_, exists := adj[u][v]
+			return exists
+		}
+		link := func(u, v int) {
+			adj[u][v] = struct{}{}
+			adj[v][u] = struct{}{}
+		}
+
+		for j := 1; j <= half; j++ {
+			for u := 0; u < order; u++ {
+				link(u, (u+j)%order)
+			}
+		}
+
+		for j := 1; j <= half; j++ {
+			for u := 0; u < order; u++ {
+				if r.Float64() >= β || len(adj[u]) >= order-1 {
+					continue
+				}
+
+				w := r.Intn(order)
+				for w == u || linked(u, w) {
+					w = r.Intn(order)
+				}
+
+				v := (u + j) % order
+				delete(adj[u], v)
+				delete(adj[v], u)
+				link(u, w)
+			}
+		}
+
+		// Emit in vertex order, rather than map order, so that a seeded source
+		// reproduces the same sequence of edges.
+		var nbrs []int
+		for u, a := range adj {
+			nbrs = nbrs[:0]
+			for v := range a {
+				if u < v {
+					nbrs = append(nbrs, v)
+				}
+			}
+			sort.Ints(nbrs)
+
+			for _, v := range nbrs {
+				if f(u, v) {
+					return
+				}
+			}
+		}
+	}
+
+	return generated(order, gen, false, stable, src)
+}
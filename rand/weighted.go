@@ -0,0 +1,123 @@
+package rand
+
+import (
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// A Distribution draws a value from some random distribution each time it is
+// called. Weighted uses one to assign weights to edges.
+type Distribution func() float64
+
+// Returns a Distribution drawing uniformly from the range [min,max).
+//
+// If no rand source is provided, the stdlib math's global rand source is used.
+func Uniform(min, max float64, src stdrand.Source) Distribution {
+	r := newRnd(src)
+	return func() float64 {
+		return min + r.Float64()*(max-min)
+	}
+}
+
+// Returns a Distribution drawing from the normal distribution with the given
+// mean and standard deviation.
+//
+// If no rand source is provided, the stdlib math's global rand source is used.
+func Normal(mean, stddev float64, src stdrand.Source) Distribution {
+	if src == nil {
+		return func() float64 {
+			return stdrand.NormFloat64()*stddev + mean
+		}
+	}
+
+	r := stdrand.New(src)
+	return func() float64 {
+		return r.NormFloat64()*stddev + mean
+	}
+}
+
+// Returns a Distribution drawing from the exponential distribution with the
+// given mean.
+//
+// If no rand source is provided, the stdlib math's global rand source is used.
+func Exponential(mean float64, src stdrand.Source) Distribution {
+	if src == nil {
+		return func() float64 {
+			return stdrand.ExpFloat64() * mean
+		}
+	}
+
+	r := stdrand.New(src)
+	return func() float64 {
+		return r.ExpFloat64() * mean
+	}
+}
+
+// Wraps a random graph so that each of its edges carries a weight drawn from
+// the provided distribution. The returned graph yields gogl.WeightedEdge from
+// Edges() and, if the wrapped graph is a digraph, gogl.WeightedArc from Arcs().
+//
+// If g is one of this package's stable graphs, the weights are stable as well:
+// each edge's weight is drawn the first time it is seen, then remembered. For
+// any other graph, including unstable ones, fresh weights are drawn on every
+// enumeration.
+func Weighted(g gogl.GraphSource, weight Distribution) gogl.GraphSource {
+	w := weightedGraph{g: g, weight: weight}
+	if isStable(g) {
+		w.memo = make(map[[2]gogl.Vertex]float64)
+	}
+
+	if _, ok := g.(gogl.DigraphSource); ok {
+		return weightedDigraph{w}
+	}
+	return w
+}
+
+type weightedGraph struct {
+	g      gogl.GraphSource
+	weight Distribution
+	memo   map[[2]gogl.Vertex]float64
+}
+
+func (g weightedGraph) weigh(u, v gogl.Vertex) float64 {
+	if g.memo == nil {
+		return g.weight()
+	}
+
+	w, exists := g.memo[[2]gogl.Vertex{u, v}]
+	if !exists {
+		w = g.weight()
+		g.memo[[2]gogl.Vertex{u, v}] = w
+	}
+	return w
+}
+
+func (g weightedGraph) Vertices(f gogl.VertexStep) {
+	g.g.Vertices(f)
+}
+
+func (g weightedGraph) Edges(f gogl.EdgeStep) {
+	g.g.Edges(func(e gogl.Edge) bool {
+		u, v := e.Both()
+		if _, ok := e.(gogl.Arc); ok {
+			return f(gogl.NewWeightedArc(u, v, g.weigh(u, v)))
+		}
+		return f(gogl.NewWeightedEdge(u, v, g.weigh(u, v)))
+	})
+}
+
+func (g weightedGraph) Order() int {
+	return gogl.Order(g.g)
+}
+
+type weightedDigraph struct {
+	weightedGraph
+}
+
+func (g weightedDigraph) Arcs(f gogl.ArcStep) {
+	g.g.(gogl.DigraphSource).Arcs(func(a gogl.Arc) bool {
+		u, v := a.Both()
+		return f(gogl.NewWeightedArc(u, v, g.weigh(u, v)))
+	})
+}
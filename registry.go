@@ -0,0 +1,218 @@
+package gogl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/* Graph implementation registry and spec resolution */
+
+const (
+	gpDirectedness GraphProperties = G_UNDIRECTED | G_DIRECTED
+	gpEdgeType     GraphProperties = G_LABELED | G_WEIGHTED | G_DATA
+	gpMultiplicity GraphProperties = G_LOOPS | G_PARALLEL
+	gpMutability   GraphProperties = G_MUTABLE | G_PERSISTENT
+)
+
+// A GraphCreator produces a graph from a GraphSpec, or explains why it cannot.
+type GraphCreator func(GraphSpec) (Graph, error)
+
+// Indicates whether a graph implementation with these properties can be used
+// to fulfill a request for a graph with the provided properties.
+//
+// Directedness and mutability must match exactly; these determine which
+// interfaces the resulting graph implements. An implementation may, however,
+// offer more than was requested in the way of edge types (so long as some
+// type was requested - basic edges must be basic) and multiplicity, as those
+// only widen what the graph is capable of holding.
+func (gp GraphProperties) Satisfies(req GraphProperties) bool {
+	if gp&gpDirectedness != req&gpDirectedness {
+		return false
+	}
+
+	if gp&gpMutability != req&gpMutability {
+		return false
+	}
+
+	if req&gpEdgeType == 0 {
+		if gp&gpEdgeType != 0 {
+			return false
+		}
+	} else if gp&req&gpEdgeType != req&gpEdgeType {
+		return false
+	}
+
+	return gp&req&gpMultiplicity == req&gpMultiplicity
+}
+
+// Counts the edge type and multiplicity capabilities an implementation has
+// beyond what was requested. Lower is a better fit.
+func surplus(impl, req GraphProperties) (n int) {
+	for extra := (impl &^ req) & (gpEdgeType | gpMultiplicity); extra != 0; extra &= extra - 1 {
+		n++
+	}
+	return
+}
+
+// An UnsatisfiableSpecError is returned when no known graph implementation
+// can satisfy the properties described by a GraphSpec.
+type UnsatisfiableSpecError struct {
+	Props     GraphProperties   // The requested properties
+	Available []GraphProperties // The properties of all the considered implementations
+}
+
+func (e *UnsatisfiableSpecError) Error() string {
+	avail := make([]string, len(e.Available))
+	for k, gp := range e.Available {
+		avail[k] = gp.String()
+	}
+
+	return fmt.Sprintf("No graph implementation satisfies the spec %s; available implementations: [%s]", e.Props, strings.Join(avail, ", "))
+}
+
+type registration struct {
+	props    GraphProperties
+	f        GraphCreator
+	fallback bool
+}
+
+// A Registry collects graph implementations, keyed by the GraphProperties
+// they provide, and resolves GraphSpecs to the implementation that best
+// fits them.
+//
+// Resolution is deterministic: of all the registered implementations that
+// satisfy a spec, the one with the fewest surplus capabilities is chosen,
+// with ties broken in favor of the numerically lowest GraphProperties.
+//
+// The zero value is an empty Registry, ready for use. Registries are safe
+// for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	impls []registration
+}
+
+// DefaultRegistry is the registry used by the package-level Register and
+// Resolve functions. Graph implementation packages register themselves here,
+// typically in an init() function. gogl's own implementations register as
+// fallbacks, so other packages may register for the same properties.
+var DefaultRegistry = &Registry{}
+
+// Registers a graph creator as providing graphs with the given properties.
+//
+// Only one creator may be registered for any one set of properties; a second
+// registration for the same properties will panic, unless the first was made
+// with RegisterFallback, in which case it is replaced.
+func (r *Registry) Register(gp GraphProperties, f GraphCreator) {
+	r.register(registration{props: gp, f: f})
+}
+
+// Registers a graph creator as a fallback provider of graphs with the given
+// properties. A fallback is used only if no creator is registered for the
+// properties with Register, whether before or after the fallback. If several
+// fallbacks are registered for the same properties, the first is kept.
+func (r *Registry) RegisterFallback(gp GraphProperties, f GraphCreator) {
+	r.register(registration{props: gp, f: f, fallback: true})
+}
+
+func (r *Registry) register(add registration) {
+	if add.f == nil {
+		panic("Cannot register a nil GraphCreator.")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, reg := range r.impls {
+		if reg.props != add.props {
+			continue
+		}
+
+		switch {
+		case add.fallback:
+			return
+		case reg.fallback:
+			r.impls[k] = add
+			return
+		default:
+			panic(fmt.Sprintf("A GraphCreator is already registered for %s.", add.props))
+		}
+	}
+
+	r.impls = append(r.impls, add)
+	sort.Slice(r.impls, func(i, j int) bool {
+		return r.impls[i].props < r.impls[j].props
+	})
+}
+
+// Finds the registered implementation that best fits the given properties,
+// returning the properties that implementation was registered with along with
+// its creator.
+//
+// If no registered implementation satisfies the properties, an
+// *UnsatisfiableSpecError is returned.
+func (r *Registry) Resolve(req GraphProperties) (GraphProperties, GraphCreator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	best := -1
+	for k, reg := range r.impls {
+		if !reg.props.Satisfies(req) {
+			continue
+		}
+		// impls are kept sorted, so strict comparison preserves the lowest-value tiebreak
+		if best == -1 || surplus(reg.props, req) < surplus(r.impls[best].props, req) {
+			best = k
+		}
+	}
+
+	if best == -1 {
+		return 0, nil, &UnsatisfiableSpecError{Props: req, Available: r.available()}
+	}
+
+	return r.impls[best].props, r.impls[best].f, nil
+}
+
+// Creates a graph from the spec using the best-fitting registered implementation.
+//
+// This method has the signature expected by GraphSpec.TryCreate.
+func (r *Registry) Create(gs GraphSpec) (Graph, error) {
+	_, f, err := r.Resolve(gs.Props)
+	if err != nil {
+		return nil, err
+	}
+
+	return f(gs)
+}
+
+// Returns the properties of every implementation in the registry, in ascending order.
+func (r *Registry) Implementations() []GraphProperties {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.available()
+}
+
+func (r *Registry) available() []GraphProperties {
+	gps := make([]GraphProperties, len(r.impls))
+	for k, reg := range r.impls {
+		gps[k] = reg.props
+	}
+	return gps
+}
+
+// Registers a graph creator with the DefaultRegistry.
+func Register(gp GraphProperties, f GraphCreator) {
+	DefaultRegistry.Register(gp, f)
+}
+
+// Registers a fallback graph creator with the DefaultRegistry.
+func RegisterFallback(gp GraphProperties, f GraphCreator) {
+	DefaultRegistry.RegisterFallback(gp, f)
+}
+
+// Resolves the given properties against the DefaultRegistry.
+func Resolve(req GraphProperties) (GraphProperties, GraphCreator, error) {
+	return DefaultRegistry.Resolve(req)
+}
@@ -0,0 +1,132 @@
+package gogl
+
+import (
+	. "github.com/sdboyer/gocheck"
+)
+
+type RegistrySuite struct{}
+
+var _ = Suite(&RegistrySuite{})
+
+// Returns a creator that produces a NullGraph, marking which creator was hit.
+func markingCreator(hit *GraphProperties, gp GraphProperties) GraphCreator {
+	return func(gs GraphSpec) (Graph, error) {
+		*hit = gp
+		return NullGraph, nil
+	}
+}
+
+func (s *RegistrySuite) TestSatisfies(c *C) {
+	dbm := GraphProperties(G_DIRECTED | G_BASIC | G_SIMPLE | G_MUTABLE)
+
+	c.Assert(dbm.Satisfies(dbm), Equals, true)
+	c.Assert(dbm.Satisfies(Spec().Directed().Props), Equals, true)
+	// directedness and mutability must match
+	c.Assert(dbm.Satisfies(Spec().Props), Equals, false)
+	c.Assert(dbm.Satisfies(Spec().Directed().Immutable().Props), Equals, false)
+	// basic edges can't be provided by a typed edge graph, or vice versa
+	c.Assert(dbm.Satisfies(Spec().Directed().Weighted().Props), Equals, false)
+	c.Assert((dbm | G_WEIGHTED).Satisfies(Spec().Directed().Props), Equals, false)
+	// surplus edge types and multiplicity are fine
+	c.Assert((dbm | G_WEIGHTED | G_LABELED).Satisfies(Spec().Directed().Weighted().Props), Equals, true)
+	c.Assert((dbm | G_LOOPS).Satisfies(Spec().Directed().Props), Equals, true)
+	c.Assert(dbm.Satisfies(Spec().Directed().Loop().Props), Equals, false)
+}
+
+func (s *RegistrySuite) TestResolveBestFit(c *C) {
+	var hit GraphProperties
+	r := &Registry{}
+
+	base := GraphProperties(G_UNDIRECTED | G_SIMPLE | G_MUTABLE)
+	candidates := []GraphProperties{
+		base | G_WEIGHTED | G_LABELED | G_DATA,
+		base | G_WEIGHTED | G_LOOPS,
+		base | G_WEIGHTED | G_LABELED,
+		base | G_WEIGHTED | G_DATA,
+	}
+	for _, gp := range candidates {
+		r.Register(gp, markingCreator(&hit, gp))
+	}
+
+	// Repeat to catch any nondeterminism
+	for i := 0; i < 20; i++ {
+		// three candidates have one surplus capability; lowest value wins
+		gp, _, err := r.Resolve(Spec().Weighted().Props)
+		c.Assert(err, IsNil)
+		c.Assert(gp, Equals, base|G_WEIGHTED|G_LABELED)
+
+		gp, _, err = r.Resolve(Spec().Weighted().Loop().Props)
+		c.Assert(err, IsNil)
+		c.Assert(gp, Equals, base|G_WEIGHTED|G_LOOPS)
+	}
+
+	_, err := Spec().Weighted().DataEdges().TryCreate(r.Create)
+	c.Assert(err, IsNil)
+	c.Assert(hit, Equals, base|G_WEIGHTED|G_DATA)
+}
+
+func (s *RegistrySuite) TestUnsatisfiable(c *C) {
+	r := &Registry{}
+	r.Register(G_DIRECTED|G_BASIC|G_SIMPLE|G_MUTABLE, markingCreator(new(GraphProperties), 0))
+
+	g, err := Spec().Directed().PseudoGraph().TryCreate(r.Create)
+	c.Assert(g, IsNil)
+	c.Assert(err, FitsTypeOf, &UnsatisfiableSpecError{})
+	c.Assert(err, ErrorMatches, "No graph implementation satisfies the spec G_DIRECTED\\|G_BASIC\\|G_LOOPS\\|G_PARALLEL\\|G_MUTABLE; available implementations: \\[G_DIRECTED\\|G_BASIC\\|G_SIMPLE\\|G_MUTABLE\\]")
+}
+
+func (s *RegistrySuite) TestDuplicateRegistration(c *C) {
+	r := &Registry{}
+	f := markingCreator(new(GraphProperties), 0)
+	r.Register(G_DIRECTED|G_BASIC|G_SIMPLE|G_MUTABLE, f)
+
+	c.Assert(func() {
+		r.Register(G_DIRECTED|G_BASIC|G_SIMPLE|G_MUTABLE, f)
+	}, PanicMatches, "A GraphCreator is already registered for .*")
+	c.Assert(r.Implementations(), HasLen, 1)
+}
+
+func (s *RegistrySuite) TestFallbackRegistration(c *C) {
+	var hit GraphProperties
+	gp := GraphProperties(G_DIRECTED | G_BASIC | G_SIMPLE | G_MUTABLE)
+	spec := Spec().Directed()
+
+	// a later Register displaces a fallback
+	r := &Registry{}
+	r.RegisterFallback(gp, markingCreator(&hit, 1))
+	r.RegisterFallback(gp, markingCreator(&hit, 2))
+	spec.TryCreate(r.Create)
+	c.Assert(hit, Equals, GraphProperties(1))
+
+	r.Register(gp, markingCreator(&hit, 3))
+	spec.TryCreate(r.Create)
+	c.Assert(hit, Equals, GraphProperties(3))
+	c.Assert(r.Implementations(), HasLen, 1)
+
+	// an earlier one is not displaced
+	r = &Registry{}
+	r.Register(gp, markingCreator(&hit, 4))
+	r.RegisterFallback(gp, markingCreator(&hit, 5))
+	spec.TryCreate(r.Create)
+	c.Assert(hit, Equals, GraphProperties(4))
+}
+
+func (s *RegistrySuite) TestPropertiesString(c *C) {
+	c.Assert(GraphProperties(0).String(), Equals, "0")
+	c.Assert(Spec().Directed().Weighted().Props.String(), Equals, "G_DIRECTED|G_WEIGHTED|G_SIMPLE|G_MUTABLE")
+	c.Assert(GraphProperties(1<<15|G_DIRECTED).String(), Equals, "G_DIRECTED|0x8000")
+}
+
+func (s *RegistrySuite) TestPropertiesText(c *C) {
+	for _, gp := range []GraphProperties{0, Spec().Directed().Weighted().Props, G_PERSISTENT | G_LOOPS, 1<<15 | G_DIRECTED} {
+		text, err := gp.MarshalText()
+		c.Assert(err, IsNil)
+
+		var parsed GraphProperties
+		c.Assert(parsed.UnmarshalText(text), IsNil)
+		c.Assert(parsed, Equals, gp)
+	}
+
+	var gp GraphProperties
+	c.Assert(gp.UnmarshalText([]byte("G_DIRECTED|G_SQUIGGLY")), ErrorMatches, `Unrecognized graph property "G_SQUIGGLY".`)
+}
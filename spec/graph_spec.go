@@ -69,6 +69,29 @@ func (el loopArcList) Edges(fn EdgeStep) {
 	}
 }
 
+// An arc list with attributes attached to its vertices.
+type attrArcList struct {
+	ArcList
+	data    map[Vertex]interface{}
+	labels  map[Vertex]string
+	weights map[Vertex]float64
+}
+
+func (el attrArcList) VertexData(v Vertex) (data interface{}, exists bool) {
+	data, exists = el.data[v]
+	return
+}
+
+func (el attrArcList) VertexLabel(v Vertex) (label string, exists bool) {
+	label, exists = el.labels[v]
+	return
+}
+
+func (el attrArcList) VertexWeight(v Vertex) (weight float64, exists bool) {
+	weight, exists = el.weights[v]
+	return
+}
+
 var GraphFixtures = map[string]GraphSource{
 	// TODO improve naming basis/patterns for these
 	"arctest": ArcList{
@@ -107,6 +130,25 @@ var GraphFixtures = map[string]GraphSource{
 		NewDataArc(1, 2, "foo"),
 		NewDataArc(2, 3, struct{ a int }{a: 2}),
 	},
+	"p-2e3v": PropertyArcList{
+		NewPropertyArc(1, 2, 5.23, "foo", "bar"),
+		NewPropertyArc(2, 3, 5.821, "baz", 42),
+	},
+	"va-2e3v": attrArcList{
+		ArcList: ArcList{
+			NewArc("foo", "bar"),
+			NewArc("bar", "baz"),
+		},
+		data:    map[Vertex]interface{}{"foo": struct{ a int }{a: 2}},
+		labels:  map[Vertex]string{"foo": "start", "bar": "middle"},
+		weights: map[Vertex]float64{"baz": 5.23},
+	},
+	// Arcs and undirected edges together, distinguished by type
+	"mixed": EdgeList{
+		NewArc("foo", "bar"),
+		NewEdge("bar", "baz"),
+		NewArc("baz", "qux"),
+	},
 }
 
 /////////////////////////////////////////////////////////////////////
@@ -150,6 +192,12 @@ func SetUpTestsFromSpec(gp GraphProperties, fn graphFactory) bool {
 		Suite(&DigraphSuite{Factory: fact})
 	}
 
+	// Mixed graphs import arc-only fixtures as arcs, so they behave directionally
+	if _, ok := g.(MixedGraph); ok {
+		directed = true
+		Suite(&MixedGraphSuite{Factory: fact})
+	}
+
 	// Set up the basic Graph suite unconditionally
 	Suite(&GraphSuite{fact, directed})
 
@@ -169,6 +217,14 @@ func SetUpTestsFromSpec(gp GraphProperties, fn graphFactory) bool {
 		Suite(&ArcSetMutatorSuite{fact})
 	}
 
+	if _, ok := g.(VertexAttributeSource); ok {
+		Suite(&VertexAttributeSourceSuite{fact})
+	}
+
+	if _, ok := g.(VertexAttributes); ok {
+		Suite(&VertexAttributesSuite{fact})
+	}
+
 	if _, ok := g.(WeightedGraph); ok {
 		wfact := func(gs GraphSource) WeightedGraph {
 			return fact(gs).(WeightedGraph)
@@ -223,5 +279,23 @@ func SetUpTestsFromSpec(gp GraphProperties, fn graphFactory) bool {
 		}
 	}
 
+	if _, ok := g.(PropertyGraph); ok {
+		wfact := func(gs GraphSource) PropertyGraph {
+			return fact(gs).(PropertyGraph)
+		}
+
+		Suite(&PropertyGraphSuite{wfact})
+
+		if _, ok := g.(PropertyDigraph); ok {
+			Suite(&PropertyDigraphSuite{wfact})
+		}
+		if _, ok := g.(PropertyEdgeSetMutator); ok {
+			Suite(&PropertyEdgeSetMutatorSuite{wfact})
+		}
+		if _, ok := g.(PropertyArcSetMutator); ok {
+			Suite(&PropertyArcSetMutatorSuite{wfact})
+		}
+	}
+
 	return true
 }
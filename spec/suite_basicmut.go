@@ -153,7 +153,7 @@ func (s *ArcSetMutatorSuite) TestGracefulEmptyVariadics(c *C) {
 }
 
 func (s *ArcSetMutatorSuite) TestAddRemoveHasArc(c *C) {
-	g := s.Factory(NullGraph).(Digraph)
+	g := s.Factory(NullGraph).(ArcMembershipChecker)
 	m := g.(ArcSetMutator)
 
 	m.AddArcs(NewArc(1, 2))
@@ -167,7 +167,7 @@ func (s *ArcSetMutatorSuite) TestAddRemoveHasArc(c *C) {
 }
 
 func (s *ArcSetMutatorSuite) TestMultiAddRemoveHasArc(c *C) {
-	g := s.Factory(NullGraph).(Digraph)
+	g := s.Factory(NullGraph).(ArcMembershipChecker)
 	m := g.(ArcSetMutator)
 
 	m.AddArcs(NewArc(1, 2), NewArc(2, 3))
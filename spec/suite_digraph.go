@@ -28,6 +28,10 @@ func (s *DigraphSuite) TestTranspose(c *C) {
 
 	c.Assert(g2.HasArc(GraphFixtures["2e3v"].(ArcList)[0]), Equals, false)
 	c.Assert(g2.HasArc(GraphFixtures["2e3v"].(ArcList)[1]), Equals, false)
+
+	// empty graphs can be transposed, too
+	empty := s.Factory(NullGraph).(Digraph).Transpose()
+	c.Assert(Order(empty), Equals, 0)
 }
 
 func (s *DigraphSuite) TestOutDegreeOf(c *C) {
@@ -0,0 +1,180 @@
+package spec
+
+import (
+	"fmt"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+	"gopkg.in/fatih/set.v0"
+)
+
+/* MixedGraphSuite - tests for graphs with both arcs and undirected edges */
+
+type MixedGraphSuite struct {
+	Factory func(GraphSource) Graph
+}
+
+func (s *MixedGraphSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+func (s *MixedGraphSuite) TestEdgesDistinguishArcs(c *C) {
+	g := s.Factory(GraphFixtures["mixed"]).(MixedGraph)
+
+	var arcs, edges int
+	g.Edges(func(e Edge) (terminate bool) {
+		if a, ok := e.(Arc); ok {
+			arcs++
+			c.Assert(a.Source() == "foo" || a.Source() == "baz", Equals, true)
+		} else {
+			edges++
+		}
+		return
+	})
+
+	c.Assert(arcs, Equals, 2)
+	c.Assert(edges, Equals, 1)
+	c.Assert(Size(g), Equals, 3)
+	c.Assert(Order(g), Equals, 4)
+}
+
+func (s *MixedGraphSuite) TestArcsAndUndirectedEdges(c *C) {
+	g := s.Factory(GraphFixtures["mixed"]).(MixedGraph)
+
+	aset := set.New(set.NonThreadSafe)
+	g.Arcs(func(a Arc) (terminate bool) {
+		aset.Add(NewArc(a.Both()))
+		return
+	})
+
+	c.Assert(aset.Size(), Equals, 2)
+	c.Assert(aset.Has(NewArc("foo", "bar")), Equals, true)
+	c.Assert(aset.Has(NewArc("baz", "qux")), Equals, true)
+
+	var hit int
+	g.UndirectedEdges(func(e Edge) (terminate bool) {
+		hit++
+		_, isArc := e.(Arc)
+		c.Assert(isArc, Equals, false)
+		u, v := e.Both()
+		c.Assert((u == "bar" && v == "baz") || (u == "baz" && v == "bar"), Equals, true)
+		return
+	})
+	c.Assert(hit, Equals, 1)
+
+	hit = 0
+	g.UndirectedIncidentTo("foo", func(e Edge) (terminate bool) {
+		hit++
+		return
+	})
+	c.Assert(hit, Equals, 0)
+
+	g.UndirectedIncidentTo("baz", func(e Edge) (terminate bool) {
+		hit++
+		return
+	})
+	c.Assert(hit, Equals, 1)
+}
+
+func (s *MixedGraphSuite) TestMembership(c *C) {
+	g := s.Factory(GraphFixtures["mixed"]).(MixedGraph)
+
+	c.Assert(g.HasArc(NewArc("foo", "bar")), Equals, true)
+	c.Assert(g.HasArc(NewArc("bar", "foo")), Equals, false)
+	c.Assert(g.HasArc(NewArc("bar", "baz")), Equals, false)
+
+	// HasEdge considers both kinds of member, in either direction
+	c.Assert(g.HasEdge(NewEdge("bar", "foo")), Equals, true)
+	c.Assert(g.HasEdge(NewEdge("baz", "bar")), Equals, true)
+	c.Assert(g.HasEdge(NewEdge("foo", "qux")), Equals, false)
+}
+
+func (s *MixedGraphSuite) TestDegreeOf(c *C) {
+	g := s.Factory(GraphFixtures["mixed"]).(MixedGraph)
+
+	count, exists := g.DegreeOf("bar")
+	c.Assert(exists, Equals, true)
+	c.Assert(count, Equals, 2)
+
+	count, exists = g.DegreeOf("qux")
+	c.Assert(exists, Equals, true)
+	c.Assert(count, Equals, 1)
+
+	count, exists = g.DegreeOf("missing")
+	c.Assert(exists, Equals, false)
+	c.Assert(count, Equals, 0)
+}
+
+func (s *MixedGraphSuite) TestIncidentTo(c *C) {
+	g := s.Factory(GraphFixtures["mixed"]).(MixedGraph)
+
+	var arcs, edges int
+	g.IncidentTo("baz", func(e Edge) (terminate bool) {
+		if _, ok := e.(Arc); ok {
+			arcs++
+		} else {
+			edges++
+		}
+		return
+	})
+
+	c.Assert(arcs, Equals, 1)
+	c.Assert(edges, Equals, 1)
+}
+
+func (s *MixedGraphSuite) TestProcession(c *C) {
+	g := s.Factory(GraphFixtures["mixed"]).(MixedGraph)
+
+	collect := func(f func(Vertex, VertexStep), v Vertex) set.Interface {
+		vset := set.New(set.NonThreadSafe)
+		f(v, func(v Vertex) (terminate bool) {
+			vset.Add(v)
+			return
+		})
+		return vset
+	}
+
+	// Arcs only in their direction, undirected edges either way
+	succ := collect(g.SuccessorsOf, "bar")
+	c.Assert(succ.Size(), Equals, 1)
+	c.Assert(succ.Has("baz"), Equals, true)
+
+	succ = collect(g.SuccessorsOf, "baz")
+	c.Assert(succ.Size(), Equals, 2)
+	c.Assert(succ.Has("bar"), Equals, true)
+	c.Assert(succ.Has("qux"), Equals, true)
+
+	c.Assert(collect(g.SuccessorsOf, "qux").Size(), Equals, 0)
+
+	pred := collect(g.PredecessorsOf, "bar")
+	c.Assert(pred.Size(), Equals, 2)
+	c.Assert(pred.Has("foo"), Equals, true)
+	c.Assert(pred.Has("baz"), Equals, true)
+
+	c.Assert(collect(g.PredecessorsOf, "foo").Size(), Equals, 0)
+}
+
+func (s *MixedGraphSuite) TestMemberExclusivity(c *C) {
+	g, ok := s.Factory(NullGraph).(MutableMixedGraph)
+	if !ok {
+		c.Skip("Graph is not mutable.")
+	}
+
+	g.AddEdges(NewEdge(1, 2))
+	g.AddArcs(NewArc(1, 2), NewArc(2, 1))
+	c.Assert(Size(g), Equals, 1)
+	c.Assert(g.HasArc(NewArc(1, 2)), Equals, false)
+
+	// Removing an arc leaves undirected edges alone, and vice versa
+	g.AddArcs(NewArc(2, 3), NewArc(3, 2))
+	g.RemoveArcs(NewArc(1, 2))
+	g.RemoveEdges(NewEdge(2, 3))
+	c.Assert(Size(g), Equals, 3)
+
+	g.AddEdges(NewEdge(3, 2))
+	c.Assert(Size(g), Equals, 3)
+
+	g.RemoveVertex(2)
+	c.Assert(Size(g), Equals, 0)
+	c.Assert(Order(g), Equals, 2)
+}
@@ -0,0 +1,196 @@
+package spec
+
+import (
+	"fmt"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+)
+
+/* PropertyGraphSuite - tests for property graphs */
+
+type PropertyGraphSuite struct {
+	Factory func(GraphSource) PropertyGraph
+}
+
+func (s *PropertyGraphSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+func (s *PropertyGraphSuite) TestEdges(c *C) {
+	// This method is not redundant with the base Graph suite as it ensures that the edges
+	// provided by the Edges() iterator actually do implement PropertyEdge.
+	g := s.Factory(GraphFixtures["p-2e3v"])
+
+	var pe PropertyEdge
+	g.Edges(func(e Edge) (terminate bool) {
+		c.Assert(e, Implements, &pe)
+		return
+	})
+}
+
+func (s *PropertyGraphSuite) TestHasPropertyEdge(c *C) {
+	g := s.Factory(GraphFixtures["p-2e3v"])
+
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(1, 2, 5.23, "foo", "bar")), Equals, true)
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(2, 1, 5.23, "foo", "bar")), Equals, true)  // both directions work
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(1, 2, 5.23, "foo", "qux")), Equals, false) // wrong data
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(1, 2, 5.23, "qux", "bar")), Equals, false) // wrong label
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(1, 2, 1.23, "foo", "bar")), Equals, false) // wrong weight
+}
+
+func (s *PropertyGraphSuite) TestHasSinglePropertyEdge(c *C) {
+	g := s.Factory(GraphFixtures["p-2e3v"])
+
+	// Edges carrying only one property are matched only on that property
+	c.Assert(g.HasWeightedEdge(NewWeightedEdge(1, 2, 5.23)), Equals, true)
+	c.Assert(g.HasWeightedEdge(NewWeightedEdge(1, 2, 1.23)), Equals, false)
+	c.Assert(g.HasLabeledEdge(NewLabeledEdge(2, 3, "baz")), Equals, true)
+	c.Assert(g.HasLabeledEdge(NewLabeledEdge(2, 3, "foo")), Equals, false)
+	c.Assert(g.HasDataEdge(NewDataEdge(1, 2, "bar")), Equals, true)
+	c.Assert(g.HasDataEdge(NewDataEdge(1, 2, "qux")), Equals, false)
+
+	// ...but edges carrying more are matched on all of them
+	c.Assert(g.HasWeightedEdge(NewPropertyEdge(1, 2, 5.23, "foo", "bar")), Equals, true)
+	c.Assert(g.HasWeightedEdge(NewPropertyEdge(1, 2, 5.23, "qux", "bar")), Equals, false)
+}
+
+func (s *PropertyGraphSuite) TestPartialSourceEdges(c *C) {
+	// Properties not carried by the source edges take their zero value
+	g := s.Factory(GraphFixtures["w-2e3v"])
+
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(1, 2, 5.23, "", nil)), Equals, true)
+	c.Assert(g.HasLabeledEdge(NewLabeledEdge(1, 2, "")), Equals, true)
+}
+
+type PropertyDigraphSuite struct {
+	Factory func(GraphSource) PropertyGraph
+}
+
+func (s *PropertyDigraphSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+func (s *PropertyDigraphSuite) TestArcSubtypeImplementation(c *C) {
+	// This method is not redundant with the base Graph suite as it ensures that the edges
+	// provided by the Arcs() iterator actually do implement PropertyArc.
+	g := s.Factory(GraphFixtures["p-2e3v"]).(PropertyDigraph)
+
+	var hit int // just internal safety check to ensure the fixture is good and hits
+	var pa PropertyArc
+	g.Arcs(func(e Arc) (terminate bool) {
+		hit++
+		c.Assert(e, Implements, &pa)
+		return
+	})
+
+	g.ArcsFrom(2, func(e Arc) (terminate bool) {
+		hit++
+		c.Assert(e, Implements, &pa)
+		return
+	})
+
+	g.ArcsTo(2, func(e Arc) (terminate bool) {
+		hit++
+		c.Assert(e, Implements, &pa)
+		return
+	})
+
+	c.Assert(hit, Equals, 4)
+}
+
+func (s *PropertyDigraphSuite) TestHasPropertyArc(c *C) {
+	g := s.Factory(GraphFixtures["p-2e3v"]).(PropertyDigraph)
+
+	c.Assert(g.HasPropertyArc(NewPropertyArc(1, 2, 5.23, "foo", "bar")), Equals, true)
+	c.Assert(g.HasPropertyArc(NewPropertyArc(2, 1, 5.23, "foo", "bar")), Equals, false) // wrong direction
+	c.Assert(g.HasPropertyArc(NewPropertyArc(1, 2, 5.23, "foo", "qux")), Equals, false) // wrong data
+	c.Assert(g.HasWeightedArc(NewWeightedArc(1, 2, 5.23)), Equals, true)
+	c.Assert(g.HasLabeledArc(NewLabeledArc(2, 3, "baz")), Equals, true)
+	c.Assert(g.HasDataArc(NewDataArc(2, 3, 42)), Equals, true)
+	c.Assert(g.HasDataArc(NewDataArc(2, 3, 43)), Equals, false)
+}
+
+func (s *PropertyDigraphSuite) TestTranspose(c *C) {
+	g := s.Factory(GraphFixtures["p-2e3v"]).(PropertyDigraph)
+
+	g2 := g.Transpose().(PropertyDigraph)
+	c.Assert(g2.HasPropertyArc(NewPropertyArc(2, 1, 5.23, "foo", "bar")), Equals, true)
+	c.Assert(g2.HasPropertyArc(NewPropertyArc(1, 2, 5.23, "foo", "bar")), Equals, false)
+
+	// empty graphs can be transposed, too
+	empty := s.Factory(NullGraph).(PropertyDigraph).Transpose()
+	c.Assert(Order(empty), Equals, 0)
+}
+
+/* PropertyEdgeSetMutatorSuite - tests for mutable property graphs */
+
+type PropertyEdgeSetMutatorSuite struct {
+	Factory func(GraphSource) PropertyGraph
+}
+
+func (s *PropertyEdgeSetMutatorSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+func (s *PropertyEdgeSetMutatorSuite) TestGracefulEmptyVariadics(c *C) {
+	g := s.Factory(NullGraph)
+	m := g.(PropertyEdgeSetMutator)
+
+	m.AddEdges()
+	c.Assert(Order(g), Equals, 0)
+	c.Assert(Size(g), Equals, 0)
+
+	m.RemoveEdges()
+	c.Assert(Order(g), Equals, 0)
+	c.Assert(Size(g), Equals, 0)
+}
+
+func (s *PropertyEdgeSetMutatorSuite) TestAddRemoveEdge(c *C) {
+	g := s.Factory(NullGraph)
+	m := g.(PropertyEdgeSetMutator)
+
+	m.AddEdges(NewPropertyEdge(1, 2, 5.23, "foo", "bar"))
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(1, 2, 5.23, "foo", "bar")), Equals, true)
+
+	// Now test removal
+	m.RemoveEdges(NewPropertyEdge(1, 2, 5.23, "foo", "bar"))
+	c.Assert(g.HasEdge(NewEdge(1, 2)), Equals, false)
+	c.Assert(g.HasPropertyEdge(NewPropertyEdge(1, 2, 5.23, "foo", "bar")), Equals, false)
+}
+
+/* PropertyArcSetMutatorSuite - tests for mutable property digraphs */
+
+type PropertyArcSetMutatorSuite struct {
+	Factory func(GraphSource) PropertyGraph
+}
+
+func (s *PropertyArcSetMutatorSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+func (s *PropertyArcSetMutatorSuite) TestGracefulEmptyVariadics(c *C) {
+	g := s.Factory(NullGraph).(PropertyDigraph)
+	m := g.(PropertyArcSetMutator)
+
+	m.AddArcs()
+	c.Assert(Order(g), Equals, 0)
+	c.Assert(Size(g), Equals, 0)
+
+	m.RemoveArcs()
+	c.Assert(Order(g), Equals, 0)
+	c.Assert(Size(g), Equals, 0)
+}
+
+func (s *PropertyArcSetMutatorSuite) TestAddRemoveHasArc(c *C) {
+	g := s.Factory(NullGraph).(PropertyDigraph)
+	m := g.(PropertyArcSetMutator)
+
+	m.AddArcs(NewPropertyArc(1, 2, 5.23, "foo", "bar"))
+	c.Assert(g.HasPropertyArc(NewPropertyArc(1, 2, 5.23, "foo", "bar")), Equals, true)
+	c.Assert(g.HasPropertyArc(NewPropertyArc(1, 2, 5.23, "foo", "qux")), Equals, false) // wrong data
+
+	// Now test removal
+	m.RemoveArcs(NewPropertyArc(1, 2, 5.23, "foo", "bar"))
+	c.Assert(g.HasPropertyArc(NewPropertyArc(1, 2, 5.23, "foo", "bar")), Equals, false)
+}
@@ -0,0 +1,158 @@
+package spec
+
+import (
+	"fmt"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+)
+
+/* Suites for vertex attribute methods */
+
+type VertexAttributeSourceSuite struct {
+	Factory func(GraphSource) Graph
+}
+
+func (s *VertexAttributeSourceSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+func assertFixtureVertexAttrs(c *C, g VertexAttributeSource) {
+	data, exists := g.VertexData("foo")
+	c.Assert(exists, Equals, true)
+	c.Assert(data, Equals, struct{ a int }{a: 2})
+
+	_, exists = g.VertexData("bar")
+	c.Assert(exists, Equals, false)
+
+	label, exists := g.VertexLabel("bar")
+	c.Assert(exists, Equals, true)
+	c.Assert(label, Equals, "middle")
+
+	_, exists = g.VertexLabel("baz")
+	c.Assert(exists, Equals, false)
+
+	weight, exists := g.VertexWeight("baz")
+	c.Assert(exists, Equals, true)
+	c.Assert(weight, Equals, 5.23)
+
+	_, exists = g.VertexWeight("foo")
+	c.Assert(exists, Equals, false)
+}
+
+func (s *VertexAttributeSourceSuite) TestCopiedFromSource(c *C) {
+	assertFixtureVertexAttrs(c, s.Factory(GraphFixtures["va-2e3v"]).(VertexAttributeSource))
+}
+
+func (s *VertexAttributeSourceSuite) TestMissingVertex(c *C) {
+	g := s.Factory(GraphFixtures["va-2e3v"]).(VertexAttributeSource)
+
+	_, exists := g.VertexData("missing")
+	c.Assert(exists, Equals, false)
+	_, exists = g.VertexLabel("missing")
+	c.Assert(exists, Equals, false)
+	_, exists = g.VertexWeight("missing")
+	c.Assert(exists, Equals, false)
+}
+
+func (s *VertexAttributeSourceSuite) TestPreservedByTranspose(c *C) {
+	g, ok := s.Factory(GraphFixtures["va-2e3v"]).(Digraph)
+	if !ok {
+		c.Skip("Graph is not a digraph.")
+	}
+
+	g2, ok := g.Transpose().(VertexAttributeSource)
+	c.Assert(ok, Equals, true)
+	assertFixtureVertexAttrs(c, g2)
+}
+
+func (s *VertexAttributeSourceSuite) TestPreservedByCopy(c *C) {
+	g := s.Factory(GraphFixtures["va-2e3v"])
+	assertFixtureVertexAttrs(c, s.Factory(g).(VertexAttributeSource))
+}
+
+type VertexAttributesSuite struct {
+	Factory func(GraphSource) Graph
+}
+
+func (s *VertexAttributesSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+func (s *VertexAttributesSuite) TestSetAndGet(c *C) {
+	g := s.Factory(GraphFixtures["2e3v"]).(VertexAttributes)
+
+	c.Assert(g.SetVertexData("foo", 42), Equals, true)
+	c.Assert(g.SetVertexLabel("foo", "start"), Equals, true)
+	c.Assert(g.SetVertexWeight("bar", 1.5), Equals, true)
+
+	data, exists := g.VertexData("foo")
+	c.Assert(exists, Equals, true)
+	c.Assert(data, Equals, 42)
+
+	label, exists := g.VertexLabel("foo")
+	c.Assert(exists, Equals, true)
+	c.Assert(label, Equals, "start")
+
+	weight, exists := g.VertexWeight("bar")
+	c.Assert(exists, Equals, true)
+	c.Assert(weight, Equals, 1.5)
+
+	_, exists = g.VertexWeight("foo")
+	c.Assert(exists, Equals, false)
+
+	// Replacement
+	g.SetVertexLabel("foo", "begin")
+	label, _ = g.VertexLabel("foo")
+	c.Assert(label, Equals, "begin")
+}
+
+func (s *VertexAttributesSuite) TestSetOnMissingVertex(c *C) {
+	g := s.Factory(NullGraph).(VertexAttributes)
+
+	c.Assert(g.SetVertexData("missing", 42), Equals, false)
+	c.Assert(g.SetVertexLabel("missing", "foo"), Equals, false)
+	c.Assert(g.SetVertexWeight("missing", 1), Equals, false)
+
+	_, exists := g.VertexData("missing")
+	c.Assert(exists, Equals, false)
+	c.Assert(Order(g.(Graph)), Equals, 0)
+}
+
+func (s *VertexAttributesSuite) TestRemovalClearsAttributes(c *C) {
+	g := s.Factory(NullGraph)
+	m, ok := g.(VertexSetMutator)
+	if !ok {
+		c.Skip("Graph cannot remove vertices.")
+	}
+	va := g.(VertexAttributes)
+
+	m.EnsureVertex("foo")
+	va.SetVertexData("foo", 42)
+	va.SetVertexLabel("foo", "start")
+	va.SetVertexWeight("foo", 1.5)
+
+	m.RemoveVertex("foo")
+	m.EnsureVertex("foo")
+
+	_, exists := va.VertexData("foo")
+	c.Assert(exists, Equals, false)
+	_, exists = va.VertexLabel("foo")
+	c.Assert(exists, Equals, false)
+	_, exists = va.VertexWeight("foo")
+	c.Assert(exists, Equals, false)
+}
+
+func (s *VertexAttributesSuite) TestTransposeIsIndependent(c *C) {
+	g, ok := s.Factory(GraphFixtures["2e3v"]).(Digraph)
+	if !ok {
+		c.Skip("Graph is not a digraph.")
+	}
+	g.(VertexAttributes).SetVertexLabel("foo", "start")
+
+	g2 := g.Transpose()
+	g.(VertexAttributes).SetVertexLabel("foo", "changed")
+
+	label, _ := g2.(VertexAttributeSource).VertexLabel("foo")
+	c.Assert(label, Equals, "start")
+}
@@ -0,0 +1,246 @@
+package typed
+
+import (
+	"fmt"
+
+	"github.com/sdboyer/gogl"
+)
+
+/* Typed to untyped */
+
+// Presents a typed GraphSource as a gogl.GraphSource - for example, to pass
+// it to gogl.GraphSpec.Using. Edges created by this package's constructors
+// become the corresponding gogl edge types, with weights converted to float64;
+// any others become basic edges.
+func UntypedSource[V comparable](g GraphSource[V]) gogl.GraphSource {
+	return untypedSource[V]{g}
+}
+
+// Presents a typed Graph as a gogl.Graph, so that gogl's algorithms can run on
+// it. Edges are converted as by UntypedSource. Vertices that are not of type V
+// are treated as absent from the graph.
+func Untyped[V comparable](g Graph[V]) gogl.Graph {
+	return untypedGraph[V]{untypedSource[V]{g}, g}
+}
+
+// Presents a typed WeightedGraph as a gogl.WeightedGraph.
+func UntypedWeighted[V comparable, W Number](g WeightedGraph[V, W]) gogl.WeightedGraph {
+	return untypedWeighted[V, W]{untypedGraph[V]{untypedSource[V]{g}, g}, g}
+}
+
+// Presents a typed DataGraph as a gogl.DataGraph. Data that is not of type D is
+// treated as absent from the graph.
+func UntypedData[V comparable, D any](g DataGraph[V, D]) gogl.DataGraph {
+	return untypedData[V, D]{untypedGraph[V]{untypedSource[V]{g}, g}, g}
+}
+
+type untypedSource[V comparable] struct {
+	src GraphSource[V]
+}
+
+func (g untypedSource[V]) Vertices(f gogl.VertexStep) {
+	g.src.Vertices(func(v V) bool {
+		return f(v)
+	})
+}
+
+func (g untypedSource[V]) Edges(f gogl.EdgeStep) {
+	g.src.Edges(func(e Edge[V]) bool {
+		return f(erase(e))
+	})
+}
+
+type untypedGraph[V comparable] struct {
+	untypedSource[V]
+	g Graph[V]
+}
+
+// Asserts both of an untyped edge's endpoints to V.
+func endpoints[V comparable](e gogl.Edge) (u, v V, ok bool) {
+	a, b := e.Both()
+	u, uok := a.(V)
+	v, vok := b.(V)
+	return u, v, uok && vok
+}
+
+func (g untypedGraph[V]) AdjacentTo(start gogl.Vertex, f gogl.VertexStep) {
+	if s, ok := start.(V); ok {
+		g.g.AdjacentTo(s, func(v V) bool {
+			return f(v)
+		})
+	}
+}
+
+func (g untypedGraph[V]) IncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	if tv, ok := v.(V); ok {
+		g.g.IncidentTo(tv, func(e Edge[V]) bool {
+			return f(erase(e))
+		})
+	}
+}
+
+func (g untypedGraph[V]) HasVertex(v gogl.Vertex) bool {
+	tv, ok := v.(V)
+	return ok && g.g.HasVertex(tv)
+}
+
+func (g untypedGraph[V]) HasEdge(e gogl.Edge) bool {
+	u, v, ok := endpoints[V](e)
+	return ok && g.g.HasEdge(NewEdge(u, v))
+}
+
+func (g untypedGraph[V]) DegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	if tv, ok := v.(V); ok {
+		return g.g.DegreeOf(tv)
+	}
+	return 0, false
+}
+
+type untypedWeighted[V comparable, W Number] struct {
+	untypedGraph[V]
+	wg WeightedGraph[V, W]
+}
+
+func (g untypedWeighted[V, W]) HasWeightedEdge(e gogl.WeightedEdge) bool {
+	u, v, ok := endpoints[V](e)
+	// A weight that W cannot represent exactly cannot be present.
+	w := W(e.Weight())
+	return ok && float64(w) == e.Weight() && g.wg.HasWeightedEdge(NewWeightedEdge(u, v, w))
+}
+
+type untypedData[V comparable, D any] struct {
+	untypedGraph[V]
+	dg DataGraph[V, D]
+}
+
+// Asserts untyped edge data to D. nil is accepted if D is an interface type.
+func dataAs[D any](x interface{}) (d D, ok bool) {
+	if x == nil {
+		return d, any(d) == nil
+	}
+	d, ok = x.(D)
+	return
+}
+
+func (g untypedData[V, D]) HasDataEdge(e gogl.DataEdge) bool {
+	u, v, ok := endpoints[V](e)
+	d, dok := dataAs[D](e.Data())
+	return ok && dok && g.dg.HasDataEdge(NewDataEdge(u, v, d))
+}
+
+/* Untyped to typed */
+
+// Presents a gogl.Graph as a typed Graph. The graph's vertices must all be of
+// type V; enumerating one that is not panics. Edges are converted to basic
+// typed edges.
+func Typed[V comparable](g gogl.Graph) Graph[V] {
+	return typedGraph[V]{g, func(e gogl.Edge) Edge[V] {
+		u, v := e.Both()
+		return NewEdge(u.(V), v.(V))
+	}}
+}
+
+// Presents a gogl.WeightedGraph as a typed WeightedGraph. As with Typed, the
+// graph's vertices must all be of type V. Weights are converted from float64
+// to W.
+func TypedWeighted[V comparable, W Number](g gogl.WeightedGraph) WeightedGraph[V, W] {
+	conv := func(e gogl.Edge) WeightedEdge[V, W] {
+		u, v := e.Both()
+		return NewWeightedEdge(u.(V), v.(V), W(e.(gogl.WeightedEdge).Weight()))
+	}
+
+	return typedWeighted[V, W]{typedGraph[V]{g, func(e gogl.Edge) Edge[V] { return conv(e) }}, g, conv}
+}
+
+// Presents a gogl.DataGraph as a typed DataGraph. As with Typed, the graph's
+// vertices must all be of type V, and its edges' data must all be of type D
+// (or nil, if D is an interface type); enumerating any other panics.
+func TypedData[V comparable, D any](g gogl.DataGraph) DataGraph[V, D] {
+	conv := func(e gogl.Edge) DataEdge[V, D] {
+		u, v := e.Both()
+		data := e.(gogl.DataEdge).Data()
+		d, ok := dataAs[D](data)
+		if !ok {
+			panic(fmt.Sprintf("Edge data of type %T is not of the expected type.", data))
+		}
+		return NewDataEdge(u.(V), v.(V), d)
+	}
+
+	return typedData[V, D]{typedGraph[V]{g, func(e gogl.Edge) Edge[V] { return conv(e) }}, g, conv}
+}
+
+type typedGraph[V comparable] struct {
+	g       gogl.Graph
+	convert func(gogl.Edge) Edge[V]
+}
+
+func (g typedGraph[V]) Vertices(f VertexStep[V]) {
+	g.g.Vertices(func(v gogl.Vertex) bool {
+		return f(v.(V))
+	})
+}
+
+func (g typedGraph[V]) Edges(f EdgeStep[V]) {
+	g.g.Edges(func(e gogl.Edge) bool {
+		return f(g.convert(e))
+	})
+}
+
+func (g typedGraph[V]) AdjacentTo(start V, f VertexStep[V]) {
+	g.g.AdjacentTo(start, func(v gogl.Vertex) bool {
+		return f(v.(V))
+	})
+}
+
+func (g typedGraph[V]) IncidentTo(v V, f EdgeStep[V]) {
+	g.g.IncidentTo(v, func(e gogl.Edge) bool {
+		return f(g.convert(e))
+	})
+}
+
+func (g typedGraph[V]) HasVertex(v V) bool {
+	return g.g.HasVertex(v)
+}
+
+func (g typedGraph[V]) HasEdge(e Edge[V]) bool {
+	u, v := e.Both()
+	return g.g.HasEdge(gogl.NewEdge(u, v))
+}
+
+func (g typedGraph[V]) DegreeOf(v V) (degree int, exists bool) {
+	return g.g.DegreeOf(v)
+}
+
+type typedWeighted[V comparable, W Number] struct {
+	typedGraph[V]
+	wg   gogl.WeightedGraph
+	conv func(gogl.Edge) WeightedEdge[V, W]
+}
+
+func (g typedWeighted[V, W]) WeightedEdges(f WeightedEdgeStep[V, W]) {
+	g.wg.Edges(func(e gogl.Edge) bool {
+		return f(g.conv(e))
+	})
+}
+
+func (g typedWeighted[V, W]) HasWeightedEdge(e WeightedEdge[V, W]) bool {
+	u, v := e.Both()
+	return g.wg.HasWeightedEdge(gogl.NewWeightedEdge(u, v, float64(e.Weight())))
+}
+
+type typedData[V comparable, D any] struct {
+	typedGraph[V]
+	dg   gogl.DataGraph
+	conv func(gogl.Edge) DataEdge[V, D]
+}
+
+func (g typedData[V, D]) DataEdges(f DataEdgeStep[V, D]) {
+	g.dg.Edges(func(e gogl.Edge) bool {
+		return f(g.conv(e))
+	})
+}
+
+func (g typedData[V, D]) HasDataEdge(e DataEdge[V, D]) bool {
+	u, v := e.Both()
+	return g.dg.HasDataEdge(gogl.NewDataEdge(u, v, e.Data()))
+}
@@ -0,0 +1,183 @@
+// Adjacency list implementations of the typed graph interfaces.
+//
+// These mirror the undirected, mutable graphs in graph/al, but store vertices
+// and edge payloads with their static types, avoiding the boxing that
+// interface{} vertices require. As in graph/al, the graphs are simple - loops
+// and parallel edges are not stored - and safe for concurrent use.
+package al
+
+import (
+	"sync"
+
+	"github.com/sdboyer/gogl/typed"
+)
+
+// The storage shared by every graph in this package: an adjacency list with a
+// payload of type P on each edge. Each edge is recorded under both endpoints.
+type adjacency[V comparable, P any] struct {
+	mu   sync.RWMutex
+	list map[V]map[V]P
+	size int
+}
+
+func (g *adjacency[V, P]) init() {
+	g.list = make(map[V]map[V]P)
+}
+
+// Traverses the graph's vertices, passing each one to the provided closure.
+func (g *adjacency[V, P]) Vertices(f typed.VertexStep[V]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for v := range g.list {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *adjacency[V, P]) AdjacentTo(start V, f typed.VertexStep[V]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for v := range g.list[start] {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *adjacency[V, P]) HasVertex(v V) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, exists := g.list[v]
+	return exists
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *adjacency[V, P]) DegreeOf(v V) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	adj, exists := g.list[v]
+	return len(adj), exists
+}
+
+// Returns the number of vertices in the graph.
+func (g *adjacency[V, P]) Order() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list)
+}
+
+// Returns the number of edges in the graph.
+func (g *adjacency[V, P]) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.size
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *adjacency[V, P]) EnsureVertex(vertices ...V) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ensureVertex(vertices...)
+}
+
+func (g *adjacency[V, P]) ensureVertex(vertices ...V) {
+	for _, v := range vertices {
+		if _, exists := g.list[v]; !exists {
+			g.list[v] = make(map[V]P)
+		}
+	}
+}
+
+// Removes a vertex from the graph. Also removes any edges of which that
+// vertex is a member.
+func (g *adjacency[V, P]) RemoveVertex(vertices ...V) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, v := range vertices {
+		if adj, exists := g.list[v]; exists {
+			for u := range adj {
+				delete(g.list[u], v)
+			}
+			g.size -= len(adj)
+			delete(g.list, v)
+		}
+	}
+}
+
+// Passes each edge to the provided function once, along with its payload.
+// Callers must hold the read lock.
+func (g *adjacency[V, P]) each(f func(u, v V, p P) bool) {
+	visited := make(map[[2]V]struct{}, g.size)
+	for u, adj := range g.list {
+		for v, p := range adj {
+			if _, seen := visited[[2]V{v, u}]; seen {
+				continue
+			}
+			visited[[2]V{u, v}] = struct{}{}
+			if f(u, v, p) {
+				return
+			}
+		}
+	}
+}
+
+// Passes each edge incident to the provided vertex to the provided function,
+// along with its payload. Callers must hold the read lock.
+func (g *adjacency[V, P]) incident(v V, f func(u, v V, p P) bool) {
+	for u, p := range g.list[v] {
+		if f(v, u, p) {
+			return
+		}
+	}
+}
+
+// Returns the payload of the edge connecting the provided vertices, if there
+// is one. Callers must hold the read lock.
+func (g *adjacency[V, P]) find(u, v V) (p P, exists bool) {
+	p, exists = g.list[u][v]
+	return
+}
+
+// Adds an edge, unless one already connects the same vertices, and its
+// vertices. Loops are discarded. Callers must hold the write lock.
+func (g *adjacency[V, P]) add(u, v V, p P) {
+	g.ensureVertex(u, v)
+	if _, exists := g.list[u][v]; exists || u == v {
+		return
+	}
+
+	g.list[u][v] = p
+	g.list[v][u] = p
+	g.size++
+}
+
+// Removes the edge connecting the provided vertices, if there is one. Callers
+// must hold the write lock.
+func (g *adjacency[V, P]) remove(u, v V) {
+	if _, exists := g.list[u][v]; exists {
+		delete(g.list[u], v)
+		delete(g.list[v], u)
+		g.size--
+	}
+}
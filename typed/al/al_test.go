@@ -0,0 +1,136 @@
+package al
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+	"github.com/sdboyer/gogl/spec"
+	"github.com/sdboyer/gogl/typed"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+// Populates a typed graph from an untyped source. Fixtures carrying no weight
+// or data are added with zero values.
+func populate(gs gogl.GraphSource, ensure func(...gogl.Vertex), add func(gogl.Edge)) {
+	if gs == nil {
+		return
+	}
+
+	gs.Vertices(func(v gogl.Vertex) (terminate bool) {
+		ensure(v)
+		return
+	})
+	gs.Edges(func(e gogl.Edge) (terminate bool) {
+		add(e)
+		return
+	})
+}
+
+func init() {
+	// Through the untyped adapters, the typed graphs must pass the same specs as
+	// the untyped adjacency lists.
+	spec.SetUpTestsFromSpec(gogl.Spec().Props, func(gs gogl.GraphSpec) gogl.Graph {
+		g := NewGraph[gogl.Vertex]()
+		populate(gs.Source, g.EnsureVertex, func(e gogl.Edge) {
+			u, v := e.Both()
+			g.AddEdges(typed.NewEdge(u, v))
+		})
+		return typed.Untyped[gogl.Vertex](g)
+	})
+
+	spec.SetUpTestsFromSpec(gogl.Spec().Weighted().Props, func(gs gogl.GraphSpec) gogl.Graph {
+		g := NewWeighted[gogl.Vertex, float64]()
+		populate(gs.Source, g.EnsureVertex, func(e gogl.Edge) {
+			u, v := e.Both()
+			g.AddEdges(typed.NewWeightedEdge(u, v, encoding.AttrsOf(e).Weight))
+		})
+		return typed.UntypedWeighted[gogl.Vertex, float64](g)
+	})
+
+	spec.SetUpTestsFromSpec(gogl.Spec().DataEdges().Props, func(gs gogl.GraphSpec) gogl.Graph {
+		g := NewData[gogl.Vertex, interface{}]()
+		populate(gs.Source, g.EnsureVertex, func(e gogl.Edge) {
+			u, v := e.Both()
+			g.AddEdges(typed.NewDataEdge(u, v, encoding.AttrsOf(e).Data))
+		})
+		return typed.UntypedData[gogl.Vertex, interface{}](g)
+	})
+}
+
+type TypedALSuite struct{}
+
+var _ = Suite(&TypedALSuite{})
+
+func (s *TypedALSuite) TestGraph(c *C) {
+	g := NewGraph[string]()
+	g.AddEdges(typed.EdgeList[string]{
+		typed.NewEdge("foo", "bar"),
+		typed.NewEdge("bar", "baz"),
+		typed.NewEdge("baz", "bar"),
+		typed.NewEdge("qux", "qux"),
+	}...)
+
+	c.Assert(g.Order(), Equals, 4)
+	c.Assert(g.Size(), Equals, 2)
+	c.Assert(g.HasEdge(typed.NewEdge("bar", "foo")), Equals, true)
+	c.Assert(g.HasEdge(typed.NewEdge("qux", "qux")), Equals, false)
+
+	d, exists := g.DegreeOf("bar")
+	c.Assert(exists, Equals, true)
+	c.Assert(d, Equals, 2)
+
+	var edges int
+	g.Edges(func(e typed.Edge[string]) (terminate bool) {
+		edges++
+		return
+	})
+	c.Assert(edges, Equals, 2)
+
+	g.RemoveVertex("bar")
+	c.Assert(g.Size(), Equals, 0)
+	c.Assert(g.Order(), Equals, 3)
+	d, _ = g.DegreeOf("foo")
+	c.Assert(d, Equals, 0)
+}
+
+func (s *TypedALSuite) TestWeighted(c *C) {
+	g := NewWeighted[int, int]()
+	g.AddEdges(typed.NewWeightedEdge(1, 2, 5), typed.NewWeightedEdge(2, 3, 7))
+
+	c.Assert(g.HasWeightedEdge(typed.NewWeightedEdge(2, 1, 5)), Equals, true)
+	c.Assert(g.HasWeightedEdge(typed.NewWeightedEdge(2, 1, 6)), Equals, false)
+
+	var total int
+	g.WeightedEdges(func(e typed.WeightedEdge[int, int]) (terminate bool) {
+		total += e.Weight()
+		return
+	})
+	c.Assert(total, Equals, 12)
+
+	g.IncidentTo(3, func(e typed.Edge[int]) (terminate bool) {
+		c.Assert(e.(typed.WeightedEdge[int, int]).Weight(), Equals, 7)
+		return
+	})
+
+	g.RemoveEdges(typed.NewWeightedEdge(3, 2, 0))
+	c.Assert(g.Size(), Equals, 1)
+}
+
+func (s *TypedALSuite) TestData(c *C) {
+	type payload struct{ n int }
+
+	g := NewData[string, payload]()
+	g.AddEdges(typed.NewDataEdge("foo", "bar", payload{1}))
+
+	c.Assert(g.HasDataEdge(typed.NewDataEdge("bar", "foo", payload{1})), Equals, true)
+	c.Assert(g.HasDataEdge(typed.NewDataEdge("bar", "foo", payload{2})), Equals, false)
+
+	g.DataEdges(func(e typed.DataEdge[string, payload]) (terminate bool) {
+		c.Assert(e.Data().n, Equals, 1)
+		return
+	})
+}
@@ -0,0 +1,244 @@
+package al
+
+import (
+	"github.com/sdboyer/gogl/typed"
+)
+
+// A Graph is a mutable, undirected graph with basic edges.
+type Graph[V comparable] struct {
+	adjacency[V, struct{}]
+}
+
+var _ typed.MutableGraph[int] = &Graph[int]{}
+
+// Creates a new, empty Graph.
+func NewGraph[V comparable]() *Graph[V] {
+	g := &Graph[V]{}
+	g.init()
+	return g
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *Graph[V]) Edges(f typed.EdgeStep[V]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.each(func(u, v V, _ struct{}) bool {
+		return f(typed.NewEdge(u, v))
+	})
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *Graph[V]) IncidentTo(v V, f typed.EdgeStep[V]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.incident(v, func(u, v V, _ struct{}) bool {
+		return f(typed.NewEdge(u, v))
+	})
+}
+
+// Indicates whether or not the given edge is present in the graph.
+func (g *Graph[V]) HasEdge(e typed.Edge[V]) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, exists := g.find(e.Both())
+	return exists
+}
+
+// Adds edges to the graph.
+func (g *Graph[V]) AddEdges(edges ...typed.Edge[V]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range edges {
+		u, v := e.Both()
+		g.add(u, v, struct{}{})
+	}
+}
+
+// Removes edges from the graph. This does NOT remove vertex members of the
+// removed edges.
+func (g *Graph[V]) RemoveEdges(edges ...typed.Edge[V]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range edges {
+		g.remove(e.Both())
+	}
+}
+
+// A Weighted graph is a mutable, undirected graph whose edges carry weights of
+// type W.
+type Weighted[V comparable, W typed.Number] struct {
+	adjacency[V, W]
+}
+
+var _ typed.MutableWeightedGraph[int, float64] = &Weighted[int, float64]{}
+
+// Creates a new, empty Weighted graph.
+func NewWeighted[V comparable, W typed.Number]() *Weighted[V, W] {
+	g := &Weighted[V, W]{}
+	g.init()
+	return g
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure. Each edge is a typed.WeightedEdge.
+func (g *Weighted[V, W]) Edges(f typed.EdgeStep[V]) {
+	g.WeightedEdges(func(e typed.WeightedEdge[V, W]) bool {
+		return f(e)
+	})
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *Weighted[V, W]) WeightedEdges(f typed.WeightedEdgeStep[V, W]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.each(func(u, v V, w W) bool {
+		return f(typed.NewWeightedEdge(u, v, w))
+	})
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *Weighted[V, W]) IncidentTo(v V, f typed.EdgeStep[V]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.incident(v, func(u, v V, w W) bool {
+		return f(typed.NewWeightedEdge(u, v, w))
+	})
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge weight.
+func (g *Weighted[V, W]) HasEdge(e typed.Edge[V]) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, exists := g.find(e.Both())
+	return exists
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if the provided WeightedEdge has the same weight as
+// the edge contained in the graph.
+func (g *Weighted[V, W]) HasWeightedEdge(e typed.WeightedEdge[V, W]) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	w, exists := g.find(e.Both())
+	return exists && w == e.Weight()
+}
+
+// Adds edges to the graph.
+func (g *Weighted[V, W]) AddEdges(edges ...typed.WeightedEdge[V, W]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range edges {
+		u, v := e.Both()
+		g.add(u, v, e.Weight())
+	}
+}
+
+// Removes edges from the graph. This does NOT remove vertex members of the
+// removed edges.
+func (g *Weighted[V, W]) RemoveEdges(edges ...typed.WeightedEdge[V, W]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range edges {
+		g.remove(e.Both())
+	}
+}
+
+// A Data graph is a mutable, undirected graph whose edges carry data of type D.
+type Data[V comparable, D any] struct {
+	adjacency[V, D]
+}
+
+var _ typed.MutableDataGraph[int, string] = &Data[int, string]{}
+
+// Creates a new, empty Data graph.
+func NewData[V comparable, D any]() *Data[V, D] {
+	g := &Data[V, D]{}
+	g.init()
+	return g
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure. Each edge is a typed.DataEdge.
+func (g *Data[V, D]) Edges(f typed.EdgeStep[V]) {
+	g.DataEdges(func(e typed.DataEdge[V, D]) bool {
+		return f(e)
+	})
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *Data[V, D]) DataEdges(f typed.DataEdgeStep[V, D]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.each(func(u, v V, d D) bool {
+		return f(typed.NewDataEdge(u, v, d))
+	})
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *Data[V, D]) IncidentTo(v V, f typed.EdgeStep[V]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	g.incident(v, func(u, v V, d D) bool {
+		return f(typed.NewDataEdge(u, v, d))
+	})
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// based solely on the presence of an edge, disregarding edge data.
+func (g *Data[V, D]) HasEdge(e typed.Edge[V]) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, exists := g.find(e.Both())
+	return exists
+}
+
+// Indicates whether or not the given data edge is present in the graph. It
+// will only match if the provided DataEdge carries data equal to that of the
+// edge contained in the graph.
+func (g *Data[V, D]) HasDataEdge(e typed.DataEdge[V, D]) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	d, exists := g.find(e.Both())
+	return exists && any(d) == any(e.Data())
+}
+
+// Adds edges to the graph.
+func (g *Data[V, D]) AddEdges(edges ...typed.DataEdge[V, D]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range edges {
+		u, v := e.Both()
+		g.add(u, v, e.Data())
+	}
+}
+
+// Removes edges from the graph. This does NOT remove vertex members of the
+// removed edges.
+func (g *Data[V, D]) RemoveEdges(edges ...typed.DataEdge[V, D]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range edges {
+		g.remove(e.Both())
+	}
+}
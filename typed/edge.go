@@ -0,0 +1,93 @@
+package typed
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Edge is the typed counterpart of gogl.Edge.
+type Edge[V comparable] interface {
+	Both() (u V, v V) // No order consistency is implied.
+}
+
+// WeightedEdge is the typed counterpart of gogl.WeightedEdge.
+type WeightedEdge[V comparable, W Number] interface {
+	Edge[V]
+	Weight() W
+}
+
+// DataEdge is the typed counterpart of gogl.DataEdge.
+type DataEdge[V comparable, D any] interface {
+	Edge[V]
+	Data() D
+}
+
+// Implemented by this package's edges, so that the adapters can convert them
+// to gogl's edge types without knowing their payload types.
+type eraser interface {
+	untyped() gogl.Edge
+}
+
+// Converts a typed edge to the untyped edge type that carries the same
+// payload. Edges not created by this package become basic edges.
+func erase[V comparable](e Edge[V]) gogl.Edge {
+	if x, ok := e.(eraser); ok {
+		return x.untyped()
+	}
+
+	u, v := e.Both()
+	return gogl.NewEdge(u, v)
+}
+
+type baseEdge[V comparable] struct {
+	u, v V
+}
+
+func (e baseEdge[V]) Both() (V, V) {
+	return e.u, e.v
+}
+
+func (e baseEdge[V]) untyped() gogl.Edge {
+	return gogl.NewEdge(e.u, e.v)
+}
+
+// Create a new basic edge.
+func NewEdge[V comparable](u, v V) Edge[V] {
+	return baseEdge[V]{u, v}
+}
+
+type baseWeightedEdge[V comparable, W Number] struct {
+	baseEdge[V]
+	w W
+}
+
+func (e baseWeightedEdge[V, W]) Weight() W {
+	return e.w
+}
+
+// Weights are converted to float64, as gogl.WeightedEdge requires.
+func (e baseWeightedEdge[V, W]) untyped() gogl.Edge {
+	return gogl.NewWeightedEdge(e.u, e.v, float64(e.w))
+}
+
+// Create a new weighted edge.
+func NewWeightedEdge[V comparable, W Number](u, v V, weight W) WeightedEdge[V, W] {
+	return baseWeightedEdge[V, W]{baseEdge[V]{u, v}, weight}
+}
+
+type baseDataEdge[V comparable, D any] struct {
+	baseEdge[V]
+	d D
+}
+
+func (e baseDataEdge[V, D]) Data() D {
+	return e.d
+}
+
+func (e baseDataEdge[V, D]) untyped() gogl.Edge {
+	return gogl.NewDataEdge(e.u, e.v, e.d)
+}
+
+// Create a new "data" edge - an edge with embedded data of type D.
+func NewDataEdge[V comparable, D any](u, v V, data D) DataEdge[V, D] {
+	return baseDataEdge[V, D]{baseEdge[V]{u, v}, data}
+}
@@ -0,0 +1,81 @@
+package typed
+
+// Shared helper function for edge lists to enumerate vertices, each once, in
+// the order they first appear.
+func elVertices[V comparable](n int, edge func(i int) Edge[V], f VertexStep[V]) {
+	seen := make(map[V]struct{}, n)
+	for i := 0; i < n; i++ {
+		u, v := edge(i).Both()
+		for _, x := range [2]V{u, v} {
+			if _, exists := seen[x]; exists {
+				continue
+			}
+			seen[x] = struct{}{}
+			if f(x) {
+				return
+			}
+		}
+	}
+}
+
+// An EdgeList is a naive GraphSource implementation that is backed only by an
+// edge slice. As with gogl.EdgeList, it is primarily intended for fixtures.
+type EdgeList[V comparable] []Edge[V]
+
+func (el EdgeList[V]) Vertices(f VertexStep[V]) {
+	elVertices(len(el), func(i int) Edge[V] { return el[i] }, f)
+}
+
+func (el EdgeList[V]) Edges(f EdgeStep[V]) {
+	for _, e := range el {
+		if f(e) {
+			return
+		}
+	}
+}
+
+// A WeightedEdgeList is an EdgeList of weighted edges.
+type WeightedEdgeList[V comparable, W Number] []WeightedEdge[V, W]
+
+func (el WeightedEdgeList[V, W]) Vertices(f VertexStep[V]) {
+	elVertices(len(el), func(i int) Edge[V] { return el[i] }, f)
+}
+
+func (el WeightedEdgeList[V, W]) Edges(f EdgeStep[V]) {
+	for _, e := range el {
+		if f(e) {
+			return
+		}
+	}
+}
+
+func (el WeightedEdgeList[V, W]) WeightedEdges(f WeightedEdgeStep[V, W]) {
+	for _, e := range el {
+		if f(e) {
+			return
+		}
+	}
+}
+
+// A DataEdgeList is an EdgeList of data edges.
+type DataEdgeList[V comparable, D any] []DataEdge[V, D]
+
+func (el DataEdgeList[V, D]) Vertices(f VertexStep[V]) {
+	elVertices(len(el), func(i int) Edge[V] { return el[i] }, f)
+}
+
+func (el DataEdgeList[V, D]) Edges(f EdgeStep[V]) {
+	for _, e := range el {
+		if f(e) {
+			return
+		}
+	}
+}
+
+func (el DataEdgeList[V, D]) DataEdges(f DataEdgeStep[V, D]) {
+	for _, e := range el {
+		if f(e) {
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// A generics-based counterpart to gogl's graph interfaces, in which vertices
+// and edge payloads have static types.
+//
+// gogl's core interfaces deal in Vertex, which is an interface{}, and edge
+// data of type interface{}, so callers must type assert whatever the graph
+// gives them. The interfaces in this package are parameterized instead: a
+// Graph[V] enumerates vertices of type V, a WeightedGraph[V, W] edges weighted
+// with W, a DataGraph[V, D] edges carrying D.
+//
+// The typed and untyped worlds are bridged by adapters. Untyped() and friends
+// present a typed graph through gogl's interfaces, so the existing algorithms
+// (dfs, bfs and so on) can run on it; Typed() and friends do the reverse. The
+// typed/al subpackage provides adjacency list implementations.
+package typed
+
+// Number is the set of types that may be used as edge weights.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// VertexSteps are used as arguments to enumerators. They are called once for
+// each vertex produced by the enumerator; returning true ends enumeration.
+type VertexStep[V comparable] func(V) (terminate bool)
+
+// EdgeSteps are used as arguments to enumerators. They are called once for
+// each edge produced by the enumerator; returning true ends enumeration.
+type EdgeStep[V comparable] func(Edge[V]) (terminate bool)
+
+// WeightedEdgeSteps are used as arguments to weighted edge enumerators.
+type WeightedEdgeStep[V comparable, W Number] func(WeightedEdge[V, W]) (terminate bool)
+
+// DataEdgeSteps are used as arguments to data edge enumerators.
+type DataEdgeStep[V comparable, D any] func(DataEdge[V, D]) (terminate bool)
+
+// GraphSource is the typed counterpart of gogl.GraphSource: the minimal set of
+// methods necessary for a full traversal.
+type GraphSource[V comparable] interface {
+	Vertices(VertexStep[V])
+	Edges(EdgeStep[V])
+}
+
+// Graph is the typed counterpart of gogl.Graph. Its methods behave exactly as
+// theirs do.
+type Graph[V comparable] interface {
+	GraphSource[V]
+	AdjacentTo(start V, f VertexStep[V])
+	IncidentTo(v V, f EdgeStep[V])
+	HasVertex(V) bool
+	HasEdge(Edge[V]) bool
+	DegreeOf(V) (degree int, exists bool)
+}
+
+// WeightedGraph is the typed counterpart of gogl.WeightedGraph. Edges() passes
+// WeightedEdges, but as Edges; WeightedEdges() passes the same edges with
+// their static type intact.
+type WeightedGraph[V comparable, W Number] interface {
+	Graph[V]
+	WeightedEdges(WeightedEdgeStep[V, W])
+	HasWeightedEdge(WeightedEdge[V, W]) bool
+}
+
+// DataGraph is the typed counterpart of gogl.DataGraph. Edges() passes
+// DataEdges, but as Edges; DataEdges() passes the same edges with their
+// static type intact.
+type DataGraph[V comparable, D any] interface {
+	Graph[V]
+	DataEdges(DataEdgeStep[V, D])
+	HasDataEdge(DataEdge[V, D]) bool
+}
+
+// MutableGraph is a Graph with basic edges that can be modified freely.
+type MutableGraph[V comparable] interface {
+	Graph[V]
+	EnsureVertex(...V)
+	RemoveVertex(...V)
+	AddEdges(...Edge[V])
+	RemoveEdges(...Edge[V])
+}
+
+// MutableWeightedGraph is a WeightedGraph that can be modified freely.
+type MutableWeightedGraph[V comparable, W Number] interface {
+	WeightedGraph[V, W]
+	EnsureVertex(...V)
+	RemoveVertex(...V)
+	AddEdges(...WeightedEdge[V, W])
+	RemoveEdges(...WeightedEdge[V, W])
+}
+
+// MutableDataGraph is a DataGraph that can be modified freely.
+type MutableDataGraph[V comparable, D any] interface {
+	DataGraph[V, D]
+	EnsureVertex(...V)
+	RemoveVertex(...V)
+	AddEdges(...DataEdge[V, D])
+	RemoveEdges(...DataEdge[V, D])
+}
@@ -0,0 +1,159 @@
+package typed
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/bfs"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type TypedSuite struct{}
+
+var _ = Suite(&TypedSuite{})
+
+func (s *TypedSuite) TestEdgeLists(c *C) {
+	el := EdgeList[int]{NewEdge(1, 2), NewEdge(2, 3), NewEdge(3, 1)}
+
+	var vertices []int
+	el.Vertices(func(v int) (terminate bool) {
+		vertices = append(vertices, v)
+		return
+	})
+	c.Assert(vertices, DeepEquals, []int{1, 2, 3})
+
+	wl := WeightedEdgeList[string, int]{NewWeightedEdge("a", "b", 2)}
+	wl.WeightedEdges(func(e WeightedEdge[string, int]) (terminate bool) {
+		c.Assert(e.Weight(), Equals, 2)
+		return
+	})
+
+	hit := 0
+	el.Vertices(func(v int) bool {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+}
+
+func (s *TypedSuite) TestUntypedSource(c *C) {
+	src := UntypedSource[string](WeightedEdgeList[string, int]{
+		NewWeightedEdge("foo", "bar", 2),
+		NewWeightedEdge("bar", "baz", 3),
+	})
+
+	g := gogl.Spec().Weighted().Using(src).Create(al.G).(gogl.WeightedGraph)
+	c.Assert(gogl.Size(g), Equals, 2)
+	c.Assert(g.HasWeightedEdge(gogl.NewWeightedEdge("bar", "foo", 2)), Equals, true)
+
+	// Data and basic edges survive too
+	src = UntypedSource[int](DataEdgeList[int, string]{NewDataEdge(1, 2, "x")})
+	src.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e.(gogl.DataEdge).Data(), Equals, "x")
+		return
+	})
+}
+
+func (s *TypedSuite) TestTyped(c *C) {
+	ug := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 5),
+		gogl.NewWeightedEdge(2, 3, 1.5),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	g := TypedWeighted[int, float64](ug)
+	var total float64
+	g.WeightedEdges(func(e WeightedEdge[int, float64]) (terminate bool) {
+		total += e.Weight()
+		return
+	})
+	c.Assert(total, Equals, 6.5)
+	c.Assert(g.HasWeightedEdge(NewWeightedEdge(3, 2, 1.5)), Equals, true)
+	c.Assert(g.HasEdge(NewEdge(1, 3)), Equals, false)
+
+	d, _ := g.DegreeOf(2)
+	c.Assert(d, Equals, 2)
+
+	// Vertices of the wrong type panic when enumerated
+	c.Assert(func() {
+		Typed[string](ug).Vertices(func(v string) (terminate bool) { return })
+	}, PanicMatches, ".*interface conversion.*")
+
+	dg := gogl.Spec().DataEdges().Using(gogl.DataEdgeList{gogl.NewDataEdge("a", "b", 42)}).Create(al.G).(gogl.DataGraph)
+	TypedData[string, int](dg).DataEdges(func(e DataEdge[string, int]) (terminate bool) {
+		c.Assert(e.Data(), Equals, 42)
+		return
+	})
+	c.Assert(func() {
+		TypedData[string, string](dg).DataEdges(func(e DataEdge[string, string]) (terminate bool) { return })
+	}, PanicMatches, "Edge data of type int is not of the expected type.")
+}
+
+// A minimal typed graph, to show that untyped algorithms run on typed graphs
+// through the adapters.
+type pathGraph struct {
+	EdgeList[int]
+}
+
+func (g pathGraph) AdjacentTo(start int, f VertexStep[int]) {
+	g.IncidentTo(start, func(e Edge[int]) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		}
+		return f(u)
+	})
+}
+
+func (g pathGraph) IncidentTo(v int, f EdgeStep[int]) {
+	for _, e := range g.EdgeList {
+		if a, b := e.Both(); (a == v || b == v) && f(e) {
+			return
+		}
+	}
+}
+
+func (g pathGraph) HasVertex(v int) (exists bool) {
+	g.Vertices(func(x int) bool {
+		exists = x == v
+		return exists
+	})
+	return
+}
+
+func (g pathGraph) HasEdge(e Edge[int]) (exists bool) {
+	u, v := e.Both()
+	g.IncidentTo(u, func(ie Edge[int]) bool {
+		a, b := ie.Both()
+		exists = (a == u && b == v) || (a == v && b == u)
+		return exists
+	})
+	return
+}
+
+func (g pathGraph) DegreeOf(v int) (degree int, exists bool) {
+	g.IncidentTo(v, func(Edge[int]) (terminate bool) {
+		degree++
+		return
+	})
+	return degree, g.HasVertex(v)
+}
+
+func (s *TypedSuite) TestUntyped(c *C) {
+	g := Untyped[int](pathGraph{EdgeList[int]{NewEdge(1, 2), NewEdge(2, 3)}})
+
+	c.Assert(g.HasVertex(2), Equals, true)
+	c.Assert(g.HasVertex("2"), Equals, false)
+	c.Assert(g.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge("foo", 2)), Equals, false)
+
+	_, exists := g.DegreeOf("foo")
+	c.Assert(exists, Equals, false)
+
+	path, err := bfs.ShortestPath(g, 3, 1)
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{3, 2, 1})
+}
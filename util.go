@@ -1,5 +1,7 @@
 package gogl
 
+import "iter"
+
 // Returns the number of vertices in a graph.
 //
 // If available, this function will take advantage of the optional optimization Order() method.
@@ -164,3 +166,88 @@ func CollectArcsTo(v Vertex, g IncidentArcEnumerator) (arcs []Arc) {
 
 	return arcs
 }
+
+/* Enumerator to iterator adapters */
+
+// Returns an iterator over a graph's vertices, for use with range-over-func.
+//
+// Breaking out of the range loop terminates the underlying enumeration.
+func VerticesSeq(g VertexEnumerator) iter.Seq[Vertex] {
+	return func(yield func(Vertex) bool) {
+		g.Vertices(func(v Vertex) (terminate bool) {
+			return !yield(v)
+		})
+	}
+}
+
+// Returns an iterator over the vertices adjacent to a given vertex.
+func AdjacentToSeq(v Vertex, g AdjacencyEnumerator) iter.Seq[Vertex] {
+	return func(yield func(Vertex) bool) {
+		g.AdjacentTo(v, func(adj Vertex) (terminate bool) {
+			return !yield(adj)
+		})
+	}
+}
+
+// Returns an iterator over a given vertex's successors.
+func SuccessorsSeq(v Vertex, g ProcessionEnumerator) iter.Seq[Vertex] {
+	return func(yield func(Vertex) bool) {
+		g.SuccessorsOf(v, func(s Vertex) (terminate bool) {
+			return !yield(s)
+		})
+	}
+}
+
+// Returns an iterator over a given vertex's predecessors.
+func PredecessorsSeq(v Vertex, g ProcessionEnumerator) iter.Seq[Vertex] {
+	return func(yield func(Vertex) bool) {
+		g.PredecessorsOf(v, func(p Vertex) (terminate bool) {
+			return !yield(p)
+		})
+	}
+}
+
+// Returns an iterator over a graph's edges.
+func EdgesSeq(g EdgeEnumerator) iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		g.Edges(func(e Edge) (terminate bool) {
+			return !yield(e)
+		})
+	}
+}
+
+// Returns an iterator over a given vertex's incident edges.
+func IncidentToSeq(v Vertex, g IncidentEdgeEnumerator) iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		g.IncidentTo(v, func(e Edge) (terminate bool) {
+			return !yield(e)
+		})
+	}
+}
+
+// Returns an iterator over a graph's arcs.
+func ArcsSeq(g ArcEnumerator) iter.Seq[Arc] {
+	return func(yield func(Arc) bool) {
+		g.Arcs(func(a Arc) (terminate bool) {
+			return !yield(a)
+		})
+	}
+}
+
+// Returns an iterator over a given vertex's out-arcs.
+func ArcsFromSeq(v Vertex, g IncidentArcEnumerator) iter.Seq[Arc] {
+	return func(yield func(Arc) bool) {
+		g.ArcsFrom(v, func(a Arc) (terminate bool) {
+			return !yield(a)
+		})
+	}
+}
+
+// Returns an iterator over a given vertex's in-arcs.
+func ArcsToSeq(v Vertex, g IncidentArcEnumerator) iter.Seq[Arc] {
+	return func(yield func(Arc) bool) {
+		g.ArcsTo(v, func(a Arc) (terminate bool) {
+			return !yield(a)
+		})
+	}
+}
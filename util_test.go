@@ -125,6 +125,77 @@ func (s *CollectionFunctorsSuite) TestCollectArcsTo(c *C) {
 	c.Assert(set.Has(NewArc("foo", "bar")), Equals, true)
 }
 
+// Tests for iterator adapters
+type IteratorSuite struct{}
+
+var _ = Suite(&IteratorSuite{})
+
+func (s *IteratorSuite) TestVerticesSeq(c *C) {
+	set := set.New(set.NonThreadSafe)
+	for v := range VerticesSeq(spec.GraphLiteralFixture(true)) {
+		set.Add(v)
+	}
+
+	c.Assert(set.Size(), Equals, 4)
+	c.Assert(set.Has("foo"), Equals, true)
+	c.Assert(set.Has("isolate"), Equals, true)
+
+	var hit int
+	for range VerticesSeq(spec.GraphLiteralFixture(true)) {
+		hit++
+		break
+	}
+	c.Assert(hit, Equals, 1)
+}
+
+func (s *IteratorSuite) TestAdjacencySeqs(c *C) {
+	g := spec.GraphLiteralFixture(true)
+
+	var adj []Vertex
+	for v := range AdjacentToSeq("bar", g) {
+		adj = append(adj, v)
+	}
+	c.Assert(len(adj), Equals, 2)
+
+	var succ, pred []Vertex
+	for v := range SuccessorsSeq("bar", g) {
+		succ = append(succ, v)
+	}
+	for v := range PredecessorsSeq("bar", g) {
+		pred = append(pred, v)
+	}
+	c.Assert(succ, DeepEquals, []Vertex{"baz"})
+	c.Assert(pred, DeepEquals, []Vertex{"foo"})
+}
+
+func (s *IteratorSuite) TestEdgeSeqs(c *C) {
+	g := spec.GraphLiteralFixture(true)
+
+	var edges, arcs int
+	for range EdgesSeq(g) {
+		edges++
+	}
+	for range ArcsSeq(g) {
+		arcs++
+	}
+	c.Assert(edges, Equals, 2)
+	c.Assert(arcs, Equals, 2)
+
+	var incident []Edge
+	for e := range IncidentToSeq("bar", g) {
+		incident = append(incident, e)
+		break
+	}
+	c.Assert(len(incident), Equals, 1)
+
+	for a := range ArcsFromSeq("foo", g) {
+		c.Assert(a, Equals, NewArc("foo", "bar"))
+	}
+	for a := range ArcsToSeq("bar", g) {
+		c.Assert(a, Equals, NewArc("foo", "bar"))
+	}
+}
+
 type CountingFunctorsSuite struct{}
 
 var _ = Suite(&CountingFunctorsSuite{})
@@ -0,0 +1,273 @@
+package view
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Wraps a graph in the filtered view matching its type. Either filter may be
+// nil, meaning everything passes.
+func wrap(g gogl.Graph, vf VertexFilter, ef EdgeFilter) gogl.Graph {
+	f := filtered{g: g, vf: vf, ef: ef}
+	if dg, ok := g.(gogl.Digraph); ok {
+		return filteredDigraph{f, dg}
+	}
+	return f
+}
+
+type filtered struct {
+	g  gogl.Graph
+	vf VertexFilter
+	ef EdgeFilter
+}
+
+func (g filtered) keepVertex(v gogl.Vertex) bool {
+	return g.vf == nil || g.vf(v)
+}
+
+func (g filtered) keepEdge(e gogl.Edge) bool {
+	u, v := e.Both()
+	return g.keepVertex(u) && g.keepVertex(v) && (g.ef == nil || g.ef(e))
+}
+
+// Returns an edge step that passes along only the edges the view keeps.
+func (g filtered) edgeStep(f gogl.EdgeStep) gogl.EdgeStep {
+	return func(e gogl.Edge) bool {
+		if !g.keepEdge(e) {
+			return false
+		}
+		return f(e)
+	}
+}
+
+// Returns an arc step that passes along only the arcs the view keeps.
+func (g filtered) arcStep(f gogl.ArcStep) gogl.ArcStep {
+	return func(a gogl.Arc) bool {
+		if !g.keepEdge(a) {
+			return false
+		}
+		return f(a)
+	}
+}
+
+func (g filtered) Vertices(f gogl.VertexStep) {
+	g.g.Vertices(func(v gogl.Vertex) bool {
+		if !g.keepVertex(v) {
+			return false
+		}
+		return f(v)
+	})
+}
+
+func (g filtered) Edges(f gogl.EdgeStep) {
+	g.g.Edges(g.edgeStep(f))
+}
+
+func (g filtered) IncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	if g.HasVertex(v) {
+		g.g.IncidentTo(v, g.edgeStep(f))
+	}
+}
+
+func (g filtered) AdjacentTo(start gogl.Vertex, f gogl.VertexStep) {
+	if !g.HasVertex(start) {
+		return
+	}
+
+	if g.ef == nil {
+		g.g.AdjacentTo(start, func(v gogl.Vertex) bool {
+			if !g.keepVertex(v) {
+				return false
+			}
+			return f(v)
+		})
+		return
+	}
+
+	g.IncidentTo(start, func(e gogl.Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		}
+		return f(u)
+	})
+}
+
+func (g filtered) HasVertex(v gogl.Vertex) bool {
+	return g.keepVertex(v) && g.g.HasVertex(v)
+}
+
+func (g filtered) HasEdge(e gogl.Edge) (exists bool) {
+	u, v := e.Both()
+	if !g.keepVertex(u) || !g.keepVertex(v) {
+		return false
+	}
+	if g.ef == nil {
+		return g.g.HasEdge(e)
+	}
+
+	// The filter may depend on more than the endpoints, so find the graph's own
+	// copy of the edge and ask about that.
+	g.IncidentTo(u, func(ie gogl.Edge) bool {
+		iu, iv := ie.Both()
+		exists = (iu == u && iv == v) || (iu == v && iv == u)
+		return exists
+	})
+	return
+}
+
+func (g filtered) DegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	if !g.HasVertex(v) {
+		return 0, false
+	}
+	if g.vf == nil && g.ef == nil {
+		return g.g.DegreeOf(v)
+	}
+
+	g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+		degree++
+		return
+	})
+	return degree, true
+}
+
+type filteredDigraph struct {
+	filtered
+	dg gogl.Digraph
+}
+
+// Enumerates arcs, rather than deferring to the digraph's Edges(), so that the
+// edge filter is only ever passed arcs.
+func (g filteredDigraph) Edges(f gogl.EdgeStep) {
+	g.dg.Arcs(g.arcStep(func(a gogl.Arc) bool {
+		return f(a)
+	}))
+}
+
+func (g filteredDigraph) IncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	if !g.HasVertex(v) {
+		return
+	}
+
+	var terminate bool
+	step := g.arcStep(func(a gogl.Arc) bool {
+		terminate = f(a)
+		return terminate
+	})
+
+	g.dg.ArcsFrom(v, step)
+	if !terminate {
+		g.dg.ArcsTo(v, step)
+	}
+}
+
+func (g filteredDigraph) AdjacentTo(start gogl.Vertex, f gogl.VertexStep) {
+	if !g.HasVertex(start) {
+		return
+	}
+
+	g.IncidentTo(start, func(e gogl.Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		}
+		return f(u)
+	})
+}
+
+func (g filteredDigraph) HasEdge(e gogl.Edge) bool {
+	u, v := e.Both()
+	return g.HasArc(gogl.NewArc(u, v)) || g.HasArc(gogl.NewArc(v, u))
+}
+
+func (g filteredDigraph) DegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	if !g.HasVertex(v) {
+		return 0, false
+	}
+
+	g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+		degree++
+		return
+	})
+	return degree, true
+}
+
+func (g filteredDigraph) Arcs(f gogl.ArcStep) {
+	g.dg.Arcs(g.arcStep(f))
+}
+
+func (g filteredDigraph) ArcsFrom(v gogl.Vertex, f gogl.ArcStep) {
+	if g.HasVertex(v) {
+		g.dg.ArcsFrom(v, g.arcStep(f))
+	}
+}
+
+func (g filteredDigraph) ArcsTo(v gogl.Vertex, f gogl.ArcStep) {
+	if g.HasVertex(v) {
+		g.dg.ArcsTo(v, g.arcStep(f))
+	}
+}
+
+func (g filteredDigraph) SuccessorsOf(v gogl.Vertex, f gogl.VertexStep) {
+	g.ArcsFrom(v, func(a gogl.Arc) bool {
+		return f(a.Target())
+	})
+}
+
+func (g filteredDigraph) PredecessorsOf(v gogl.Vertex, f gogl.VertexStep) {
+	g.ArcsTo(v, func(a gogl.Arc) bool {
+		return f(a.Source())
+	})
+}
+
+func (g filteredDigraph) InDegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	if !g.HasVertex(v) {
+		return 0, false
+	}
+
+	g.ArcsTo(v, func(a gogl.Arc) (terminate bool) {
+		degree++
+		return
+	})
+	return degree, true
+}
+
+func (g filteredDigraph) OutDegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	if !g.HasVertex(v) {
+		return 0, false
+	}
+
+	g.ArcsFrom(v, func(a gogl.Arc) (terminate bool) {
+		degree++
+		return
+	})
+	return degree, true
+}
+
+func (g filteredDigraph) HasArc(a gogl.Arc) (exists bool) {
+	if !g.keepVertex(a.Source()) || !g.keepVertex(a.Target()) {
+		return false
+	}
+	if g.ef == nil {
+		return g.dg.HasArc(a)
+	}
+
+	g.ArcsFrom(a.Source(), func(ia gogl.Arc) bool {
+		exists = ia.Target() == a.Target()
+		return exists
+	})
+	return
+}
+
+// Returns the same view of a lazy transpose of the digraph; see Transpose. The
+// edge filter is passed each arc of the transpose reversed, just as it appears
+// in this view.
+func (g filteredDigraph) Transpose() gogl.Digraph {
+	ef := g.ef
+	if ef != nil {
+		ef = func(e gogl.Edge) bool {
+			return g.ef(reverse(e.(gogl.Arc)))
+		}
+	}
+
+	return wrap(Transpose(g.dg), g.vf, ef).(gogl.Digraph)
+}
@@ -0,0 +1,118 @@
+package view
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// Returns a view of the transpose of a digraph: the same vertices, with every
+// arc reversed. Arcs keep their weights, labels and data.
+//
+// Creating the view takes constant time and memory, unlike the Transpose()
+// method of most Digraph implementations, which builds a reversed copy.
+// Transposing the view returns the original digraph.
+func Transpose(g gogl.Digraph) gogl.Digraph {
+	if t, ok := g.(transposed); ok {
+		return t.dg
+	}
+	return transposed{g}
+}
+
+type transposed struct {
+	dg gogl.Digraph
+}
+
+// Returns the reverse of the provided arc, preserving its properties.
+func reverse(a gogl.Arc) gogl.Arc {
+	return encoding.AttrsOf(a).Arc(a.Target(), a.Source())
+}
+
+// Returns an arc step that reverses each arc before passing it along.
+func reversing(f gogl.ArcStep) gogl.ArcStep {
+	return func(a gogl.Arc) bool {
+		return f(reverse(a))
+	}
+}
+
+func (g transposed) Vertices(f gogl.VertexStep) {
+	g.dg.Vertices(f)
+}
+
+func (g transposed) Edges(f gogl.EdgeStep) {
+	g.dg.Arcs(func(a gogl.Arc) bool {
+		return f(reverse(a))
+	})
+}
+
+func (g transposed) Arcs(f gogl.ArcStep) {
+	g.dg.Arcs(reversing(f))
+}
+
+func (g transposed) IncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	var terminate bool
+	step := func(a gogl.Arc) bool {
+		terminate = f(a)
+		return terminate
+	}
+
+	g.ArcsFrom(v, step)
+	if !terminate {
+		g.ArcsTo(v, step)
+	}
+}
+
+func (g transposed) AdjacentTo(v gogl.Vertex, f gogl.VertexStep) {
+	g.dg.AdjacentTo(v, f)
+}
+
+func (g transposed) ArcsFrom(v gogl.Vertex, f gogl.ArcStep) {
+	g.dg.ArcsTo(v, reversing(f))
+}
+
+func (g transposed) ArcsTo(v gogl.Vertex, f gogl.ArcStep) {
+	g.dg.ArcsFrom(v, reversing(f))
+}
+
+func (g transposed) SuccessorsOf(v gogl.Vertex, f gogl.VertexStep) {
+	g.dg.PredecessorsOf(v, f)
+}
+
+func (g transposed) PredecessorsOf(v gogl.Vertex, f gogl.VertexStep) {
+	g.dg.SuccessorsOf(v, f)
+}
+
+func (g transposed) HasVertex(v gogl.Vertex) bool {
+	return g.dg.HasVertex(v)
+}
+
+func (g transposed) HasEdge(e gogl.Edge) bool {
+	return g.dg.HasEdge(e)
+}
+
+func (g transposed) HasArc(a gogl.Arc) bool {
+	return g.dg.HasArc(gogl.NewArc(a.Target(), a.Source()))
+}
+
+func (g transposed) DegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	return g.dg.DegreeOf(v)
+}
+
+func (g transposed) InDegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	return g.dg.OutDegreeOf(v)
+}
+
+func (g transposed) OutDegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	return g.dg.InDegreeOf(v)
+}
+
+func (g transposed) Order() int {
+	return gogl.Order(g.dg)
+}
+
+func (g transposed) Size() int {
+	return gogl.Size(g.dg)
+}
+
+func (g transposed) Transpose() gogl.Digraph {
+	return g.dg
+}
@@ -0,0 +1,123 @@
+package view
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/encoding"
+)
+
+// Returns a view of the underlying undirected graph of a digraph: the same
+// vertices, with an undirected edge wherever the digraph has an arc in either
+// direction. A pair of opposing arcs becomes a single edge, carrying the
+// properties of one of them; which one is unspecified.
+//
+// Creating the view takes constant time and memory. Enumerating its edges
+// first collects the digraph's arcs, then checks each for an opposing arc
+// once that enumeration has returned, holding a set of the opposing pairs
+// seen so far to avoid passing them twice. Enumerating the edges or adjacent
+// vertices of a single vertex holds a set of its successors. The digraph is
+// thus never queried from within one of its own enumerations, which could
+// deadlock a graph that locks for reading while another goroutine writes.
+func Undirected(g gogl.Digraph) gogl.Graph {
+	return undirected{g}
+}
+
+type undirected struct {
+	dg gogl.Digraph
+}
+
+func (g undirected) Vertices(f gogl.VertexStep) {
+	g.dg.Vertices(f)
+}
+
+func (g undirected) Edges(f gogl.EdgeStep) {
+	// Only arcs with an opposite number need remembering; the second of each
+	// such pair is skipped.
+	mutual := make(map[[2]gogl.Vertex]struct{})
+
+	var arcs []gogl.Arc
+	g.dg.Arcs(func(a gogl.Arc) (terminate bool) {
+		arcs = append(arcs, a)
+		return
+	})
+
+	for _, a := range arcs {
+		u, v := a.Both()
+		if g.dg.HasArc(gogl.NewArc(v, u)) {
+			if _, seen := mutual[[2]gogl.Vertex{v, u}]; seen {
+				continue
+			}
+			mutual[[2]gogl.Vertex{u, v}] = struct{}{}
+		}
+
+		if f(encoding.AttrsOf(a).Edge(u, v)) {
+			return
+		}
+	}
+}
+
+// Passes each out-arc of the vertex as an edge, then each in-arc that has no
+// opposing out-arc.
+func (g undirected) IncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	var terminate bool
+	out := make(map[gogl.Vertex]struct{})
+	g.dg.ArcsFrom(v, func(a gogl.Arc) bool {
+		out[a.Target()] = struct{}{}
+		terminate = f(encoding.AttrsOf(a).Edge(a.Source(), a.Target()))
+		return terminate
+	})
+	if terminate {
+		return
+	}
+
+	g.dg.ArcsTo(v, func(a gogl.Arc) bool {
+		if _, opposed := out[a.Source()]; opposed {
+			return false
+		}
+		return f(encoding.AttrsOf(a).Edge(a.Source(), a.Target()))
+	})
+}
+
+func (g undirected) AdjacentTo(v gogl.Vertex, f gogl.VertexStep) {
+	var terminate bool
+	out := make(map[gogl.Vertex]struct{})
+	g.dg.SuccessorsOf(v, func(u gogl.Vertex) bool {
+		out[u] = struct{}{}
+		terminate = f(u)
+		return terminate
+	})
+	if terminate {
+		return
+	}
+
+	g.dg.PredecessorsOf(v, func(u gogl.Vertex) bool {
+		if _, opposed := out[u]; opposed {
+			return false
+		}
+		return f(u)
+	})
+}
+
+func (g undirected) HasVertex(v gogl.Vertex) bool {
+	return g.dg.HasVertex(v)
+}
+
+func (g undirected) HasEdge(e gogl.Edge) bool {
+	u, v := e.Both()
+	return g.dg.HasArc(gogl.NewArc(u, v)) || g.dg.HasArc(gogl.NewArc(v, u))
+}
+
+func (g undirected) DegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	if !g.dg.HasVertex(v) {
+		return 0, false
+	}
+
+	g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+		degree++
+		return
+	})
+	return degree, true
+}
+
+func (g undirected) Order() int {
+	return gogl.Order(g.dg)
+}
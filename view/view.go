@@ -0,0 +1,90 @@
+// Provides lazy views of graphs: wrappers that present part of an underlying
+// graph, or the graph transposed or made undirected, as a Graph (or Digraph)
+// in its own right, without copying it.
+//
+// Views hold no vertices or edges of their own. Every method is answered by
+// consulting the underlying graph and applying the view's filter or
+// transformation, so a view
+// always reflects the graph's current state, and its enumerators, membership
+// checks and degree counts always agree with one another. The price is that
+// queries may be slower than on the underlying graph; DegreeOf, for example,
+// must enumerate the incident edges of a filtered vertex to count them.
+//
+// Views of a Digraph are themselves Digraphs. On digraphs, edge filters are
+// always passed Arcs.
+package view
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// A VertexFilter reports whether a vertex belongs in a view.
+type VertexFilter func(gogl.Vertex) bool
+
+// An EdgeFilter reports whether an edge belongs in a view.
+type EdgeFilter func(gogl.Edge) bool
+
+// Returns a VertexFilter accepting exactly the provided vertices.
+func Set(vertices ...gogl.Vertex) VertexFilter {
+	set := make(map[gogl.Vertex]struct{}, len(vertices))
+	for _, v := range vertices {
+		set[v] = struct{}{}
+	}
+
+	return func(v gogl.Vertex) bool {
+		_, exists := set[v]
+		return exists
+	}
+}
+
+// Returns a view of the subgraph of g induced by the vertices accepted by the
+// filter: those vertices, and every edge of g connecting two of them.
+func Induced(g gogl.Graph, keep VertexFilter) gogl.Graph {
+	return wrap(g, keep, nil)
+}
+
+// Returns a view of g containing all its vertices, but only those edges
+// accepted by the filter.
+func EdgeFiltered(g gogl.Graph, keep EdgeFilter) gogl.Graph {
+	return wrap(g, nil, keep)
+}
+
+// Returns a view of the ego network of radius k around the center vertex: the
+// subgraph induced by the vertices within k steps of it. In a digraph, steps
+// follow arcs forward, from a vertex to its successors; to follow them in
+// either direction, take the ego network of Undirected(g) instead.
+//
+// Unlike other views, the membership of an ego network is determined once, by
+// a breadth-first search when the view is created; it holds a set of the
+// vertices found, but no edges. If the center is not in g, the view is empty.
+func Ego(g gogl.Graph, center gogl.Vertex, k int) gogl.Graph {
+	if !g.HasVertex(center) {
+		return Induced(g, func(gogl.Vertex) bool { return false })
+	}
+
+	next := g.AdjacentTo
+	if dg, ok := g.(gogl.Digraph); ok {
+		next = dg.SuccessorsOf
+	}
+
+	set := map[gogl.Vertex]struct{}{center: struct{}{}}
+	frontier := []gogl.Vertex{center}
+	for i := 0; i < k && len(frontier) > 0; i++ {
+		var found []gogl.Vertex
+		for _, u := range frontier {
+			next(u, func(v gogl.Vertex) (terminate bool) {
+				if _, seen := set[v]; !seen {
+					set[v] = struct{}{}
+					found = append(found, v)
+				}
+				return
+			})
+		}
+		frontier = found
+	}
+
+	return Induced(g, func(v gogl.Vertex) bool {
+		_, exists := set[v]
+		return exists
+	})
+}
@@ -0,0 +1,299 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/gen"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/spec"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+func init() {
+	// Views that filter nothing must behave exactly like the graphs they wrap.
+	for _, gp := range []gogl.GraphProperties{
+		gogl.Spec().Props,
+		gogl.Spec().Directed().Props,
+	} {
+		spec.SetUpTestsFromSpec(gp, func(gs gogl.GraphSpec) gogl.Graph {
+			return Induced(al.G(gs), func(gogl.Vertex) bool { return true })
+		})
+		spec.SetUpTestsFromSpec(gp, func(gs gogl.GraphSpec) gogl.Graph {
+			return EdgeFiltered(al.G(gs), func(gogl.Edge) bool { return true })
+		})
+	}
+
+	dgp := gogl.Spec().Directed().Props
+
+	// A transposed view of a digraph built from reversed fixtures must behave
+	// just like a digraph built from the fixtures themselves.
+	spec.SetUpTestsFromSpec(dgp, func(gs gogl.GraphSpec) gogl.Graph {
+		if gs.Source != nil {
+			gs.Source = reversed{gs.Source.(gogl.DigraphSource)}
+		}
+		return Transpose(al.G(gs).(gogl.Digraph))
+	})
+
+	// The underlying undirected view of a digraph must behave like an
+	// undirected graph built from the same fixtures.
+	spec.SetUpTestsFromSpec(dgp, func(gs gogl.GraphSpec) gogl.Graph {
+		return Undirected(al.G(gs).(gogl.Digraph))
+	})
+}
+
+// A DigraphSource with all its arcs reversed.
+type reversed struct {
+	gogl.DigraphSource
+}
+
+func (r reversed) Edges(f gogl.EdgeStep) {
+	r.Arcs(func(a gogl.Arc) bool {
+		return f(a)
+	})
+}
+
+func (r reversed) Arcs(f gogl.ArcStep) {
+	r.DigraphSource.Arcs(func(a gogl.Arc) bool {
+		return f(gogl.NewArc(a.Target(), a.Source()))
+	})
+}
+
+type ViewSuite struct{}
+
+var _ = Suite(&ViewSuite{})
+
+func build(src gogl.GraphSource, directed bool) gogl.Graph {
+	b := gogl.Spec().Weighted()
+	if directed {
+		b = b.Directed()
+	}
+	return b.Using(src).Create(al.G)
+}
+
+func (s *ViewSuite) TestInduced(c *C) {
+	g := Induced(build(gen.Complete(5), false), Set(0, 1, 2, "missing"))
+
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(g.HasVertex(3), Equals, false)
+	c.Assert(g.HasVertex("missing"), Equals, false)
+	c.Assert(g.HasEdge(gogl.NewEdge(0, 1)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(0, 3)), Equals, false)
+
+	d, exists := g.DegreeOf(0)
+	c.Assert(exists, Equals, true)
+	c.Assert(d, Equals, 2)
+	_, exists = g.DegreeOf(4)
+	c.Assert(exists, Equals, false)
+
+	var adj []gogl.Vertex
+	g.AdjacentTo(1, func(v gogl.Vertex) (terminate bool) {
+		adj = append(adj, v)
+		return
+	})
+	c.Assert(len(adj), Equals, 2)
+
+	var hit int
+	g.IncidentTo(3, func(e gogl.Edge) (terminate bool) {
+		hit++
+		return
+	})
+	c.Assert(hit, Equals, 0)
+}
+
+func (s *ViewSuite) TestEdgeFiltered(c *C) {
+	heavy := func(e gogl.Edge) bool {
+		return e.(gogl.WeightedEdge).Weight() > 2
+	}
+	src := gen.Path(5).Weighted(func(u, v int) float64 { return float64(u + v) })
+	g := EdgeFiltered(build(src, false), heavy)
+
+	c.Assert(gogl.Order(g), Equals, 5)
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(g.HasEdge(gogl.NewEdge(0, 1)), Equals, false)
+	c.Assert(g.HasEdge(gogl.NewEdge(2, 1)), Equals, true)
+
+	d, _ := g.DegreeOf(1)
+	c.Assert(d, Equals, 1)
+	d, exists := g.DegreeOf(0)
+	c.Assert(exists, Equals, true)
+	c.Assert(d, Equals, 0)
+
+	g.AdjacentTo(1, func(v gogl.Vertex) (terminate bool) {
+		c.Assert(v, Equals, 2)
+		return
+	})
+}
+
+func (s *ViewSuite) TestFilteredDigraph(c *C) {
+	src := gen.Cycle(4).Directed().Weighted(func(u, v int) float64 { return float64(u) })
+	dg := build(src, true).(gogl.Digraph)
+
+	g := EdgeFiltered(dg, func(e gogl.Edge) bool {
+		c.Assert(e, Implements, new(gogl.Arc))
+		return e.(gogl.Arc).Source() != 0
+	}).(gogl.Digraph)
+
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(g.HasArc(gogl.NewArc(0, 1)), Equals, false)
+	c.Assert(g.HasArc(gogl.NewArc(1, 2)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(2, 1)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(1, 0)), Equals, false)
+
+	out, _ := g.OutDegreeOf(0)
+	in, _ := g.InDegreeOf(0)
+	c.Assert(out, Equals, 0)
+	c.Assert(in, Equals, 1)
+
+	g.SuccessorsOf(0, func(v gogl.Vertex) (terminate bool) {
+		c.Error("Vertex 0 should have no successors")
+		return
+	})
+
+	// The filter sees the transpose's arcs as they appear in the view
+	t := g.Transpose()
+	c.Assert(gogl.Size(t), Equals, 3)
+	c.Assert(t.HasArc(gogl.NewArc(1, 0)), Equals, false)
+	c.Assert(t.HasArc(gogl.NewArc(0, 3)), Equals, true)
+	c.Assert(t.HasArc(gogl.NewArc(2, 1)), Equals, true)
+}
+
+func (s *ViewSuite) TestEgo(c *C) {
+	g := build(gen.Path(7), false)
+
+	e := Ego(g, 3, 2)
+	c.Assert(gogl.Order(e), Equals, 5)
+	c.Assert(gogl.Size(e), Equals, 4)
+	c.Assert(e.HasVertex(0), Equals, false)
+	c.Assert(e.HasVertex(5), Equals, true)
+
+	c.Assert(gogl.Order(Ego(g, 3, 0)), Equals, 1)
+	c.Assert(gogl.Order(Ego(g, "missing", 3)), Equals, 0)
+
+	// In digraphs, only successors are reached
+	dg := build(gen.Path(7).Directed(), true)
+	e = Ego(dg, 3, 2)
+	c.Assert(gogl.Order(e), Equals, 3)
+	c.Assert(e.HasVertex(2), Equals, false)
+	c.Assert(e, Implements, new(gogl.Digraph))
+}
+
+func (s *ViewSuite) TestNoCopy(c *C) {
+	g := gogl.Spec().Using(gen.Path(3)).Create(al.G)
+	v := Induced(g, Set(0, 1, 2, 3))
+
+	c.Assert(gogl.Size(v), Equals, 2)
+	g.(gogl.MutableGraph).AddEdges(gogl.NewEdge(2, 3))
+	c.Assert(gogl.Size(v), Equals, 3)
+	c.Assert(v.HasVertex(3), Equals, true)
+}
+
+func (s *ViewSuite) TestTranspose(c *C) {
+	src := gen.Path(3).Directed().Weighted(func(u, v int) float64 { return float64(u + 1) })
+	dg := build(src, true).(gogl.Digraph)
+	t := Transpose(dg)
+
+	c.Assert(t.HasArc(gogl.NewArc(1, 0)), Equals, true)
+	c.Assert(t.HasArc(gogl.NewArc(0, 1)), Equals, false)
+	c.Assert(Transpose(t), Equals, dg)
+	c.Assert(t.Transpose(), Equals, dg)
+
+	t.ArcsFrom(2, func(a gogl.Arc) (terminate bool) {
+		c.Assert(a.Target(), Equals, 1)
+		c.Assert(a.(gogl.WeightedArc).Weight(), Equals, float64(2))
+		return
+	})
+
+	in, _ := t.InDegreeOf(0)
+	out, _ := t.OutDegreeOf(0)
+	c.Assert(in, Equals, 1)
+	c.Assert(out, Equals, 0)
+
+	// Views are live
+	dg.(gogl.WeightedArcSetMutator).AddArcs(gogl.NewWeightedArc(2, 0, 5))
+	c.Assert(t.HasArc(gogl.NewArc(0, 2)), Equals, true)
+}
+
+func (s *ViewSuite) TestUndirected(c *C) {
+	dg := build(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 1),
+		gogl.NewWeightedArc(2, 1, 2),
+		gogl.NewWeightedArc(2, 3, 3),
+	}, true).(gogl.Digraph)
+	g := Undirected(dg)
+
+	c.Assert(g, Not(Implements), new(gogl.Digraph))
+	c.Assert(gogl.Size(g), Equals, 2)
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e, Not(Implements), new(gogl.Arc))
+		c.Assert(e, Implements, new(gogl.WeightedEdge))
+		return
+	})
+
+	c.Assert(g.HasEdge(gogl.NewEdge(3, 2)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(1, 3)), Equals, false)
+
+	d, _ := g.DegreeOf(2)
+	c.Assert(d, Equals, 2)
+	d, _ = g.DegreeOf(1)
+	c.Assert(d, Equals, 1)
+
+	var adj []gogl.Vertex
+	g.AdjacentTo(2, func(v gogl.Vertex) (terminate bool) {
+		adj = append(adj, v)
+		return
+	})
+	c.Assert(len(adj), Equals, 2)
+
+	// Ego networks can follow arcs backwards through the undirected view
+	c.Assert(gogl.Order(Ego(g, 3, 1)), Equals, 2)
+	c.Assert(gogl.Order(Ego(dg, 3, 1)), Equals, 1)
+}
+
+// Enumerating the view must not query the digraph from within its own
+// enumerations, or a waiting writer would deadlock it.
+func (s *ViewSuite) TestUndirectedConcurrentWrites(c *C) {
+	dg := gogl.Spec().Directed().Create(al.G).(gogl.Digraph)
+	m := dg.(gogl.ArcSetMutator)
+	for i := 0; i < 50; i++ {
+		m.AddArcs(gogl.NewArc(i, i+1), gogl.NewArc(i+1, i))
+	}
+	g := Undirected(dg)
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				m.AddArcs(gogl.NewArc(0, 100))
+				m.RemoveArcs(gogl.NewArc(0, 100))
+			}
+		}
+	}()
+
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		for i := 0; i < 500; i++ {
+			gogl.Size(g)
+			g.DegreeOf(i % 50)
+			g.AdjacentTo(i%50, func(gogl.Vertex) (terminate bool) { return })
+		}
+	}()
+
+	select {
+	case <-read:
+	case <-time.After(10 * time.Second):
+		c.Fatal("Enumerating the view deadlocked with a concurrent writer.")
+	}
+	close(stop)
+	<-done
+}